@@ -0,0 +1,48 @@
+// Command badges awards the badges of the rules to existing users. Run it
+// after adding rules, since the engine only evaluates new events.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/behummble/Questions-answers/internal/badges"
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/storage/postgres"
+	"github.com/joho/godotenv"
+)
+
+const batchSize = 500
+
+func main() {
+	ctx, stop := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+	defer stop()
+	godotenv.Load("./.env")
+	cfg := config.MustLoad()
+	log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(cfg.Log.Level)}))
+	storage := postgres.NewStorage(ctx, log, cfg.Storage)
+	defer storage.Shutdown(ctx)
+
+	rules, err := badges.LoadRules(cfg.Badges.RulesPath)
+	if err != nil {
+		panic(err)
+	}
+	engine := badges.NewEngine(log, rules, storage)
+	if err := engine.Sync(ctx); err != nil {
+		panic(err)
+	}
+	checked, err := engine.Backfill(ctx, batchSize)
+	if err != nil {
+		log.Error("BadgesBackfillError", slog.String("component", "badges"), slog.Any("error", err))
+		os.Exit(1)
+	}
+	log.Info(fmt.Sprintf("Backfill badges for %d users", checked))
+}
@@ -2,16 +2,30 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"log/slog"
+	nethttp "net/http"
 	"os"
 	"os/signal"
 	"time"
 	"syscall"
 
+	"github.com/behummble/Questions-answers/internal/attachments"
+	"github.com/behummble/Questions-answers/internal/badges"
 	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/digest"
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/handlers/graphql"
+	grpcserver "github.com/behummble/Questions-answers/internal/handlers/grpc"
 	"github.com/behummble/Questions-answers/internal/handlers/http"
+	"github.com/behummble/Questions-answers/internal/jobs"
+	"github.com/behummble/Questions-answers/internal/notifications"
+	"github.com/behummble/Questions-answers/internal/reputation"
 	"github.com/behummble/Questions-answers/internal/service"
+	"github.com/behummble/Questions-answers/internal/storage/cache"
 	"github.com/behummble/Questions-answers/internal/storage/postgres"
+	"github.com/behummble/Questions-answers/internal/views"
+	"github.com/behummble/Questions-answers/internal/webhooks"
 	"github.com/joho/godotenv"
 )
 
@@ -27,21 +41,243 @@ func main() {
 	setEnv()
 	cfg := config.MustLoad()
 	log := newLog(cfg.Log)
+	limitStreams(log, &cfg.Server.Streams, cfg.Storage)
 	storage := postgres.NewStorage(ctx, log, cfg.Storage)
+	questionStorage, answerStorage, userStorage, viewStorage := withCache(log, cfg.Cache, storage)
 	log.Info("DB connected")
-	service := service.NewService(log, storage, storage)
-	server := http.NewServer(ctx, log, &cfg.Server, service)
+	hub := events.NewHub(cfg.Events.BufferSize, cfg.Events.SubscriberBuffer)
+	webhookManager := webhooks.NewManager(log, storage)
+	webhookDispatcher := webhooks.NewDispatcher(log, cfg.Webhooks, storage, nil)
+	eventDispatcher := events.NewDispatcher(log, storage, cfg.Events)
+	eventDispatcher.SubscribeLocal("hub", hub.Handle)
+	scheduler := jobs.NewScheduler(log, storage)
+	ledger := reputation.NewLedger(log, cfg.Reputation, storage)
+	if cfg.Reputation.RecomputeOnStart {
+		ledger.Recompute(ctx)
+	}
+	eventDispatcher.Subscribe("reputation", ledger.Handle)
+	// Badges are evaluated after the ledger, so reputation rules see the
+	// total that includes the event.
+	var badgeEngine http.Badges
+	var engine *badges.Engine
+	if cfg.Badges.Enabled {
+		engine = newBadges(ctx, log, cfg.Badges, storage)
+		eventDispatcher.Subscribe("badges", engine.Handle)
+		badgeEngine = engine
+	}
+	var serverAttachments http.Attachments
+	if cfg.Attachments.Enabled {
+		manager := newAttachments(log, cfg.Attachments, storage)
+		eventDispatcher.Subscribe("attachments", manager.Handle)
+		// The sweep also retries blobs whose delete failed after an event.
+		addJob(scheduler, cfg.Jobs, jobs.Job{Name: "attachments_sweep", Schedule: "@hourly", Run: manager.Sweep})
+		serverAttachments = manager
+	}
+	var sender *digest.Sender
+	var verifier notifications.Verifier
+	if cfg.Digest.Enabled {
+		sender = newDigest(log, cfg.Digest, storage)
+		verifier = sender
+	}
+	var serverNotifications http.Notifications
+	if cfg.Notifications.Enabled {
+		inbox := notifications.NewInbox(log, storage, verifier)
+		eventDispatcher.Subscribe("notifications", inbox.Handle)
+		serverNotifications = inbox
+	}
+	if cfg.Webhooks.Enabled {
+		eventDispatcher.Subscribe("webhooks", webhookDispatcher.Handle)
+		go webhookDispatcher.Run(ctx)
+		log.Info("Webhook dispatcher is Up")
+	}
+	go eventDispatcher.Run(ctx)
+	addJob(scheduler, cfg.Jobs, jobs.Job{
+		Name: "outbox_purge",
+		Schedule: "@daily",
+		Run: func(ctx context.Context) error {
+			_, err := storage.PurgeOutbox(ctx, time.Now().Add(-cfg.Jobs.OutboxRetention))
+			return err
+		},
+	})
+	var viewCounter *views.Counter
+	var serverViews http.Views
+	if cfg.Views.Enabled {
+		viewCounter = views.NewCounter(log, cfg.Views, viewStorage)
+		if engine != nil {
+			viewCounter.Subscribe(engine.QuestionsViewed)
+		}
+		addJob(scheduler, cfg.Jobs, jobs.Job{
+			Name: "views_flush",
+			Schedule: "@every " + cfg.Views.FlushInterval.String(),
+			Local: true,
+			Run: viewCounter.Flush,
+		})
+		serverViews = viewCounter
+	}
+	if sender != nil {
+		addJob(scheduler, cfg.Jobs, jobs.Job{
+			Name: "digest",
+			Schedule: "@every " + cfg.Digest.CheckInterval.String(),
+			Run: func(ctx context.Context) error {
+				_, err := sender.Send(ctx)
+				return err
+			},
+		})
+	}
+	storage.Reserve(scheduler.Locks())
+	expvar.Publish("jobs", expvar.Func(func() any { return scheduler.Jobs() }))
+	go scheduler.Run(ctx)
+	log.Info("Jobs are Up")
+	service := service.NewService(log, questionStorage, answerStorage, userStorage, storage, cfg.Reputation, cfg.Duplicates, eventDispatcher)
+	var graphqlHandler nethttp.Handler
+	if cfg.GraphQL.Enabled {
+		graphqlHandler = newGraphQL(log, &cfg.GraphQL, service)
+	}
+	server := http.NewServer(ctx, log, &cfg.Server, service, http.Deps{
+		Events: hub,
+		Webhooks: webhookManager,
+		Badges: badgeEngine,
+		Views: serverViews,
+		Attachments: serverAttachments,
+		Notifications: serverNotifications,
+		Jobs: scheduler,
+		GraphQL: graphqlHandler,
+	})
 	go server.Start()
 	log.Info("Server is Up")
+	var grpcServer *grpcserver.Server
+	if cfg.GRPC.Enabled {
+		grpcServer = newGRPC(log, &cfg.GRPC, service)
+		go grpcServer.Start()
+		log.Info("gRPC server is Up", slog.Int("port", cfg.GRPC.Port))
+	}
 	<- ctx.Done()
 	shutdownContext, cancel := context.WithTimeout(context.Background(), 10 * time.Second)
 	defer cancel()
 	server.Shutdown(shutdownContext)
+	hub.Close()
 	log.Info("Server is Down")
+	if grpcServer != nil {
+		grpcServer.Shutdown(shutdownContext)
+		log.Info("gRPC server is Down")
+	}
+	scheduler.Shutdown(shutdownContext)
+	log.Info("Jobs are Down")
+	eventDispatcher.Shutdown(shutdownContext)
+	if viewCounter != nil {
+		viewCounter.Flush(shutdownContext)
+	}
+	if cfg.Webhooks.Enabled {
+		webhookDispatcher.Shutdown(shutdownContext)
+		log.Info("Webhook dispatcher is Down")
+	}
 	service.Shutdown(shutdownContext)
 	log.Info("DB is Down")
 }
 
+// withCache wraps the storages whose writes make cached questions and
+// answers stale. The reputation ledger is not among them, see cache.Storage.
+func withCache(log *slog.Logger, cfg config.CacheConfig, storage *postgres.Storage) (service.StorageQuestion, service.StorageAnswer, service.StorageUser, views.Storage) {
+	if !cfg.Enabled {
+		return storage, storage, storage, storage
+	}
+	backend, err := cache.NewBackend(cfg)
+	if err != nil {
+		panic(err)
+	}
+	cached := cache.NewStorage(log, backend, cfg.TTL, storage, storage, storage)
+	expvar.Publish("storage_cache", expvar.Func(func() any { return cached.Stats() }))
+	log.Info("Cache enabled", slog.String("backend", cfg.Backend))
+
+	return cached, cached, cached, cache.NewViews(cached, storage)
+}
+
+// limitStreams keeps the NDJSON streams, each holding a database
+// connection for as long as it lasts, to half of the pool, so they cannot
+// leave the other requests without a connection.
+func limitStreams(log *slog.Logger, cfg *config.StreamsConfig, storage config.StorageConfig) {
+	limit := storage.PoolSize / 2
+	if storage.PoolSize <= 0 || cfg.MaxConcurrent > 0 && cfg.MaxConcurrent <= limit {
+		return
+	}
+	log.Warn(
+		"Concurrent streams lowered below the database pool",
+		slog.Int("max_concurrent", cfg.MaxConcurrent),
+		slog.Int("pool_size", storage.PoolSize),
+	)
+	cfg.MaxConcurrent = max(limit, 1)
+}
+
+func newBadges(ctx context.Context, log *slog.Logger, cfg config.BadgesConfig, storage badges.Storage) *badges.Engine {
+	rules, err := badges.LoadRules(cfg.RulesPath)
+	if err != nil {
+		panic(err)
+	}
+	engine := badges.NewEngine(log, rules, storage)
+	if err := engine.Sync(ctx); err != nil {
+		panic(err)
+	}
+
+	return engine
+}
+
+func newAttachments(log *slog.Logger, cfg config.AttachmentsConfig, storage attachments.Storage) *attachments.Manager {
+	blobs, err := attachments.NewBlobs(cfg)
+	if err != nil {
+		panic(err)
+	}
+	manager, err := attachments.NewManager(log, cfg, storage, blobs)
+	if err != nil {
+		panic(err)
+	}
+	log.Info("Attachments enabled", slog.String("backend", cfg.Backend))
+
+	return manager
+}
+
+// addJob adds the job with the schedule configured for it, if any, unless
+// it is configured "off".
+func addJob(scheduler *jobs.Scheduler, cfg config.JobsConfig, job jobs.Job) {
+	if schedule, ok := cfg.Schedules[job.Name]; ok {
+		job.Schedule = schedule
+	}
+	if job.Schedule == "off" {
+		return
+	}
+	if err := scheduler.Add(job); err != nil {
+		panic(err)
+	}
+}
+
+func newDigest(log *slog.Logger, cfg config.DigestConfig, storage digest.Storage) *digest.Sender {
+	mailer, err := digest.NewMailer(cfg)
+	if err != nil {
+		panic(err)
+	}
+	log.Info("Digests enabled", slog.String("mailer", cfg.Mailer))
+
+	return digest.NewSender(log, cfg, cfg.SMTP.From, storage, mailer)
+}
+
+func newGraphQL(log *slog.Logger, cfg *config.GraphQLConfig, service graphql.Service) nethttp.Handler {
+	handler, err := graphql.NewHandler(log, cfg, service)
+	if err != nil {
+		panic(err)
+	}
+	log.Info("GraphQL enabled", slog.Bool("allowlist_only", cfg.AllowlistOnly))
+
+	return handler
+}
+
+func newGRPC(log *slog.Logger, cfg *config.GRPCConfig, service grpcserver.Service) *grpcserver.Server {
+	server, err := grpcserver.NewServer(log, cfg, service)
+	if err != nil {
+		panic(err)
+	}
+
+	return server
+}
+
 func newLog(config config.LogConfig) *slog.Logger {
 	var output *os.File
 	if config.Path != "" {
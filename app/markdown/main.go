@@ -0,0 +1,94 @@
+// Command markdown renders the HTML of the questions and answers written
+// before Markdown support. New texts are rendered when they are written.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/markdown"
+	"github.com/behummble/Questions-answers/internal/storage/cache"
+	"github.com/behummble/Questions-answers/internal/storage/postgres"
+	"github.com/joho/godotenv"
+)
+
+const batchSize = 500
+
+func main() {
+	ctx, stop := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+	defer stop()
+	godotenv.Load("./.env")
+	cfg := config.MustLoad()
+	log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(cfg.Log.Level)}))
+	storage := postgres.NewStorage(ctx, log, cfg.Storage)
+	contents, shutdown := withCache(log, cfg.Cache, storage)
+	defer shutdown(ctx)
+
+	for _, table := range []string{"questions", "answers"} {
+		rendered, err := backfill(ctx, contents, table)
+		if err != nil {
+			log.Error("MarkdownBackfillError", slog.String("component", "markdown"), slog.Any("error", err))
+			os.Exit(1)
+		}
+		log.Info(fmt.Sprintf("Render %d %s", rendered, table))
+	}
+}
+
+// withCache passes the backfill through a Redis cache shared with the
+// servers, so they do not serve the questions and answers it renders
+// without HTML. The memory cache of a server is out of its reach and keeps
+// them until the TTL.
+func withCache(log *slog.Logger, cfg config.CacheConfig, storage *postgres.Storage) (cache.ContentStorage, func(context.Context)) {
+	if !cfg.Enabled {
+		return storage, storage.Shutdown
+	}
+	if cfg.Backend != "redis" {
+		log.Warn(
+			"CacheNotInvalidated",
+			slog.String("component", "markdown"),
+			slog.Duration("ttl", cfg.TTL),
+		)
+		return storage, storage.Shutdown
+	}
+	backend, err := cache.NewBackend(cfg)
+	if err != nil {
+		panic(err)
+	}
+	cached := cache.NewStorage(log, backend, cfg.TTL, storage, storage, storage)
+
+	return cache.NewContents(cached, storage), cached.Shutdown
+}
+
+func backfill(ctx context.Context, storage cache.ContentStorage, table string) (int, error) {
+	var rendered, afterID int
+	for {
+		contents, err := storage.UnrenderedContents(ctx, table, afterID, batchSize)
+		if err != nil {
+			return rendered, err
+		}
+		for i := range contents {
+			html, err := markdown.Render(contents[i].Text)
+			if err != nil {
+				return rendered, err
+			}
+			contents[i].TextHTML = html
+		}
+		if err := storage.SaveRenderedContents(ctx, table, contents); err != nil {
+			return rendered, err
+		}
+		rendered += len(contents)
+		if len(contents) < batchSize {
+			return rendered, nil
+		}
+		afterID = contents[len(contents) - 1].ID
+	}
+}
@@ -0,0 +1,310 @@
+// Package attachments stores files attached to questions and answers and
+// serves them through signed, expiring URLs.
+package attachments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+var (
+	ErrInvalidAttachment = errors.New("InvalidAttachment")
+	ErrAttachmentTooLarge = errors.New("AttachmentTooLarge")
+	ErrUnsupportedType = errors.New("UnsupportedAttachmentType")
+	ErrInvalidSignature = errors.New("InvalidSignature")
+)
+
+const (
+	maxFilenameLength = 255
+	sweepBatchSize = 100
+)
+
+var userIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type Storage interface {
+	// CreateAttachment returns gorm.ErrRecordNotFound when the parent
+	// question or answer does not exist.
+	CreateAttachment(ctx context.Context, data *models.Attachment) error
+	Attachment(ctx context.Context, id int) (models.Attachment, error)
+	QuestionAttachments(ctx context.Context, questionID int) ([]models.Attachment, error)
+	AnswerAttachments(ctx context.Context, answerID int) ([]models.Attachment, error)
+	// OrphanedAttachments returns attachments whose parent was deleted.
+	OrphanedAttachments(ctx context.Context, limit int) ([]models.Attachment, error)
+	DeleteAttachments(ctx context.Context, ids []int) error
+}
+
+// Manager handles uploads and downloads of attachments. Handle is
+// subscribed to the events dispatcher and deletes the blobs of attachments
+// whose parent was deleted.
+type Manager struct {
+	log *slog.Logger
+	storage Storage
+	blobs Blobs
+	maxSize int64
+	allowedTypes []string
+	urlTTL time.Duration
+	signingKey []byte
+	now func() time.Time
+}
+
+// NewManager fails without a signing key: a random one would invalidate the
+// download URLs on every restart and differ between instances.
+func NewManager(log *slog.Logger, cfg config.AttachmentsConfig, storage Storage, blobs Blobs) (*Manager, error) {
+	if cfg.SigningKey == "" {
+		return nil, errors.New("attachments signing key is not set")
+	}
+
+	return &Manager{
+		log: log,
+		storage: storage,
+		blobs: blobs,
+		maxSize: cfg.MaxSize,
+		allowedTypes: cfg.AllowedTypes,
+		urlTTL: cfg.URLTTL,
+		signingKey: []byte(cfg.SigningKey),
+		now: time.Now,
+	}, nil
+}
+
+// NewBlobs returns the blob store selected by the config.
+func NewBlobs(cfg config.AttachmentsConfig) (Blobs, error) {
+	switch cfg.Backend {
+	case "", "local":
+		return NewLocal(cfg.Local.Path)
+	case "s3":
+		return NewS3(cfg.S3)
+	}
+
+	return nil, fmt.Errorf("unknown attachments backend %q", cfg.Backend)
+}
+
+// MaxSize is the largest file accepted, in bytes.
+func(m *Manager) MaxSize() int64 {
+	return m.maxSize
+}
+
+// Upload stores the file and attaches it to attachment.QuestionID or
+// attachment.AnswerID. The content type is sniffed from the file rather
+// than taken from the client.
+func(m *Manager) Upload(ctx context.Context, attachment models.Attachment, body io.Reader) (models.CreateAttachmentResponse, error) {
+	if !userIDPattern.MatchString(attachment.UserID) {
+		return models.CreateAttachmentResponse{}, fmt.Errorf("%w: invalid user", ErrInvalidAttachment)
+	}
+	if (attachment.QuestionID == nil) == (attachment.AnswerID == nil) {
+		return models.CreateAttachmentResponse{}, fmt.Errorf("%w: exactly one parent is required", ErrInvalidAttachment)
+	}
+	data, err := io.ReadAll(io.LimitReader(body, m.maxSize + 1))
+	if err != nil {
+		return models.CreateAttachmentResponse{}, fmt.Errorf("%w: %v", ErrInvalidAttachment, err)
+	}
+	if int64(len(data)) > m.maxSize {
+		return models.CreateAttachmentResponse{}, fmt.Errorf("%w: larger than %d bytes", ErrAttachmentTooLarge, m.maxSize)
+	}
+	if len(data) == 0 {
+		return models.CreateAttachmentResponse{}, fmt.Errorf("%w: empty file", ErrInvalidAttachment)
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !slices.Contains(m.allowedTypes, contentType) {
+		return models.CreateAttachmentResponse{}, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+	key, err := newBlobKey()
+	if err != nil {
+		return models.CreateAttachmentResponse{}, err
+	}
+	attachment.ID = 0
+	attachment.Filename = cleanFilename(attachment.Filename)
+	attachment.ContentType = contentType
+	attachment.Size = int64(len(data))
+	attachment.BlobKey = key
+
+	// The row goes first: a blob without a row would never be deleted,
+	// while a row without a blob only fails its downloads.
+	if err := m.storage.CreateAttachment(ctx, &attachment); err != nil {
+		m.logError("DB_WritingError", "db", err)
+		return models.CreateAttachmentResponse{}, err
+	}
+	if err := m.blobs.Put(ctx, key, contentType, data); err != nil {
+		m.logError("BlobWritingError", "blobs", err)
+		if err := m.storage.DeleteAttachments(context.WithoutCancel(ctx), []int{attachment.ID}); err != nil {
+			m.logError("DB_WritingError", "db", err)
+		}
+		return models.CreateAttachmentResponse{}, errors.New("BlobWritingError")
+	}
+	m.log.Info(fmt.Sprintf("Attachment with id: %d uploaded", attachment.ID))
+	m.sign(&attachment)
+
+	return models.CreateAttachmentResponse{Attachment: attachment}, nil
+}
+
+func(m *Manager) QuestionAttachments(ctx context.Context, id int) (models.GetAttachmentsResponse, error) {
+	attachments, err := m.storage.QuestionAttachments(ctx, id)
+	return m.list(attachments, err)
+}
+
+func(m *Manager) AnswerAttachments(ctx context.Context, id int) (models.GetAttachmentsResponse, error) {
+	attachments, err := m.storage.AnswerAttachments(ctx, id)
+	return m.list(attachments, err)
+}
+
+// Download checks the signature of a download URL and opens the blob. The
+// caller closes the returned reader.
+func(m *Manager) Download(ctx context.Context, id int, expires, signature string) (models.Attachment, io.ReadCloser, error) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || m.now().Unix() > expiresAt {
+		return models.Attachment{}, nil, ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(m.signature(id, expiresAt))) {
+		return models.Attachment{}, nil, ErrInvalidSignature
+	}
+	attachment, err := m.storage.Attachment(ctx, id)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+	if attachment.QuestionID == nil && attachment.AnswerID == nil {
+		return models.Attachment{}, nil, ErrBlobNotFound
+	}
+	body, err := m.blobs.Get(ctx, attachment.BlobKey)
+	if err != nil {
+		if !errors.Is(err, ErrBlobNotFound) {
+			m.logError("BlobReadingError", "blobs", err)
+		}
+		return models.Attachment{}, nil, err
+	}
+
+	return attachment, body, nil
+}
+
+// Handle deletes the attachments left without a parent when a question or
+// an answer is deleted. Answers deleted with their question leave no event
+// of their own, so the sweep covers every orphan, not only the parent of
+// the event.
+func(m *Manager) Handle(ctx context.Context, event events.Event) error {
+	if event.Type != events.QuestionDeleted && event.Type != events.AnswerDeleted {
+		return nil
+	}
+	return m.Sweep(ctx)
+}
+
+// Sweep deletes the blobs of orphaned attachments and then their rows, so
+// a failed delete is retried by the next sweep.
+func(m *Manager) Sweep(ctx context.Context) error {
+	for {
+		orphans, err := m.storage.OrphanedAttachments(ctx, sweepBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(orphans) == 0 {
+			return nil
+		}
+		ids := make([]int, 0, len(orphans))
+		var errs []error
+		for _, orphan := range orphans {
+			if err := m.blobs.Delete(ctx, orphan.BlobKey); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			ids = append(ids, orphan.ID)
+		}
+		if len(ids) != 0 {
+			if err := m.storage.DeleteAttachments(ctx, ids); err != nil {
+				return err
+			}
+			m.log.Info(fmt.Sprintf("Deleted %d orphaned attachments", len(ids)))
+		}
+		if len(errs) != 0 {
+			return errors.Join(errs...)
+		}
+		if len(orphans) < sweepBatchSize {
+			return nil
+		}
+	}
+}
+
+func(m *Manager) list(attachments []models.Attachment, err error) (models.GetAttachmentsResponse, error) {
+	if err != nil {
+		return models.GetAttachmentsResponse{}, err
+	}
+	for i := range attachments {
+		m.sign(&attachments[i])
+	}
+
+	return models.GetAttachmentsResponse{Attachments: attachments}, nil
+}
+
+// sign sets the download URL of the attachment, valid for urlTTL.
+func(m *Manager) sign(attachment *models.Attachment) {
+	expires := m.now().Add(m.urlTTL).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", m.signature(attachment.ID, expires))
+	attachment.URL = fmt.Sprintf("/v1/attachments/%d?%s", attachment.ID, query.Encode())
+}
+
+// signature is an HMAC-SHA256 over "<id>.<expires>", so neither can be
+// changed without invalidating the URL.
+func(m *Manager) signature(id int, expires int64) string {
+	mac := hmac.New(sha256.New, m.signingKey)
+	mac.Write([]byte(strconv.Itoa(id) + "." + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func(m *Manager) logError(msg, component string, err error) {
+	m.log.Error(
+		msg,
+		slog.String("component", component),
+		slog.Any("error", err),
+	)
+}
+
+// newBlobKey returns a random key, spread over 256 prefixes so a local
+// directory does not grow too large.
+func newBlobKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	key := hex.EncodeToString(b)
+	return key[:2] + "/" + key, nil
+}
+
+// cleanFilename keeps the base name of the client's filename, without
+// control characters, for the Content-Disposition of downloads.
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "." || name == "/" || name == "" {
+		return "attachment"
+	}
+	for len(name) > maxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name) - size]
+	}
+
+	return name
+}
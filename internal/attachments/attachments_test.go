@@ -0,0 +1,182 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+const testUser = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestUploadSniffsContentType(t *testing.T) {
+	ctx := context.Background()
+	manager, _, _, questionID := newTestManager(t)
+
+	res, err := manager.Upload(ctx, models.Attachment{QuestionID: &questionID, UserID: testUser, Filename: "../../shot.png"}, bytes.NewReader(pngHeader))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Attachment.ContentType != "image/png" || res.Attachment.Filename != "shot.png" || res.Attachment.Size != int64(len(pngHeader)) {
+		t.Errorf("Excpected a sniffed png named shot.png, got %+v", res.Attachment)
+	}
+
+	_, err = manager.Upload(ctx, models.Attachment{QuestionID: &questionID, UserID: testUser, Filename: "page.png"}, strings.NewReader("<html><script>alert(1)</script>"))
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Excpected ErrUnsupportedType for HTML named .png, got %v", err)
+	}
+	_, err = manager.Upload(ctx, models.Attachment{QuestionID: &questionID, UserID: testUser}, strings.NewReader(strings.Repeat("a", 1025)))
+	if !errors.Is(err, ErrAttachmentTooLarge) {
+		t.Errorf("Excpected ErrAttachmentTooLarge, got %v", err)
+	}
+	missing := questionID + 1
+	_, err = manager.Upload(ctx, models.Attachment{QuestionID: &missing, UserID: testUser}, strings.NewReader("log line"))
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Excpected ErrRecordNotFound for a missing question, got %v", err)
+	}
+	_, err = manager.Upload(ctx, models.Attachment{QuestionID: &questionID, UserID: "nobody"}, strings.NewReader("log line"))
+	if !errors.Is(err, ErrInvalidAttachment) {
+		t.Errorf("Excpected ErrInvalidAttachment for an invalid user, got %v", err)
+	}
+}
+
+func TestDownloadChecksSignature(t *testing.T) {
+	ctx := context.Background()
+	manager, _, _, questionID := newTestManager(t)
+	now := time.Date(2026, time.January, 10, 10, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+
+	res, err := manager.Upload(ctx, models.Attachment{QuestionID: &questionID, UserID: testUser, Filename: "app.log"}, strings.NewReader("log line"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, expires, signature := parseURL(t, res.Attachment.URL)
+
+	attachment, body, err := manager.Download(ctx, id, expires, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "log line" || attachment.ContentType != "text/plain" {
+		t.Errorf("Excpected the uploaded log, got %q as %s", data, attachment.ContentType)
+	}
+
+	later, _ := strconv.ParseInt(expires, 10, 64)
+	if _, _, err := manager.Download(ctx, id, strconv.FormatInt(later + 60, 10), signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Excpected ErrInvalidSignature for a changed expiry, got %v", err)
+	}
+	if _, _, err := manager.Download(ctx, id + 1, expires, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Excpected ErrInvalidSignature for another attachment, got %v", err)
+	}
+	now = now.Add(16 * time.Minute)
+	if _, _, err := manager.Download(ctx, id, expires, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Excpected ErrInvalidSignature after expiry, got %v", err)
+	}
+}
+
+func TestSweepDeletesBlobsOfDeletedParents(t *testing.T) {
+	ctx := context.Background()
+	manager, questions, answers, questionID := newTestManager(t)
+	created := []*models.Answer{{QuestionID: questionID, UserID: testUser, Text: "test"}}
+	if err := answers.CreateAnswer(ctx, created); err != nil {
+		t.Fatal(err)
+	}
+	answerID := created[0].ID
+
+	question, err := manager.Upload(ctx, models.Attachment{QuestionID: &questionID, UserID: testUser}, strings.NewReader("question log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer, err := manager.Upload(ctx, models.Attachment{AnswerID: &answerID, UserID: testUser}, strings.NewReader("answer log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := questions.DeleteQuestion(ctx, questionID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Handle(ctx, events.Event{Type: events.QuestionDeleted, QuestionID: questionID}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, attachment := range []models.Attachment{question.Attachment, answer.Attachment} {
+		id, expires, signature := parseURL(t, attachment.URL)
+		if _, _, err := manager.Download(ctx, id, expires, signature); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("Excpected attachment %d to be deleted, got %v", id, err)
+		}
+	}
+	blobs := manager.blobs.(*Local)
+	entries, err := os.ReadDir(blobs.root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		files, _ := os.ReadDir(filepath.Join(blobs.root, entry.Name()))
+		if len(files) != 0 {
+			t.Errorf("Excpected no blobs left, got %d in %s", len(files), entry.Name())
+		}
+	}
+}
+
+func TestNewManagerRequiresSigningKey(t *testing.T) {
+	_, err := NewManager(slog.Default(), config.AttachmentsConfig{}, nil, nil)
+	if err == nil {
+		t.Fatal("Excpected an error without a signing key")
+	}
+}
+
+func newTestManager(t *testing.T) (*Manager, *mock.MockStorageQuestions, *mock.MockStorageAnswers, int) {
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	question := &models.Question{Text: "test"}
+	if err := questions.CreateQuestion(context.Background(), question); err != nil {
+		t.Fatal(err)
+	}
+	blobs, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.AttachmentsConfig{
+		MaxSize: 1024,
+		AllowedTypes: []string{"image/png", "text/plain"},
+		URLTTL: 15 * time.Minute,
+		SigningKey: "test",
+	}
+	manager, err := NewManager(slog.Default(), cfg, mock.NewMockStorageAttachments(questions), blobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return manager, questions, answers, question.ID
+}
+
+func parseURL(t *testing.T, raw string) (int, string, string) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(u.Path, "/v1/attachments/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+
+	return id, query.Get("expires"), query.Get("signature")
+}
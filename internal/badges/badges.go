@@ -0,0 +1,285 @@
+package badges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/ilyakaznacheev/cleanenv"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidRules = errors.New("InvalidBadgeRules")
+
+// Metrics a rule can compare with its threshold.
+const (
+	MetricAnswers = "answers"
+	MetricAcceptedAnswers = "accepted_answers"
+	MetricMaxAnswerScore = "max_answer_score"
+	MetricReputation = "reputation"
+	MetricQuestions = "questions"
+	MetricMaxQuestionViews = "max_question_views"
+)
+
+type Rule struct {
+	Name string `yaml:"name"`
+	Title string `yaml:"title"`
+	Description string `yaml:"description"`
+	Events []string `yaml:"events"`
+	Metric string `yaml:"metric"`
+	Threshold int `yaml:"threshold"`
+}
+
+type Rules struct {
+	Badges []Rule `yaml:"badges"`
+}
+
+type Storage interface {
+	SaveBadges(ctx context.Context, badges []models.Badge) ([]models.Badge, error)
+	AllBadges(ctx context.Context) ([]models.Badge, error)
+	UserBadges(ctx context.Context, userID string) ([]models.UserBadge, error)
+	AwardBadge(ctx context.Context, award *models.UserBadge) error
+	UserStats(ctx context.Context, userID string) (models.UserStats, error)
+	QuestionAuthors(ctx context.Context, ids []int) ([]string, error)
+	UserIDs(ctx context.Context, afterID string, limit int) ([]string, error)
+}
+
+// Engine awards badges by the rules. Handle is subscribed to the events
+// dispatcher; Backfill evaluates every user for history written before the
+// rules existed.
+type Engine struct {
+	log *slog.Logger
+	rules []Rule
+	storage Storage
+	ids map[string]int
+}
+
+// LoadRules reads and validates the rules file.
+func LoadRules(path string) ([]Rule, error) {
+	var rules Rules
+	if err := cleanenv.ReadConfig(path, &rules); err != nil {
+		return nil, err
+	}
+	if err := validate(rules.Badges); err != nil {
+		return nil, err
+	}
+
+	return rules.Badges, nil
+}
+
+func NewEngine(log *slog.Logger, rules []Rule, storage Storage) *Engine {
+	return &Engine{
+		log: log,
+		rules: rules,
+		storage: storage,
+	}
+}
+
+// Sync stores the badge definitions of the rules. It must be called before
+// the engine awards anything.
+func(e *Engine) Sync(ctx context.Context) error {
+	badges := make([]models.Badge, 0, len(e.rules))
+	for _, rule := range e.rules {
+		badges = append(badges, models.Badge{
+			Name: rule.Name,
+			Title: rule.Title,
+			Description: rule.Description,
+		})
+	}
+	saved, err := e.storage.SaveBadges(ctx, badges)
+	if err != nil {
+		e.logError("DB_WritingError", err)
+		return err
+	}
+	e.ids = make(map[string]int, len(saved))
+	for _, badge := range saved {
+		e.ids[badge.Name] = badge.ID
+	}
+
+	return nil
+}
+
+func(e *Engine) Badges(ctx context.Context) (models.GetBadgesResponse, error) {
+	badges, err := e.storage.AllBadges(ctx)
+	if err != nil {
+		e.logError("DB_ReadingError", err)
+		return models.GetBadgesResponse{}, err
+	}
+
+	return models.GetBadgesResponse{Badges: badges}, nil
+}
+
+func(e *Engine) UserBadges(ctx context.Context, userID string) (models.GetUserBadgesResponse, error) {
+	badges, err := e.storage.UserBadges(ctx, userID)
+	if err != nil {
+		e.logError("DB_ReadingError", err)
+		return models.GetUserBadgesResponse{}, err
+	}
+
+	return models.GetUserBadgesResponse{Badges: badges}, nil
+}
+
+func(e *Engine) Handle(ctx context.Context, event events.Event) error {
+	userID, err := author(event)
+	if err != nil || userID == "" {
+		return err
+	}
+	rules := make([]Rule, 0, len(e.rules))
+	for _, rule := range e.rules {
+		if len(rule.Events) == 0 || slices.Contains(rule.Events, event.Type) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return e.evaluate(ctx, userID, int64(event.ID), rules)
+}
+
+// QuestionsViewed evaluates the view rules for the authors of the questions.
+// Views are not events; the view counter calls it after every flush.
+func(e *Engine) QuestionsViewed(ctx context.Context, ids []int) error {
+	rules := make([]Rule, 0)
+	for _, rule := range e.rules {
+		if rule.Metric == MetricMaxQuestionViews {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	authors, err := e.storage.QuestionAuthors(ctx, ids)
+	if err != nil {
+		e.logError("DB_ReadingError", err)
+		return err
+	}
+	for _, userID := range authors {
+		if err := e.evaluate(ctx, userID, 0, rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Backfill evaluates every rule for every user and returns the number of
+// users checked. Badges already awarded are kept.
+func(e *Engine) Backfill(ctx context.Context, batchSize int) (int, error) {
+	var checked int
+	var afterID string
+	for {
+		ids, err := e.storage.UserIDs(ctx, afterID, batchSize)
+		if err != nil {
+			e.logError("DB_ReadingError", err)
+			return checked, err
+		}
+		for _, id := range ids {
+			if err := e.evaluate(ctx, id, 0, e.rules); err != nil {
+				return checked, err
+			}
+			checked++
+		}
+		if len(ids) < batchSize {
+			return checked, nil
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
+
+func(e *Engine) evaluate(ctx context.Context, userID string, eventID int64, rules []Rule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	stats, err := e.storage.UserStats(ctx, userID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		e.logError("DB_ReadingError", err)
+		return err
+	}
+	for _, rule := range rules {
+		badgeID, ok := e.ids[rule.Name]
+		if !ok || metric(stats, rule.Metric) < rule.Threshold {
+			continue
+		}
+		award := &models.UserBadge{UserID: userID, BadgeID: badgeID, EventID: eventID}
+		if err := e.storage.AwardBadge(ctx, award); err != nil {
+			e.logError("DB_WritingError", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func(e *Engine) logError(msg string, err error) {
+	e.log.Error(
+		msg,
+		slog.String("component", "badges"),
+		slog.Any("error", err),
+	)
+}
+
+// author returns the user whose metrics the event can change.
+func author(event events.Event) (string, error) {
+	switch event.Type {
+	case events.QuestionCreated:
+		question, err := events.Decode[models.Question](event)
+		if err != nil || question.UserID == nil {
+			return "", err
+		}
+		return *question.UserID, nil
+	case events.AnswerCreated:
+		answer, err := events.Decode[models.Answer](event)
+		return answer.UserID, err
+	case events.AnswerVoted:
+		voted, err := events.Decode[models.AnswerVoted](event)
+		return voted.Answer.UserID, err
+	case events.AnswerAccepted:
+		accepted, err := events.Decode[models.AnswerAccepted](event)
+		return accepted.Answer.UserID, err
+	}
+
+	return "", nil
+}
+
+func metric(stats models.UserStats, name string) int {
+	switch name {
+	case MetricAnswers:
+		return stats.Answers
+	case MetricAcceptedAnswers:
+		return stats.AcceptedAnswers
+	case MetricMaxAnswerScore:
+		return stats.MaxAnswerScore
+	case MetricReputation:
+		return stats.Reputation
+	case MetricQuestions:
+		return stats.Questions
+	case MetricMaxQuestionViews:
+		return stats.MaxQuestionViews
+	}
+
+	return 0
+}
+
+func validate(rules []Rule) error {
+	names := make(map[string]struct{}, len(rules))
+	metrics := []string{MetricAnswers, MetricAcceptedAnswers, MetricMaxAnswerScore, MetricReputation, MetricQuestions, MetricMaxQuestionViews}
+	for _, rule := range rules {
+		if rule.Name == "" || rule.Title == "" {
+			return fmt.Errorf("%w: badge without name or title", ErrInvalidRules)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("%w: duplicate badge %s", ErrInvalidRules, rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+		if !slices.Contains(metrics, rule.Metric) {
+			return fmt.Errorf("%w: unknown metric %s of badge %s", ErrInvalidRules, rule.Metric, rule.Name)
+		}
+		if rule.Threshold <= 0 {
+			return fmt.Errorf("%w: threshold of badge %s must be positive", ErrInvalidRules, rule.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package badges
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+const userID = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+func TestLoadRulesOfConfig(t *testing.T) {
+	rules, err := LoadRules("../../config/badges.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) == 0 {
+		t.Error("Excpected badge rules in config")
+	}
+}
+
+func TestValidateRejectsUnknownMetric(t *testing.T) {
+	err := validate([]Rule{{Name: "viewed", Title: "Viewed", Metric: "question_views", Threshold: 100}})
+	if !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("Excpected ErrInvalidRules, got %v", err)
+	}
+}
+
+func TestHandleAwardsOnce(t *testing.T) {
+	ctx := context.Background()
+	answers, storage := newStorage()
+	engine := newEngine(t, storage)
+
+	for i := 0; i < 2; i++ {
+		created := []*models.Answer{{QuestionID: 1, UserID: userID, Text: "test"}}
+		answers.CreateAnswer(ctx, created)
+		event := events.NewAnswerCreated(*created[0])
+		event.ID = uint64(i + 1)
+		if err := engine.Handle(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, _ := engine.UserBadges(ctx, userID)
+	if len(res.Badges) != 1 || res.Badges[0].Badge.Name != "first_answer" || res.Badges[0].EventID != 1 {
+		t.Errorf("Excpected first_answer awarded by event 1, got %+v", res.Badges)
+	}
+}
+
+func TestBackfillEvaluatesHistory(t *testing.T) {
+	ctx := context.Background()
+	answers, storage := newStorage()
+	answers.CreateAnswer(ctx, []*models.Answer{{QuestionID: 1, UserID: userID, Text: "test"}})
+	answers.AcceptAnswer(ctx, 1)
+	engine := newEngine(t, storage)
+
+	checked, err := engine.Backfill(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, _ := engine.UserBadges(ctx, userID)
+	if checked != 1 || len(res.Badges) != 2 {
+		t.Errorf("Excpected 2 badges for 1 user, got %d users and %+v", checked, res.Badges)
+	}
+}
+
+func TestQuestionBadges(t *testing.T) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(2, answers)
+	storage := mock.NewMockStorageBadges(mock.NewMockStorageUsers(questions))
+	rules := []Rule{
+		{Name: "first_question", Title: "Curious", Events: []string{events.QuestionCreated}, Metric: MetricQuestions, Threshold: 1},
+		{Name: "popular_question", Title: "Popular Question", Metric: MetricMaxQuestionViews, Threshold: 100},
+	}
+	engine := NewEngine(slog.Default(), rules, storage)
+	if err := engine.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	author := userID
+	question := &models.Question{Text: "test", UserID: &author}
+	questions.CreateQuestion(ctx, question)
+	event := events.NewQuestionCreated(*question)
+	event.ID = 1
+	if err := engine.Handle(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+	res, _ := engine.UserBadges(ctx, userID)
+	if len(res.Badges) != 1 || res.Badges[0].Badge.Name != "first_question" {
+		t.Fatalf("Excpected first_question, got %+v", res.Badges)
+	}
+
+	views := mock.NewMockStorageViews(questions)
+	views.AddViews(ctx, map[int]int{question.ID: 99}, 0)
+	engine.QuestionsViewed(ctx, []int{question.ID})
+	if res, _ := engine.UserBadges(ctx, userID); len(res.Badges) != 1 {
+		t.Errorf("Excpected no view badge below the threshold, got %+v", res.Badges)
+	}
+	views.AddViews(ctx, map[int]int{question.ID: 1}, 0)
+	if err := engine.QuestionsViewed(ctx, []int{question.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if res, _ := engine.UserBadges(ctx, userID); len(res.Badges) != 2 {
+		t.Errorf("Excpected popular_question at 100 views, got %+v", res.Badges)
+	}
+}
+
+func newStorage() (*mock.MockStorageAnswers, *mock.MockStorageBadges) {
+	answers := mock.NewMockStorageAnswers(2)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	return answers, mock.NewMockStorageBadges(mock.NewMockStorageUsers(questions))
+}
+
+func newEngine(t *testing.T, storage Storage) *Engine {
+	rules := []Rule{
+		{Name: "first_answer", Title: "First Answer", Events: []string{events.AnswerCreated}, Metric: MetricAnswers, Threshold: 1},
+		{Name: "first_accepted", Title: "Solved It", Events: []string{events.AnswerAccepted}, Metric: MetricAcceptedAnswers, Threshold: 1},
+		{Name: "trusted", Title: "Trusted", Metric: MetricReputation, Threshold: 1000},
+	}
+	engine := NewEngine(slog.Default(), rules, storage)
+	if err := engine.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return engine
+}
@@ -3,19 +3,91 @@ package config
 import (
 	"flag"
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
 	Server ServerConfig `yaml:"server"`
+	GRPC GRPCConfig `yaml:"grpc"`
+	GraphQL GraphQLConfig `yaml:"graphql"`
 	Log LogConfig `yaml:"log"`
 	Storage StorageConfig `yaml:"storage"`
+	Cache CacheConfig `yaml:"cache"`
+	Events EventsConfig `yaml:"events"`
+	Webhooks WebhooksConfig `yaml:"webhooks"`
+	Reputation ReputationConfig `yaml:"reputation"`
+	Badges BadgesConfig `yaml:"badges"`
+	Views ViewsConfig `yaml:"views"`
+	Duplicates DuplicatesConfig `yaml:"duplicates"`
+	Attachments AttachmentsConfig `yaml:"attachments"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Digest DigestConfig `yaml:"digest"`
+	Jobs JobsConfig `yaml:"jobs"`
 }
 
 type ServerConfig struct {
 	Host string `yaml:"host"`
 	Port int `yaml:"port"`	
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	// TrustProxy makes the server take the client address from the last
+	// X-Forwarded-For hop. Enable it only behind a single proxy that
+	// appends to the header.
+	TrustProxy bool `yaml:"trust_proxy"`
+	// AdminToken guards the /admin, batch, merge and move endpoints, sent
+	// as a bearer token. Without it they are not served.
+	AdminToken string `yaml:"admin_token" env:"ADMIN_TOKEN"`
+	LegacyRoutes LegacyRoutesConfig `yaml:"legacy_routes"`
+	Batch BatchConfig `yaml:"batch"`
+	Streams StreamsConfig `yaml:"streams"`
+}
+
+// StreamsConfig limits the NDJSON streams: at most MaxConcurrent are
+// served at once, a stream whose client takes no row for WriteTimeout is
+// cut, and so is one lasting longer than MaxDuration. Each stream holds a
+// database connection, so MaxConcurrent is kept below storage.pool_size.
+type StreamsConfig struct {
+	MaxConcurrent int `yaml:"max_concurrent" env-default:"8"`
+	WriteTimeout time.Duration `yaml:"write_timeout" env-default:"30s"`
+	MaxDuration time.Duration `yaml:"max_duration" env-default:"10m"`
+}
+
+// BatchConfig limits POST /questions:batch, an admin endpoint: a request
+// has at most MaxOperations operations and MaxBytes of body.
+type BatchConfig struct {
+	MaxOperations int `yaml:"max_operations" env-default:"500"`
+	MaxBytes int64 `yaml:"max_bytes" env-default:"1048576"`
+}
+
+// LegacyRoutesConfig controls the unversioned aliases of the /v1 routes.
+// Their responses carry Deprecated and Sunset, dates in 2006-01-02 form,
+// as the Deprecation and Sunset headers.
+type LegacyRoutesConfig struct {
+	Disabled bool `yaml:"disabled" env:"LEGACY_ROUTES_DISABLED"`
+	Deprecated time.Time `yaml:"deprecated" env:"LEGACY_ROUTES_DEPRECATED" env-layout:"2006-01-02"`
+	Sunset time.Time `yaml:"sunset" env:"LEGACY_ROUTES_SUNSET" env-layout:"2006-01-02"`
+}
+
+// GRPCConfig sets the port of the gRPC API. Calls must carry Token as a
+// bearer token when it is set.
+type GRPCConfig struct {
+	Enabled bool `yaml:"enabled" env:"GRPC_ENABLED"`
+	Host string `yaml:"host"`
+	Port int `yaml:"port" env:"GRPC_PORT" env-default:"9090"`
+	Token string `yaml:"token" env:"GRPC_TOKEN"`
+}
+
+// GraphQLConfig controls /graphql. Operations deeper than MaxDepth or
+// costlier than MaxComplexity are rejected. PersistedQueries is a JSON file
+// of queries by their SHA-256; with AllowlistOnly only those run.
+type GraphQLConfig struct {
+	Enabled bool `yaml:"enabled" env:"GRAPHQL_ENABLED"`
+	MaxDepth int `yaml:"max_depth" env-default:"8"`
+	MaxComplexity int `yaml:"max_complexity" env-default:"5000"`
+	Introspection bool `yaml:"introspection" env:"GRAPHQL_INTROSPECTION"`
+	PersistedQueries string `yaml:"persisted_queries" env:"GRAPHQL_PERSISTED_QUERIES"`
+	AllowlistOnly bool `yaml:"allowlist_only" env:"GRAPHQL_ALLOWLIST_ONLY"`
 }
 
 type LogConfig struct {
@@ -30,6 +102,156 @@ type StorageConfig struct {
 	Username string `yaml:"username" env:"POSTGRES_USER" end-default:"admin"`
 	Password string `yaml:"password" env:"POSTGRES_PASSWORD" env-default:"admin"`
 	TimeZone string `yaml:"timezone"`
+	// PoolSize bounds the open connections used by queries; the connections
+	// held by the advisory locks of the jobs come on top of it.
+	PoolSize int `yaml:"pool_size" env:"DB_POOL_SIZE" env-default:"16"`
+}
+
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled" env:"CACHE_ENABLED"`
+	Backend string `yaml:"backend" env:"CACHE_BACKEND" env-default:"memory"`
+	Size int `yaml:"size" env-default:"10000"`
+	TTL time.Duration `yaml:"ttl" env-default:"1m"`
+	Redis RedisConfig `yaml:"redis"`
+}
+
+type RedisConfig struct {
+	Addr string `yaml:"addr" env:"REDIS_ADDR" env-default:"127.0.0.1:6379"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD"`
+	DB int `yaml:"db"`
+	// PoolSize bounds the open connections; a request waits for a free one.
+	PoolSize int `yaml:"pool_size" env-default:"8"`
+	Timeout time.Duration `yaml:"timeout" env-default:"1s"`
+}
+
+// EventsConfig configures the outbox relay. A replica claims a batch for
+// Lease; an event whose subscribers still fail after MaxAttempts claims is
+// dead-lettered. The hub of every replica reads the outbox on its own and
+// waits up to GapTimeout for an event ID whose transaction is not committed.
+type EventsConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	BatchSize int `yaml:"batch_size" env-default:"100"`
+	Lease time.Duration `yaml:"lease" env-default:"30s"`
+	MaxAttempts int `yaml:"max_attempts" env-default:"10"`
+	GapTimeout time.Duration `yaml:"gap_timeout" env-default:"10s"`
+	BufferSize int `yaml:"buffer_size" env-default:"1024"`
+	SubscriberBuffer int `yaml:"subscriber_buffer" env-default:"64"`
+}
+
+type WebhooksConfig struct {
+	Enabled bool `yaml:"enabled" env:"WEBHOOKS_ENABLED"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	BatchSize int `yaml:"batch_size" env-default:"100"`
+	Timeout time.Duration `yaml:"timeout" env-default:"10s"`
+	MaxAttempts int `yaml:"max_attempts" env-default:"8"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env-default:"10s"`
+	MaxBackoff time.Duration `yaml:"max_backoff" env-default:"1h"`
+	DisableAfter int `yaml:"disable_after" env-default:"20"`
+}
+
+// ReputationConfig holds the points each rule awards and the reputation a
+// user needs for a privilege.
+type ReputationConfig struct {
+	AnswerUpvoted int `yaml:"answer_upvoted" env-default:"10"`
+	AnswerDownvoted int `yaml:"answer_downvoted" env-default:"-2"`
+	AnswerAccepted int `yaml:"answer_accepted" env-default:"15"`
+	DownvoteCast int `yaml:"downvote_cast" env-default:"-1"`
+	RecomputeOnStart bool `yaml:"recompute_on_start"`
+	Privileges PrivilegesConfig `yaml:"privileges"`
+}
+
+type PrivilegesConfig struct {
+	Downvote int `yaml:"downvote" env-default:"125"`
+	EditOthers int `yaml:"edit_others" env-default:"2000"`
+}
+
+type BadgesConfig struct {
+	Enabled bool `yaml:"enabled" env:"BADGES_ENABLED"`
+	RulesPath string `yaml:"rules_path" env-default:"./config/badges.yaml"`
+}
+
+// ViewsConfig controls question view counting. A viewer is counted once per
+// question within Window; MaxViewers bounds the viewers remembered for that,
+// forgetting the oldest first.
+type ViewsConfig struct {
+	Enabled bool `yaml:"enabled" env:"VIEWS_ENABLED"`
+	Window time.Duration `yaml:"window" env-default:"30m"`
+	FlushInterval time.Duration `yaml:"flush_interval" env-default:"10s"`
+	HalfLife time.Duration `yaml:"half_life" env-default:"6h"`
+	MaxViewers int `yaml:"max_viewers" env-default:"100000"`
+}
+
+// DuplicatesConfig sets when a new question is reported as a likely
+// duplicate: the trigram similarity of the texts is at least Threshold. At
+// most Limit duplicates are reported; a Limit of 0 disables the check.
+type DuplicatesConfig struct {
+	Threshold float64 `yaml:"threshold" env-default:"0.6"`
+	Limit int `yaml:"limit" env-default:"5"`
+}
+
+// AttachmentsConfig limits uploads and sets where their blobs are stored.
+// Backend is "local" or "s3". Download URLs are signed with SigningKey and
+// expire after URLTTL; SigningKey is required when attachments are enabled.
+type AttachmentsConfig struct {
+	Enabled bool `yaml:"enabled" env:"ATTACHMENTS_ENABLED"`
+	Backend string `yaml:"backend" env:"ATTACHMENTS_BACKEND" env-default:"local"`
+	MaxSize int64 `yaml:"max_size" env-default:"10485760"`
+	AllowedTypes []string `yaml:"allowed_types" env-default:"image/png,image/jpeg,image/gif,image/webp,text/plain"`
+	URLTTL time.Duration `yaml:"url_ttl" env-default:"15m"`
+	SigningKey string `yaml:"signing_key" env:"ATTACHMENTS_SIGNING_KEY"`
+	Local LocalBlobsConfig `yaml:"local"`
+	S3 S3Config `yaml:"s3"`
+}
+
+type LocalBlobsConfig struct {
+	Path string `yaml:"path" env-default:"./data/attachments"`
+}
+
+// S3Config points at an S3-compatible service. Objects are addressed
+// path-style, which MinIO and other stand-ins support.
+type S3Config struct {
+	Endpoint string `yaml:"endpoint" env:"S3_ENDPOINT"`
+	Region string `yaml:"region" env-default:"us-east-1"`
+	Bucket string `yaml:"bucket" env:"S3_BUCKET"`
+	AccessKey string `yaml:"access_key" env:"S3_ACCESS_KEY"`
+	SecretKey string `yaml:"secret_key" env:"S3_SECRET_KEY"`
+	Timeout time.Duration `yaml:"timeout" env-default:"30s"`
+}
+
+type NotificationsConfig struct {
+	Enabled bool `yaml:"enabled" env:"NOTIFICATIONS_ENABLED"`
+}
+
+// DigestConfig controls the email digests. Every CheckInterval the users
+// whose daily or weekly digest is due are mailed. Mailer is "smtp" or
+// "file"; the file mailer writes .eml files to FilePath. Links in the
+// emails start with BaseURL.
+type DigestConfig struct {
+	Enabled bool `yaml:"enabled" env:"DIGEST_ENABLED"`
+	CheckInterval time.Duration `yaml:"check_interval" env-default:"10m"`
+	BatchSize int `yaml:"batch_size" env-default:"100"`
+	BaseURL string `yaml:"base_url" env:"DIGEST_BASE_URL" env-default:"http://localhost:8080"`
+	Mailer string `yaml:"mailer" env:"DIGEST_MAILER" env-default:"file"`
+	FilePath string `yaml:"file_path" env-default:"./data/mail"`
+	SMTP SMTPConfig `yaml:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host string `yaml:"host" env:"SMTP_HOST" env-default:"127.0.0.1"`
+	Port int `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From string `yaml:"from" env:"SMTP_FROM" env-default:"Questions <noreply@localhost>"`
+	Timeout time.Duration `yaml:"timeout" env-default:"30s"`
+}
+
+// JobsConfig sets the schedules of the background jobs. Schedules
+// overrides the schedule of a job by name with a cron expression or
+// "@every <duration>"; "off" disables the job. Processed outbox events are
+// deleted after OutboxRetention.
+type JobsConfig struct {
+	Schedules map[string]string `yaml:"schedules"`
+	OutboxRetention time.Duration `yaml:"outbox_retention" env-default:"168h"`
 }
 
 func MustLoad() *Config {
@@ -0,0 +1,285 @@
+// Package digest mails each user who asked for it a daily or weekly
+// summary of the new answers on the questions they follow and of the new
+// questions without answers in the tags they follow.
+package digest
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	htmltemplate "html/template"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/mailer"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	htmlTemplate = htmltemplate.Must(htmltemplate.ParseFS(templates, "templates/digest.html"))
+	textTemplate = texttemplate.Must(texttemplate.ParseFS(templates, "templates/digest.txt"))
+	verifyHTMLTemplate = htmltemplate.Must(htmltemplate.ParseFS(templates, "templates/verify.html"))
+	verifyTextTemplate = texttemplate.Must(texttemplate.ParseFS(templates, "templates/verify.txt"))
+)
+
+const excerptLength = 280
+
+// maxUnanswered is how many unanswered questions a digest lists at most.
+const maxUnanswered = 20
+
+// periods is how far apart the digests of each frequency are sent.
+var periods = map[string]time.Duration{
+	models.DigestDaily: 24 * time.Hour,
+	models.DigestWeekly: 7 * 24 * time.Hour,
+}
+
+type Storage interface {
+	// DueDigests returns the users after the user ID after, in the order of
+	// their IDs, with the digest frequency and an email whose last digest
+	// was sent at or before sentBefore, or never.
+	DueDigests(ctx context.Context, frequency string, sentBefore time.Time, after string, limit int) ([]models.NotificationPreferences, error)
+	// FollowedAnswers returns the answers created in [since, until) on the
+	// questions the user follows, leaving out the user's own answers.
+	FollowedAnswers(ctx context.Context, userID string, since, until time.Time) ([]models.DigestAnswer, error)
+	// UnansweredQuestions returns the questions created in [since, until)
+	// with a tag the user follows that have no answer yet, leaving out the
+	// user's own questions and the duplicates.
+	UnansweredQuestions(ctx context.Context, userID string, since, until time.Time, limit int) ([]models.DigestQuestion, error)
+	// SwapDigestSent sets the time the digest of the user was sent to next
+	// if it is still previous, and reports whether it did. It keeps two
+	// senders from mailing the same digest.
+	SwapDigestSent(ctx context.Context, userID string, previous, next *time.Time) (bool, error)
+}
+
+// Sender mails the digests that are due. Send is run as a job every
+// CheckInterval.
+type Sender struct {
+	log *slog.Logger
+	cfg config.DigestConfig
+	from string
+	storage Storage
+	mailer mailer.Mailer
+	now func() time.Time
+}
+
+func NewSender(log *slog.Logger, cfg config.DigestConfig, from string, storage Storage, mailer mailer.Mailer) *Sender {
+	return &Sender{
+		log: log,
+		cfg: cfg,
+		from: from,
+		storage: storage,
+		mailer: mailer,
+		now: time.Now,
+	}
+}
+
+// NewMailer returns the mailer the config selects.
+func NewMailer(cfg config.DigestConfig) (mailer.Mailer, error) {
+	if cfg.Mailer == "smtp" {
+		return mailer.NewSMTP(cfg.SMTP), nil
+	}
+	return mailer.NewFile(cfg.FilePath)
+}
+
+// Send mails every digest that is due and returns how many were mailed.
+// A user with nothing new gets no email, but the period still counts as
+// sent. The users are paged by ID, so the digests that fail are retried on
+// the next run rather than in this one.
+func(s *Sender) Send(ctx context.Context) (int, error) {
+	sent := 0
+	for _, frequency := range []string{models.DigestDaily, models.DigestWeekly} {
+		period := periods[frequency]
+		now := s.now()
+		after := ""
+		for {
+			due, err := s.storage.DueDigests(ctx, frequency, now.Add(-period), after, s.cfg.BatchSize)
+			if err != nil {
+				return sent, err
+			}
+			for _, preferences := range due {
+				if err := ctx.Err(); err != nil {
+					return sent, err
+				}
+				if s.send(ctx, preferences, period, now) {
+					sent++
+				}
+				after = preferences.UserID
+			}
+			if len(due) < s.cfg.BatchSize {
+				break
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+// send claims the digest of the user for the period ending at now, then
+// mails it. The claim is given back when the email fails, so the next
+// pass retries it.
+func(s *Sender) send(ctx context.Context, preferences models.NotificationPreferences, period time.Duration, now time.Time) bool {
+	since := now.Add(-period)
+	if preferences.DigestSentAt != nil {
+		since = *preferences.DigestSentAt
+	}
+	claimed, err := s.storage.SwapDigestSent(ctx, preferences.UserID, preferences.DigestSentAt, &now)
+	if err != nil {
+		s.logError("DB_WritingError", "db", err)
+		return false
+	}
+	if !claimed {
+		return false
+	}
+	answers, err := s.storage.FollowedAnswers(ctx, preferences.UserID, since, now)
+	var unanswered []models.DigestQuestion
+	if err == nil {
+		unanswered, err = s.storage.UnansweredQuestions(ctx, preferences.UserID, since, now, maxUnanswered)
+	}
+	if err == nil && len(answers) == 0 && len(unanswered) == 0 {
+		return false
+	}
+	if err == nil {
+		var message mailer.Message
+		message, err = s.message(preferences, since, now, answers, unanswered)
+		if err == nil {
+			err = s.mailer.Send(ctx, message)
+		}
+	}
+	if err != nil {
+		s.logError("DigestError", "digest", err, slog.String("user_id", preferences.UserID))
+		if _, err := s.storage.SwapDigestSent(ctx, preferences.UserID, &now, preferences.DigestSentAt); err != nil {
+			s.logError("DB_WritingError", "db", err)
+		}
+		return false
+	}
+
+	return true
+}
+
+type digestData struct {
+	BaseURL string
+	Frequency string
+	Since time.Time
+	Until time.Time
+	Answers int
+	Questions []digestQuestion
+	Unanswered []digestQuestion
+}
+
+type digestQuestion struct {
+	ID int
+	Text string
+	URL string
+	Tags []string
+	Answers []models.DigestAnswer
+}
+
+func(s *Sender) message(preferences models.NotificationPreferences, since, until time.Time, answers []models.DigestAnswer, unanswered []models.DigestQuestion) (mailer.Message, error) {
+	baseURL := strings.TrimRight(s.cfg.BaseURL, "/")
+	data := digestData{
+		BaseURL: baseURL,
+		Frequency: preferences.Digest,
+		Since: since,
+		Until: until,
+		Answers: len(answers),
+	}
+	index := make(map[int]int)
+	for _, answer := range answers {
+		ind, ok := index[answer.QuestionID]
+		if !ok {
+			ind = len(data.Questions)
+			index[answer.QuestionID] = ind
+			data.Questions = append(data.Questions, digestQuestion{
+				ID: answer.QuestionID,
+				Text: answer.QuestionText,
+				URL: baseURL + "/v1/questions/" + strconv.Itoa(answer.QuestionID),
+			})
+		}
+		answer.AnswerText = excerpt(answer.AnswerText)
+		data.Questions[ind].Answers = append(data.Questions[ind].Answers, answer)
+	}
+	for _, question := range unanswered {
+		data.Unanswered = append(data.Unanswered, digestQuestion{
+			ID: question.ID,
+			Text: excerpt(question.Text),
+			URL: baseURL + "/v1/questions/" + strconv.Itoa(question.ID),
+			Tags: question.Tags,
+		})
+	}
+
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Execute(&html, data); err != nil {
+		return mailer.Message{}, err
+	}
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return mailer.Message{}, err
+	}
+	counts := make([]string, 0, 2)
+	if len(answers) != 0 {
+		counts = append(counts, plural(len(answers), "new answer"))
+	}
+	if len(unanswered) != 0 {
+		counts = append(counts, plural(len(unanswered), "unanswered question"))
+	}
+
+	return mailer.Message{
+		From: s.from,
+		To: preferences.Email,
+		Subject: "Your " + preferences.Digest + " digest: " + strings.Join(counts, " and "),
+		Text: text.String(),
+		HTML: html.String(),
+	}, nil
+}
+
+// SendVerification mails the link that verifies the address; digests are
+// sent to verified addresses only.
+func(s *Sender) SendVerification(ctx context.Context, email, token string) error {
+	link := strings.TrimRight(s.cfg.BaseURL, "/") + "/v1/notifications/verify?token=" + url.QueryEscape(token)
+	var html, text bytes.Buffer
+	if err := verifyHTMLTemplate.Execute(&html, link); err != nil {
+		return err
+	}
+	if err := verifyTextTemplate.Execute(&text, link); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		From: s.from,
+		To: email,
+		Subject: "Confirm your email address",
+		Text: text.String(),
+		HTML: html.String(),
+	})
+}
+
+func(s *Sender) logError(msg, component string, err error, attrs ...any) {
+	s.log.Error(
+		msg,
+		append([]any{slog.String("component", component), slog.Any("error", err)}, attrs...)...,
+	)
+}
+
+func plural(count int, noun string) string {
+	res := strconv.Itoa(count) + " " + noun
+	if count != 1 {
+		res += "s"
+	}
+	return res
+}
+
+// excerpt shortens a text to its first excerptLength runes.
+func excerpt(text string) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= excerptLength {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:excerptLength])) + "…"
+}
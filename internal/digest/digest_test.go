@@ -0,0 +1,219 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/mailer"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+const (
+	follower = "33333333-3333-3333-3333-333333333333"
+	answerer = "22222222-2222-2222-2222-222222222222"
+)
+
+func TestSendDailyDigest(t *testing.T) {
+	ctx := context.Background()
+	sender, memory, now := newTestSender(t)
+
+	if sent, _ := sender.Send(ctx); sent != 1 {
+		t.Fatalf("Excpected 1 digest, got %d", sent)
+	}
+	messages := memory.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Excpected 1 message, got %d", len(messages))
+	}
+	message := messages[0]
+	if message.To != "follower@example.com" || message.Subject != "Your daily digest: 1 new answer" {
+		t.Errorf("Excpected the daily digest for follower@example.com, got %s %q", message.To, message.Subject)
+	}
+	if !strings.Contains(message.HTML, "&lt;b&gt;Why?&lt;/b&gt;") || !strings.Contains(message.Text, "<b>Why?</b>") {
+		t.Errorf("Excpected the question escaped in HTML only, got %s", message.HTML)
+	}
+	if !strings.Contains(message.Text, "https://qa.example.com/v1/questions/1") || strings.Contains(message.Text, "my own answer") {
+		t.Errorf("Excpected a link and no own answer, got %s", message.Text)
+	}
+
+	if sent, _ := sender.Send(ctx); sent != 0 {
+		t.Errorf("Excpected no digest before the next period, got %d", sent)
+	}
+	sender.now = func() time.Time { return now.Add(25 * time.Hour) }
+	if sent, _ := sender.Send(ctx); sent != 0 || len(memory.Messages()) != 1 {
+		t.Errorf("Excpected no email without new answers, got %d", sent)
+	}
+}
+
+func TestFailedDigestIsRetried(t *testing.T) {
+	ctx := context.Background()
+	sender, memory, _ := newTestSender(t)
+	sender.mailer = failingMailer{}
+
+	if sent, _ := sender.Send(ctx); sent != 0 {
+		t.Fatalf("Excpected no digest, got %d", sent)
+	}
+	sender.mailer = memory
+	if sent, _ := sender.Send(ctx); sent != 1 {
+		t.Errorf("Excpected the digest to be retried, got %d", sent)
+	}
+}
+
+func TestUnverifiedEmailGetsNoDigest(t *testing.T) {
+	ctx := context.Background()
+	sender, memory, _ := newTestSender(t)
+	storage := sender.storage.(*mock.MockStorageDigests)
+	storage.Unverify(follower)
+
+	if sent, _ := sender.Send(ctx); sent != 0 || len(memory.Messages()) != 0 {
+		t.Errorf("Excpected no digest to an unverified email, got %d", sent)
+	}
+}
+
+func TestSendVerification(t *testing.T) {
+	sender, memory, _ := newTestSender(t)
+
+	if err := sender.SendVerification(context.Background(), "alice@example.com", "a b"); err != nil {
+		t.Fatal(err)
+	}
+	messages := memory.Messages()
+	if len(messages) != 1 || messages[0].To != "alice@example.com" {
+		t.Fatalf("Excpected 1 message to alice@example.com, got %+v", messages)
+	}
+	if !strings.Contains(messages[0].Text, "https://qa.example.com/v1/notifications/verify?token=a+b") {
+		t.Errorf("Excpected the verification link, got %s", messages[0].Text)
+	}
+}
+
+type failingMailer struct{}
+
+func(failingMailer) Send(ctx context.Context, message mailer.Message) error {
+	return errors.New("relay down")
+}
+
+func newTestSender(t *testing.T) (*Sender, *mailer.Memory, time.Time) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	notifications := mock.NewMockStorageNotifications(questions)
+	question := &models.Question{Text: "<b>Why?</b>"}
+	if err := questions.CreateQuestion(ctx, question); err != nil {
+		t.Fatal(err)
+	}
+	if err := notifications.FollowQuestion(ctx, question.ID, follower); err != nil {
+		t.Fatal(err)
+	}
+	subscribe(t, notifications, follower, "follower@example.com")
+	created := []*models.Answer{
+		{QuestionID: question.ID, UserID: answerer, Text: "Because."},
+		{QuestionID: question.ID, UserID: follower, Text: "my own answer"},
+	}
+	if err := answers.CreateAnswer(ctx, created); err != nil {
+		t.Fatal(err)
+	}
+
+	memory := mailer.NewMemory()
+	cfg := config.DigestConfig{BatchSize: 10, BaseURL: "https://qa.example.com/"}
+	sender := NewSender(slog.Default(), cfg, "digest@example.com", mock.NewMockStorageDigests(notifications, answers), memory)
+	// The mocks create everything at the same instant.
+	now := created[0].CreatedAt.Add(time.Hour)
+	sender.now = func() time.Time { return now }
+
+	return sender, memory, now
+}
+
+func TestSendEndsWhenMailsFail(t *testing.T) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	notifications := mock.NewMockStorageNotifications(questions)
+	question := &models.Question{Text: "Why?", Tags: []string{"go"}}
+	if err := questions.CreateQuestion(ctx, question); err != nil {
+		t.Fatal(err)
+	}
+	for _, userID := range []string{follower, answerer} {
+		subscribe(t, notifications, userID, "user@example.com")
+		notifications.FollowTag(ctx, "go", userID)
+	}
+	// With a page of one user, a pass that retried the failed digests
+	// would never end.
+	cfg := config.DigestConfig{BatchSize: 1, BaseURL: "https://qa.example.com"}
+	sender := NewSender(slog.Default(), cfg, "digest@example.com", mock.NewMockStorageDigests(notifications, answers), failingMailer{})
+	sender.now = func() time.Time { return question.CreatedAt.Add(time.Hour) }
+
+	done := make(chan int)
+	go func() {
+		sent, _ := sender.Send(ctx)
+		done <- sent
+	}()
+	select {
+	case sent := <-done:
+		if sent != 0 {
+			t.Errorf("Excpected no digest, got %d", sent)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Excpected Send to end when every mail fails")
+	}
+}
+
+func TestDigestListsUnansweredQuestionsInFollowedTags(t *testing.T) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	notifications := mock.NewMockStorageNotifications(questions)
+	subscribe(t, notifications, follower, "follower@example.com")
+	notifications.FollowTag(ctx, "go", follower)
+	own := follower
+	created := []*models.Question{
+		{Text: "How do channels work?", Tags: []string{"go", "sql"}},
+		{Text: "What is a JOIN?", Tags: []string{"sql"}},
+		{Text: "Answered", Tags: []string{"go"}},
+		{Text: "My own question", Tags: []string{"go"}, UserID: &own},
+	}
+	for _, question := range created {
+		if err := questions.CreateQuestion(ctx, question); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := answers.CreateAnswer(ctx, []*models.Answer{{QuestionID: 3, UserID: answerer, Text: "Yes."}}); err != nil {
+		t.Fatal(err)
+	}
+
+	memory := mailer.NewMemory()
+	cfg := config.DigestConfig{BatchSize: 10, BaseURL: "https://qa.example.com"}
+	sender := NewSender(slog.Default(), cfg, "digest@example.com", mock.NewMockStorageDigests(notifications, answers), memory)
+	now := created[0].CreatedAt.Add(time.Hour)
+	sender.now = func() time.Time { return now }
+
+	if sent, _ := sender.Send(ctx); sent != 1 {
+		t.Fatalf("Excpected 1 digest, got %d", sent)
+	}
+	message := memory.Messages()[0]
+	if message.Subject != "Your daily digest: 1 unanswered question" {
+		t.Errorf("Excpected a digest of 1 unanswered question, got %q", message.Subject)
+	}
+	if !strings.Contains(message.Text, "How do channels work? [go, sql]") || !strings.Contains(message.Text, "https://qa.example.com/v1/questions/1") {
+		t.Errorf("Excpected the unanswered question in go, got %s", message.Text)
+	}
+	for _, text := range []string{"What is a JOIN?", "Answered", "My own question"} {
+		if strings.Contains(message.Text, text) {
+			t.Errorf("Excpected no %q in the digest, got %s", text, message.Text)
+		}
+	}
+}
+
+func subscribe(t *testing.T, notifications *mock.MockStorageNotifications, userID, email string) {
+	preferences := models.DefaultNotificationPreferences(userID)
+	preferences.Email = email
+	preferences.Digest = models.DigestDaily
+	verified := time.Now()
+	preferences.EmailVerifiedAt = &verified
+	if err := notifications.SaveNotificationPreferences(context.Background(), &preferences); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,20 +1,29 @@
 package endtoend
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/behummble/Questions-answers/internal/attachments"
+	"github.com/behummble/Questions-answers/internal/events"
 	"github.com/behummble/Questions-answers/internal/mock"
 	"github.com/behummble/Questions-answers/internal/models"
 	srv "github.com/behummble/Questions-answers/internal/handlers/http"
 	"github.com/behummble/Questions-answers/internal/service"
 	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/gorilla/websocket"
 )
 
 func TestEndToEnd(t *testing.T) {
@@ -24,10 +33,25 @@ func TestEndToEnd(t *testing.T) {
 	ctx := context.Background()
 
     // Инициализация сервиса
-    svc := service.NewService(slog.Default(), mockQuestionStorage, mockAnswerStorage)
+    hub := events.NewHub(16, 16)
+    dispatcher := events.NewDispatcher(slog.Default(), mockAnswerStorage.Outbox(), config.EventsConfig{PollInterval: 10*time.Millisecond, BatchSize: 100, GapTimeout: time.Second})
+    dispatcher.SubscribeLocal("hub", hub.Handle)
+    go dispatcher.Run(ctx)
+    defer dispatcher.Shutdown(ctx)
+    svc := service.NewService(slog.Default(), mockQuestionStorage, mockAnswerStorage, mock.NewMockStorageUsers(mockQuestionStorage), mock.NewMockStorageComments(mockQuestionStorage), config.ReputationConfig{}, config.DuplicatesConfig{}, dispatcher)
+
+    blobs, err := attachments.NewLocal(t.TempDir())
+    if err != nil {
+        t.Fatal(err)
+    }
+    attachmentsConfig := config.AttachmentsConfig{MaxSize: 1 << 20, AllowedTypes: []string{"image/png", "text/plain"}, URLTTL: time.Minute, SigningKey: "test"}
+    manager, err := attachments.NewManager(slog.Default(), attachmentsConfig, mock.NewMockStorageAttachments(mockQuestionStorage), blobs)
+    if err != nil {
+        t.Fatal(err)
+    }
 
     // Инициализация сервера
-    srv := srv.NewServer(ctx, slog.Default(), &config.ServerConfig{}, svc)
+    srv := srv.NewServer(ctx, slog.Default(), &config.ServerConfig{AdminToken: "secret"}, svc, srv.Deps{Events: hub, Attachments: manager})
     testServer := httptest.NewServer(srv.GetHandler())
     defer testServer.Close()
 
@@ -149,6 +173,7 @@ func TestEndToEnd(t *testing.T) {
         if getAnswerResp.Answer.ID != answerID {
             t.Errorf("Expected answer ID %d, got %d", answerID, getAnswerResp.Answer.ID)
         }
+        answerETag := resp.Header.Get("ETag")
 
         // 6. Проверка, что ответ привязан к вопросу
         resp, err = client.Get(fmt.Sprintf("%s/questions/%d", testServer.URL, questionID))
@@ -170,6 +195,7 @@ func TestEndToEnd(t *testing.T) {
         if err != nil {
             t.Fatalf("Failed to create delete request: %v", err)
         }
+        req.Header.Set("If-Match", answerETag)
 
         resp, err = client.Do(req)
         if err != nil {
@@ -273,6 +299,7 @@ func TestEndToEnd(t *testing.T) {
         if err != nil {
             t.Fatalf("Failed to create delete request: %v", err)
         }
+        req.Header.Set("If-Match", resp.Header.Get("ETag"))
 
         resp, err = client.Do(req)
         if err != nil {
@@ -309,4 +336,316 @@ func TestEndToEnd(t *testing.T) {
 			}
 		}
     })
+    t.Run("Markdown formats", func(t *testing.T) {
+        reqBody, _ := json.Marshal(models.CreateQuestionRequest{Text: "Why does `<script>` **break**?"})
+        resp, err := client.Post(testServer.URL+"/questions", "application/json", bytes.NewBuffer(reqBody))
+        if err != nil {
+            t.Fatalf("Failed to create question: %v", err)
+        }
+        var createQuestionResp models.CreateQuestionResponse
+        if err := json.NewDecoder(resp.Body).Decode(&createQuestionResp); err != nil {
+            t.Fatalf("Failed to decode response: %v", err)
+        }
+        resp.Body.Close()
+        excpectedHTML := "<p>Why does <code>&lt;script&gt;</code> <strong>break</strong>?</p>\n"
+        if createQuestionResp.Question.TextHTML != excpectedHTML {
+            t.Errorf("Expected HTML %q, got %q", excpectedHTML, createQuestionResp.Question.TextHTML)
+        }
+
+        for format, excpected := range map[string]models.Question{
+            "html": {TextHTML: excpectedHTML},
+            "markdown": {Text: "Why does `<script>` **break**?"},
+        } {
+            resp, err := client.Get(fmt.Sprintf("%s/questions/%d?format=%s", testServer.URL, createQuestionResp.Question.ID, format))
+            if err != nil {
+                t.Fatalf("Failed to get question: %v", err)
+            }
+            var getQuestionResp models.GetQuestionResponse
+            if err := json.NewDecoder(resp.Body).Decode(&getQuestionResp); err != nil {
+                t.Fatalf("Failed to decode response: %v", err)
+            }
+            resp.Body.Close()
+            if getQuestionResp.Question.Text != excpected.Text || getQuestionResp.Question.TextHTML != excpected.TextHTML {
+                t.Errorf("Expected %s only, got %+v", format, getQuestionResp.Question)
+            }
+        }
+    })
+    t.Run("Duplicate redirects readers", func(t *testing.T) {
+        ids := make([]int, 0, 2)
+        for _, text := range []string{"What is a channel?", "What is a Go channel?"} {
+            reqBody, _ := json.Marshal(models.CreateQuestionRequest{Text: text})
+            resp, err := client.Post(testServer.URL+"/questions", "application/json", bytes.NewBuffer(reqBody))
+            if err != nil {
+                t.Fatalf("Failed to create question: %v", err)
+            }
+            var createQuestionResp models.CreateQuestionResponse
+            if err := json.NewDecoder(resp.Body).Decode(&createQuestionResp); err != nil {
+                t.Fatalf("Failed to decode response: %v", err)
+            }
+            resp.Body.Close()
+            ids = append(ids, createQuestionResp.Question.ID)
+        }
+
+        resp, err := client.Get(fmt.Sprintf("%s/questions/%d", testServer.URL, ids[1]))
+        if err != nil {
+            t.Fatalf("Failed to get question: %v", err)
+        }
+        resp.Body.Close()
+        body := strings.NewReader(`{"UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6"}`)
+        req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/questions/%d/duplicate-of/%d", testServer.URL, ids[1], ids[0]), body)
+        req.Header.Set("Content-Type", "application/json")
+        req.Header.Set("If-Match", resp.Header.Get("ETag"))
+        req.Header.Set("Authorization", "Bearer secret")
+        resp, err = client.Do(req)
+        if err != nil {
+            t.Fatalf("Failed to mark duplicate: %v", err)
+        }
+        resp.Body.Close()
+        if resp.StatusCode != http.StatusOK {
+            t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+        }
+
+        resp, err = client.Get(fmt.Sprintf("%s/questions/%d", testServer.URL, ids[1]))
+        if err != nil {
+            t.Fatalf("Failed to get duplicate: %v", err)
+        }
+        defer resp.Body.Close()
+        var getQuestionResp models.GetQuestionResponse
+        if err := json.NewDecoder(resp.Body).Decode(&getQuestionResp); err != nil {
+            t.Fatalf("Failed to decode response: %v", err)
+        }
+        if getQuestionResp.Question.ID != ids[0] {
+            t.Errorf("Expected redirect to question %d, got %d", ids[0], getQuestionResp.Question.ID)
+        }
+    })
+    t.Run("Attachment lifecycle", func(t *testing.T) {
+        reqBody, _ := json.Marshal(models.CreateQuestionRequest{Text: "Why does my build fail?"})
+        resp, err := client.Post(testServer.URL+"/questions", "application/json", bytes.NewBuffer(reqBody))
+        if err != nil {
+            t.Fatalf("Failed to create question: %v", err)
+        }
+        var createQuestionResp models.CreateQuestionResponse
+        if err := json.NewDecoder(resp.Body).Decode(&createQuestionResp); err != nil {
+            t.Fatalf("Failed to decode response: %v", err)
+        }
+        resp.Body.Close()
+        questionID := createQuestionResp.Question.ID
+
+        var form bytes.Buffer
+        writer := multipart.NewWriter(&form)
+        writer.WriteField("UserID", "3fa85f64-5717-4562-b3fc-2c963f66afa6")
+        part, _ := writer.CreateFormFile("file", "build.log")
+        part.Write([]byte("error: undefined: foo\n"))
+        writer.Close()
+        resp, err = client.Post(fmt.Sprintf("%s/questions/%d/attachments", testServer.URL, questionID), writer.FormDataContentType(), &form)
+        if err != nil {
+            t.Fatalf("Failed to upload attachment: %v", err)
+        }
+        var createAttachmentResp models.CreateAttachmentResponse
+        if err := json.NewDecoder(resp.Body).Decode(&createAttachmentResp); err != nil {
+            t.Fatalf("Failed to decode response: %v", err)
+        }
+        resp.Body.Close()
+        if resp.StatusCode != http.StatusCreated {
+            t.Fatalf("Expected status 201, got %d", resp.StatusCode)
+        }
+
+        resp, err = client.Get(fmt.Sprintf("%s/questions/%d/attachments", testServer.URL, questionID))
+        if err != nil {
+            t.Fatalf("Failed to list attachments: %v", err)
+        }
+        var getAttachmentsResp models.GetAttachmentsResponse
+        if err := json.NewDecoder(resp.Body).Decode(&getAttachmentsResp); err != nil {
+            t.Fatalf("Failed to decode response: %v", err)
+        }
+        resp.Body.Close()
+        if len(getAttachmentsResp.Attachments) != 1 || getAttachmentsResp.Attachments[0].Filename != "build.log" {
+            t.Fatalf("Expected the uploaded attachment, got %+v", getAttachmentsResp.Attachments)
+        }
+
+        resp, err = client.Get(testServer.URL + getAttachmentsResp.Attachments[0].URL)
+        if err != nil {
+            t.Fatalf("Failed to download attachment: %v", err)
+        }
+        body, _ := io.ReadAll(resp.Body)
+        resp.Body.Close()
+        if resp.StatusCode != http.StatusOK || string(body) != "error: undefined: foo\n" {
+            t.Errorf("Expected the uploaded file, got %d %q", resp.StatusCode, body)
+        }
+        if disposition := resp.Header.Get("Content-Disposition"); disposition != `attachment; filename=build.log` {
+            t.Errorf("Expected download as build.log, got %q", disposition)
+        }
+
+        resp, err = client.Get(fmt.Sprintf("%s/questions/%d", testServer.URL, questionID))
+        if err != nil {
+            t.Fatalf("Failed to get question: %v", err)
+        }
+        resp.Body.Close()
+        req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/questions/%d", testServer.URL, questionID), nil)
+        req.Header.Set("If-Match", resp.Header.Get("ETag"))
+        resp, err = client.Do(req)
+        if err != nil {
+            t.Fatalf("Failed to delete question: %v", err)
+        }
+        resp.Body.Close()
+        if err := manager.Sweep(ctx); err != nil {
+            t.Fatalf("Failed to sweep attachments: %v", err)
+        }
+        resp, err = client.Get(testServer.URL + createAttachmentResp.Attachment.URL)
+        if err != nil {
+            t.Fatalf("Failed to download attachment: %v", err)
+        }
+        resp.Body.Close()
+        if resp.StatusCode != http.StatusNotFound {
+            t.Errorf("Expected status 404 after deleting the question, got %d", resp.StatusCode)
+        }
+    })
+    t.Run("Answer events stream", func(t *testing.T) {
+        reqBody, _ := json.Marshal(models.CreateQuestionRequest{Text: "What is SSE?"})
+        resp, err := client.Post(testServer.URL+"/questions", "application/json", bytes.NewBuffer(reqBody))
+        if err != nil {
+            t.Fatalf("Failed to create question: %v", err)
+        }
+        var createQuestionResp models.CreateQuestionResponse
+        if err := json.NewDecoder(resp.Body).Decode(&createQuestionResp); err != nil {
+            t.Fatalf("Failed to decode response: %v", err)
+        }
+        resp.Body.Close()
+        eventsURL := fmt.Sprintf("%s/questions/%d/events", testServer.URL, createQuestionResp.Question.ID)
+
+        streamCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+        defer cancel()
+        req, _ := http.NewRequestWithContext(streamCtx, "GET", eventsURL, nil)
+        stream, err := client.Do(req)
+        if err != nil {
+            t.Fatalf("Failed to open event stream: %v", err)
+        }
+        defer stream.Body.Close()
+
+        if ct := stream.Header.Get("Content-Type"); ct != "text/event-stream" {
+            t.Fatalf("Expected text/event-stream, got %q", ct)
+        }
+
+        reqBody, _ = json.Marshal(models.CreateAnswerRequest{
+            Texts: []string{"Server-Sent Events"},
+            UserID: "123e4567-e89b-12d3-a456-426614174000",
+        })
+        resp, err = client.Post(
+            fmt.Sprintf("%s/questions/%d/answers", testServer.URL, createQuestionResp.Question.ID),
+            "application/json",
+            bytes.NewBuffer(reqBody),
+        )
+        if err != nil {
+            t.Fatalf("Failed to create answer: %v", err)
+        }
+        resp.Body.Close()
+
+        id, eventType := readEvent(t, bufio.NewReader(stream.Body))
+        if eventType != events.AnswerCreated {
+            t.Errorf("Expected %s event, got %q", events.AnswerCreated, eventType)
+        }
+        stream.Body.Close()
+
+        // Reconnecting from before the event replays it from the buffer.
+        lastID, _ := strconv.Atoi(id)
+        req, _ = http.NewRequestWithContext(streamCtx, "GET", eventsURL, nil)
+        req.Header.Set("Last-Event-ID", strconv.Itoa(lastID-1))
+        replay, err := client.Do(req)
+        if err != nil {
+            t.Fatalf("Failed to reopen event stream: %v", err)
+        }
+        defer replay.Body.Close()
+
+        replayID, eventType := readEvent(t, bufio.NewReader(replay.Body))
+        if replayID != id || eventType != events.AnswerCreated {
+            t.Errorf("Expected replay of event %s, got %s %q", id, replayID, eventType)
+        }
+    })
+    t.Run("WebSocket live feed", func(t *testing.T) {
+        wsURL := "ws" + strings.TrimPrefix(testServer.URL, "http") + "/ws"
+        conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+        if err != nil {
+            t.Fatalf("Failed to open websocket: %v", err)
+        }
+        defer conn.Close()
+        conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+        reqBody, _ := json.Marshal(models.CreateQuestionRequest{Text: "What is WebSocket?"})
+        resp, err := client.Post(testServer.URL+"/questions", "application/json", bytes.NewBuffer(reqBody))
+        if err != nil {
+            t.Fatalf("Failed to create question: %v", err)
+        }
+        resp.Body.Close()
+
+        var frame struct {
+            Type string
+            Payload events.Event
+        }
+        if err := conn.ReadJSON(&frame); err != nil {
+            t.Fatalf("Failed to read frame: %v", err)
+        }
+        if frame.Type != events.QuestionCreated {
+            t.Fatalf("Expected %s frame, got %q", events.QuestionCreated, frame.Type)
+        }
+        questionID := frame.Payload.QuestionID
+
+        subscribe := fmt.Sprintf(`{"type":"subscribe","payload":{"question_ids":[%d]}}`, questionID)
+        if err := conn.WriteMessage(websocket.TextMessage, []byte(subscribe)); err != nil {
+            t.Fatalf("Failed to subscribe: %v", err)
+        }
+        if err := conn.ReadJSON(&frame); err != nil || frame.Type != "subscribed" {
+            t.Fatalf("Expected subscribed ack, got %q: %v", frame.Type, err)
+        }
+
+        reqBody, _ = json.Marshal(models.CreateAnswerRequest{
+            Texts: []string{"A full-duplex protocol"},
+            UserID: "123e4567-e89b-12d3-a456-426614174000",
+        })
+        resp, err = client.Post(
+            fmt.Sprintf("%s/questions/%d/answers", testServer.URL, questionID),
+            "application/json",
+            bytes.NewBuffer(reqBody),
+        )
+        if err != nil {
+            t.Fatalf("Failed to create answer: %v", err)
+        }
+        resp.Body.Close()
+
+        if err := conn.ReadJSON(&frame); err != nil {
+            t.Fatalf("Failed to read frame: %v", err)
+        }
+        if frame.Type != events.AnswerCreated || frame.Payload.QuestionID != questionID {
+            t.Errorf("Expected %s frame for question %d, got %+v", events.AnswerCreated, questionID, frame)
+        }
+
+        // Shutdown drains the connection with a going-away close frame.
+        shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+        defer cancel()
+        if err := srv.Shutdown(shutdownCtx); err != nil {
+            t.Fatalf("Failed to shutdown: %v", err)
+        }
+        _, _, err = conn.ReadMessage()
+        if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+            t.Errorf("Expected going away close, got %v", err)
+        }
+    })
+}
+
+func readEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+    var id, eventType string
+    for {
+        line, err := reader.ReadString('\n')
+        if err != nil {
+            t.Fatalf("Failed to read event: %v", err)
+        }
+        line = strings.TrimRight(line, "\n")
+        switch {
+        case strings.HasPrefix(line, "id: "):
+            id = strings.TrimPrefix(line, "id: ")
+        case strings.HasPrefix(line, "event: "):
+            eventType = strings.TrimPrefix(line, "event: ")
+        case line == "" && eventType != "":
+            return id, eventType
+        }
+    }
 }
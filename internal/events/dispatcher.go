@@ -0,0 +1,276 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+type OutboxStorage interface {
+	ClaimOutbox(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.OutboxEvent, error)
+	MarkOutboxProcessed(ctx context.Context, id int64) error
+	MarkOutboxFailed(ctx context.Context, id int64) error
+	OutboxAfter(ctx context.Context, id int64, limit int) ([]models.OutboxEvent, error)
+	OutboxCursor(ctx context.Context) (int64, error)
+}
+
+// Handler consumes one event. Delivery is at-least-once, so handlers must
+// tolerate seeing an event again after a failure or a restart.
+type Handler func(ctx context.Context, event Event) error
+
+type subscriber struct {
+	name string
+	handle Handler
+}
+
+// claim records the subscribers that handled an event claimed by this
+// replica until its lease runs out.
+type claim struct {
+	subscribers map[string]struct{}
+	until time.Time
+}
+
+// Dispatcher relays outbox events to in-process subscribers.
+//
+// Subscribers registered with Subscribe see every event once across the
+// replicas: a replica claims a batch for the configured lease, and an event
+// is marked processed once every subscriber has handled it. A failing
+// subscriber gets the event again when the lease runs out, on this replica
+// without calling the others twice, or on any replica after a restart. After
+// MaxAttempts claims the event is dead-lettered.
+//
+// Subscribers registered with SubscribeLocal serve connections of a single
+// process, like the Hub, and see every event on every replica in outbox ID
+// order. They are fed from a cursor of this replica over all outbox rows,
+// and are not retried.
+type Dispatcher struct {
+	log *slog.Logger
+	storage OutboxStorage
+	cfg config.EventsConfig
+	now func() time.Time
+	subscribers []subscriber
+	handled map[uint64]*claim
+	local []subscriber
+	cursor int64
+	tailing bool
+	pending map[int64]Event
+	gapSince time.Time
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewDispatcher(log *slog.Logger, storage OutboxStorage, cfg config.EventsConfig) *Dispatcher {
+	return &Dispatcher{
+		log: log,
+		storage: storage,
+		cfg: cfg,
+		now: time.Now,
+		handled: make(map[uint64]*claim),
+		pending: make(map[int64]Event),
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Subscribe registers a handler under a unique name. It must be called
+// before Run.
+func(d *Dispatcher) Subscribe(name string, handle Handler) {
+	d.subscribers = append(d.subscribers, subscriber{name: name, handle: handle})
+}
+
+// SubscribeLocal registers a handler that sees the events of all replicas on
+// this one. It must be called before Run.
+func(d *Dispatcher) SubscribeLocal(name string, handle Handler) {
+	d.local = append(d.local, subscriber{name: name, handle: handle})
+}
+
+// Notify triggers a poll without waiting for the interval. The service calls
+// it after every successful write.
+func(d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func(d *Dispatcher) Run(ctx context.Context) {
+	defer close(d.done)
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		d.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stop:
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// Shutdown stops polling and waits for the current batch to finish.
+func(d *Dispatcher) Shutdown(ctx context.Context) {
+	close(d.stop)
+	select {
+	case <-d.done:
+	case <-ctx.Done():
+	}
+}
+
+// Poll feeds the local subscribers and relays one claimed batch in outbox
+// order.
+func(d *Dispatcher) Poll(ctx context.Context) {
+	d.tail(ctx)
+	if len(d.subscribers) == 0 {
+		return
+	}
+	now := d.now()
+	claimed, err := d.storage.ClaimOutbox(ctx, now, d.cfg.Lease, d.cfg.BatchSize)
+	if err != nil {
+		d.logError("DB_ReadingError", "", err)
+		return
+	}
+	d.forget(now, claimed)
+	for _, row := range claimed {
+		event := FromOutbox(row)
+		if !d.dispatch(ctx, event, now.Add(d.cfg.Lease)) {
+			if row.Attempts >= d.cfg.MaxAttempts {
+				d.deadLetter(ctx, row)
+			}
+			continue
+		}
+		if err := d.storage.MarkOutboxProcessed(ctx, row.ID); err != nil {
+			d.logError("DB_WritingError", "", err)
+			continue
+		}
+		delete(d.handled, event.ID)
+	}
+}
+
+func(d *Dispatcher) deadLetter(ctx context.Context, row models.OutboxEvent) {
+	if err := d.storage.MarkOutboxFailed(ctx, row.ID); err != nil {
+		d.logError("DB_WritingError", "", err)
+		return
+	}
+	delete(d.handled, uint64(row.ID))
+	d.log.Warn(
+		fmt.Sprintf("Dead-letter event with id: %d after %d attempts", row.ID, row.Attempts),
+		slog.String("component", "events"),
+		slog.String("type", row.Type),
+	)
+}
+
+// forget drops the subscribers recorded for events whose lease ran out and
+// that were not claimed again, as another replica processed them.
+func(d *Dispatcher) forget(now time.Time, claimed []models.OutboxEvent) {
+	ids := make(map[uint64]struct{}, len(claimed))
+	for _, row := range claimed {
+		ids[uint64(row.ID)] = struct{}{}
+	}
+	for id, claim := range d.handled {
+		if _, ok := ids[id]; !ok && !claim.until.After(now) {
+			delete(d.handled, id)
+		}
+	}
+}
+
+// tail hands the events after the cursor to the local subscribers. IDs are
+// taken when a transaction inserts, not when it commits, so an event can
+// show up after higher ones: the cursor stops at a missing ID until
+// GapTimeout, and the events above it are held in pending, so subscribers
+// resuming after an ID, like the Hub, do not skip the lower one.
+func(d *Dispatcher) tail(ctx context.Context) {
+	if len(d.local) == 0 {
+		return
+	}
+	if !d.tailing {
+		cursor, err := d.storage.OutboxCursor(ctx)
+		if err != nil {
+			d.logError("DB_ReadingError", "", err)
+			return
+		}
+		d.cursor, d.tailing = cursor, true
+	}
+	rows, err := d.storage.OutboxAfter(ctx, d.cursor, d.cfg.BatchSize)
+	if err != nil {
+		d.logError("DB_ReadingError", "", err)
+		return
+	}
+	for _, row := range rows {
+		d.pending[row.ID] = FromOutbox(row)
+	}
+	d.advance(ctx)
+}
+
+// advance moves the cursor over the pending events that follow it and
+// hands them to the local subscribers.
+func(d *Dispatcher) advance(ctx context.Context) {
+	for {
+		event, ok := d.pending[d.cursor+1]
+		if !ok {
+			break
+		}
+		for _, sub := range d.local {
+			if err := sub.handle(ctx, event); err != nil {
+				d.logError("EventHandlingError", sub.name, err)
+			}
+		}
+		delete(d.pending, d.cursor+1)
+		d.cursor++
+	}
+	if len(d.pending) == 0 {
+		d.gapSince = time.Time{}
+		return
+	}
+	if d.gapSince.IsZero() {
+		d.gapSince = d.now()
+		return
+	}
+	if d.now().Sub(d.gapSince) < d.cfg.GapTimeout {
+		return
+	}
+	// The missing IDs were rolled back or their transaction is stuck.
+	d.cursor = slices.Min(slices.Collect(maps.Keys(d.pending))) - 1
+	d.gapSince = time.Time{}
+	d.advance(ctx)
+}
+
+func(d *Dispatcher) dispatch(ctx context.Context, event Event, until time.Time) bool {
+	handled, ok := d.handled[event.ID]
+	if !ok {
+		handled = &claim{subscribers: make(map[string]struct{}, len(d.subscribers))}
+		d.handled[event.ID] = handled
+	}
+	handled.until = until
+	for _, sub := range d.subscribers {
+		if _, ok := handled.subscribers[sub.name]; ok {
+			continue
+		}
+		if err := sub.handle(ctx, event); err != nil {
+			d.logError("EventHandlingError", sub.name, err)
+			continue
+		}
+		handled.subscribers[sub.name] = struct{}{}
+	}
+
+	return len(handled.subscribers) == len(d.subscribers)
+}
+
+func(d *Dispatcher) logError(msg, subscriber string, err error) {
+	d.log.Error(
+		msg,
+		slog.String("component", "events"),
+		slog.String("subscriber", subscriber),
+		slog.Any("error", err),
+	)
+}
@@ -0,0 +1,278 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+type outbox struct {
+	rows []models.OutboxEvent
+}
+
+func(o *outbox) ClaimOutbox(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.OutboxEvent, error) {
+	res := make([]models.OutboxEvent, 0)
+	for ind, v := range o.rows {
+		if v.ProcessedAt != nil || v.FailedAt != nil || v.LockedUntil != nil && v.LockedUntil.After(now) || len(res) == limit {
+			continue
+		}
+		lockedUntil := now.Add(lease)
+		o.rows[ind].LockedUntil = &lockedUntil
+		o.rows[ind].Attempts++
+		res = append(res, o.rows[ind])
+	}
+	return res, nil
+}
+
+func(o *outbox) MarkOutboxProcessed(ctx context.Context, id int64) error {
+	for ind := range o.rows {
+		if o.rows[ind].ID == id {
+			now := time.Now()
+			o.rows[ind].ProcessedAt = &now
+		}
+	}
+	return nil
+}
+
+func(o *outbox) MarkOutboxFailed(ctx context.Context, id int64) error {
+	for ind := range o.rows {
+		if o.rows[ind].ID == id {
+			now := time.Now()
+			o.rows[ind].FailedAt = &now
+		}
+	}
+	return nil
+}
+
+func(o *outbox) OutboxAfter(ctx context.Context, id int64, limit int) ([]models.OutboxEvent, error) {
+	res := make([]models.OutboxEvent, 0)
+	for _, v := range o.rows {
+		if v.ID > id && len(res) < limit {
+			res = append(res, v)
+		}
+	}
+	return res, nil
+}
+
+func(o *outbox) OutboxCursor(ctx context.Context) (int64, error) {
+	for _, v := range o.rows {
+		if v.ProcessedAt == nil && v.FailedAt == nil {
+			return v.ID - 1, nil
+		}
+	}
+	if len(o.rows) == 0 {
+		return 0, nil
+	}
+	return o.rows[len(o.rows)-1].ID, nil
+}
+
+func(o *outbox) add(id int64, event Event) {
+	row := event.Outbox()
+	row.ID = id
+	o.rows = append(o.rows, row)
+}
+
+func TestDispatcherRetriesFailingSubscriber(t *testing.T) {
+	ctx := context.Background()
+	storage := &outbox{}
+	storage.add(1, NewAnswerCreated(models.Answer{ID: 2, QuestionID: 1}))
+
+	calls := map[string]int{}
+	fail := true
+	d := NewDispatcher(slog.Default(), storage, testConfig())
+	d.Subscribe("hub", func(ctx context.Context, event Event) error {
+		calls["hub"]++
+		return nil
+	})
+	d.Subscribe("webhooks", func(ctx context.Context, event Event) error {
+		calls["webhooks"]++
+		if fail {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+
+	d.Poll(ctx)
+	if storage.rows[0].ProcessedAt != nil {
+		t.Fatal("Excpected event to stay pending while a subscriber fails")
+	}
+
+	fail = false
+	d.Poll(ctx)
+	if calls["webhooks"] != 1 {
+		t.Errorf("Excpected claimed event not to be retried before the lease ends, got %v", calls)
+	}
+	d.now = func() time.Time { return time.Now().Add(time.Minute) }
+	d.Poll(ctx)
+	if storage.rows[0].ProcessedAt == nil {
+		t.Error("Excpected event to be processed after all subscribers succeed")
+	}
+	if calls["hub"] != 1 || calls["webhooks"] != 2 {
+		t.Errorf("Excpected hub called once and webhooks twice, got %v", calls)
+	}
+
+	d.Poll(ctx)
+	if calls["hub"] != 1 || calls["webhooks"] != 2 {
+		t.Errorf("Excpected processed event not to be dispatched again, got %v", calls)
+	}
+}
+
+func TestDispatcherForgetsEventsOfOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	storage := &outbox{}
+	storage.add(1, NewQuestionCreated(models.Question{ID: 1}))
+	d := NewDispatcher(slog.Default(), storage, testConfig())
+	d.Subscribe("webhooks", func(ctx context.Context, event Event) error {
+		return errors.New("unavailable")
+	})
+
+	d.Poll(ctx)
+	d.Poll(ctx)
+	if len(d.handled) != 1 {
+		t.Errorf("Excpected the claimed event to be remembered during its lease, got %d", len(d.handled))
+	}
+
+	// Another replica claims the event after the lease and processes it.
+	now := time.Now()
+	storage.rows[0].ProcessedAt = &now
+	d.now = func() time.Time { return time.Now().Add(time.Minute) }
+	d.Poll(ctx)
+	if len(d.handled) != 0 {
+		t.Errorf("Excpected the event processed elsewhere to be forgotten, got %d", len(d.handled))
+	}
+}
+
+func TestDispatcherKeepsOutboxIDs(t *testing.T) {
+	storage := &outbox{}
+	for i := 1; i <= 2; i++ {
+		storage.add(int64(i + 10), NewQuestionCreated(models.Question{ID: i}))
+	}
+
+	hub := NewHub(4, 4)
+	d := NewDispatcher(slog.Default(), storage, testConfig())
+	d.SubscribeLocal("hub", hub.Handle)
+	d.Poll(context.Background())
+
+	replay, sub := hub.Subscribe(ForQuestion(2), 0)
+	defer sub.Close()
+	if len(replay) != 1 || replay[0].ID != 12 {
+		t.Fatalf("Excpected event 12 of question 2, got %+v", replay)
+	}
+	question, err := Decode[models.Question](replay[0])
+	if err != nil || question.ID != 2 {
+		t.Errorf("Excpected payload of question 2, got %+v %v", question, err)
+	}
+}
+
+func TestDispatcherDeadLettersEvent(t *testing.T) {
+	ctx := context.Background()
+	storage := &outbox{}
+	storage.add(1, NewQuestionCreated(models.Question{ID: 1}))
+
+	calls := 0
+	d := NewDispatcher(slog.Default(), storage, testConfig())
+	d.Subscribe("webhooks", func(ctx context.Context, event Event) error {
+		calls++
+		return errors.New("unavailable")
+	})
+	for i := 0; i < 4; i++ {
+		d.now = func() time.Time { return time.Now().Add(time.Duration(i) * time.Minute) }
+		d.Poll(ctx)
+	}
+
+	if storage.rows[0].FailedAt == nil || storage.rows[0].ProcessedAt != nil {
+		t.Errorf("Excpected event to be dead-lettered, got %+v", storage.rows[0])
+	}
+	if calls != 2 {
+		t.Errorf("Excpected 2 attempts, got %d", calls)
+	}
+}
+
+func TestDispatchersShareClaimedEvents(t *testing.T) {
+	ctx := context.Background()
+	storage := &outbox{}
+
+	handled := make([]uint64, 0)
+	hubs := make([]*Hub, 0)
+	replicas := make([]*Dispatcher, 0)
+	for i := 0; i < 2; i++ {
+		hub := NewHub(4, 4)
+		d := NewDispatcher(slog.Default(), storage, testConfig())
+		d.SubscribeLocal("hub", hub.Handle)
+		d.Subscribe("reputation", func(ctx context.Context, event Event) error {
+			handled = append(handled, event.ID)
+			return nil
+		})
+		d.Poll(ctx)
+		hubs = append(hubs, hub)
+		replicas = append(replicas, d)
+	}
+	storage.add(1, NewQuestionCreated(models.Question{ID: 1}))
+	storage.add(2, NewQuestionCreated(models.Question{ID: 2}))
+	for _, d := range replicas {
+		d.Poll(ctx)
+	}
+
+	if len(handled) != 2 || handled[0] != 1 || handled[1] != 2 {
+		t.Errorf("Excpected each event handled once, got %v", handled)
+	}
+	for i, hub := range hubs {
+		if hub.LastID() != 2 {
+			t.Errorf("Excpected hub %d to see both events, got last id %d", i, hub.LastID())
+		}
+	}
+}
+
+func TestLocalSubscribersWaitForGaps(t *testing.T) {
+	ctx := context.Background()
+	storage := &outbox{}
+	hub := NewHub(8, 8)
+	d := NewDispatcher(slog.Default(), storage, testConfig())
+	d.SubscribeLocal("hub", hub.Handle)
+	d.Poll(ctx)
+
+	// Event 1 commits after event 2.
+	storage.add(2, NewQuestionCreated(models.Question{ID: 2}))
+	d.Poll(ctx)
+	if hub.LastID() != 0 {
+		t.Errorf("Excpected event 2 held back until event 1, got last id %d", hub.LastID())
+	}
+	storage.rows = append([]models.OutboxEvent{NewQuestionCreated(models.Question{ID: 1}).Outbox()}, storage.rows...)
+	storage.rows[0].ID = 1
+	d.Poll(ctx)
+	replay, sub := hub.Subscribe(nil, 0)
+	sub.Close()
+	if len(replay) != 2 || replay[0].ID != 1 || d.cursor != 2 {
+		t.Errorf("Excpected both events and cursor 2, got %+v and %d", replay, d.cursor)
+	}
+
+	// Event 3 is rolled back.
+	storage.add(4, NewQuestionCreated(models.Question{ID: 4}))
+	d.Poll(ctx)
+	if d.cursor != 2 {
+		t.Errorf("Excpected cursor to wait at the gap, got %d", d.cursor)
+	}
+	d.now = func() time.Time { return time.Now().Add(time.Minute) }
+	d.Poll(ctx)
+	if d.cursor != 4 || len(d.pending) != 0 {
+		t.Errorf("Excpected cursor past the gap, got %d", d.cursor)
+	}
+	if hub.LastID() != 4 {
+		t.Errorf("Excpected event 4 published once, got last id %d", hub.LastID())
+	}
+}
+
+func testConfig() config.EventsConfig {
+	return config.EventsConfig{
+		PollInterval: time.Second,
+		BatchSize: 10,
+		Lease: 30 * time.Second,
+		MaxAttempts: 2,
+		GapTimeout: 10 * time.Second,
+	}
+}
@@ -0,0 +1,125 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+const (
+	QuestionCreated = "question.created"
+	QuestionDeleted = "question.deleted"
+	QuestionMarkedDuplicate = "question.marked_duplicate"
+	QuestionMerged = "question.merged"
+	AnswerCreated = "answer.created"
+	AnswerDeleted = "answer.deleted"
+	AnswerVoted = "answer.voted"
+	AnswerAccepted = "answer.accepted"
+	AnswerUnaccepted = "answer.unaccepted"
+	AnswerMoved = "answer.moved"
+	CommentCreated = "comment.created"
+)
+
+// Event is a domain event. The storage layer writes it to the outbox in the
+// transaction of the change, and the Dispatcher relays it to subscribers.
+// Payload holds the JSON of the affected entity.
+type Event struct {
+	ID uint64 `json:"id"`
+	Type string `json:"type"`
+	QuestionID int `json:"question_id,omitempty"`
+	AnswerID int `json:"answer_id,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewQuestionCreated(question models.Question) Event {
+	return newEvent(QuestionCreated, question.ID, 0, question)
+}
+
+func NewQuestionDeleted(question models.Question) Event {
+	return newEvent(QuestionDeleted, question.ID, 0, question)
+}
+
+func NewQuestionMarkedDuplicate(question models.Question) Event {
+	return newEvent(QuestionMarkedDuplicate, question.ID, 0, question)
+}
+
+func NewQuestionMerged(merged models.QuestionsMerged) Event {
+	return newEvent(QuestionMerged, merged.Question.ID, 0, merged)
+}
+
+func NewAnswerCreated(answer models.Answer) Event {
+	return newEvent(AnswerCreated, answer.QuestionID, answer.ID, answer)
+}
+
+func NewAnswerDeleted(answer models.Answer) Event {
+	return newEvent(AnswerDeleted, answer.QuestionID, answer.ID, answer)
+}
+
+func NewAnswerVoted(voted models.AnswerVoted) Event {
+	return newEvent(AnswerVoted, voted.Answer.QuestionID, voted.Answer.ID, voted)
+}
+
+func NewAnswerAccepted(accepted models.AnswerAccepted) Event {
+	return newEvent(AnswerAccepted, accepted.Answer.QuestionID, accepted.Answer.ID, accepted)
+}
+
+// NewAnswerUnaccepted is the event of an answer that is no longer accepted
+// because a merge or a move put it next to the accepted answer of another
+// question.
+func NewAnswerUnaccepted(unaccepted models.AnswerUnaccepted) Event {
+	return newEvent(AnswerUnaccepted, unaccepted.QuestionID, unaccepted.ID, unaccepted)
+}
+
+func NewAnswerMoved(moved models.AnswerMoved) Event {
+	return newEvent(AnswerMoved, moved.Answer.QuestionID, moved.Answer.ID, moved)
+}
+
+func NewCommentCreated(created models.CommentCreated) Event {
+	var answerID int
+	if created.Comment.AnswerID != nil {
+		answerID = *created.Comment.AnswerID
+	}
+	return newEvent(CommentCreated, created.QuestionID, answerID, created)
+}
+
+// FromOutbox converts a stored outbox row back into an event.
+func FromOutbox(row models.OutboxEvent) Event {
+	return Event{
+		ID: uint64(row.ID),
+		Type: row.Type,
+		QuestionID: row.QuestionID,
+		AnswerID: row.AnswerID,
+		Payload: row.Payload,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+// Outbox converts the event into a row to insert.
+func(e Event) Outbox() models.OutboxEvent {
+	return models.OutboxEvent{
+		Type: e.Type,
+		QuestionID: e.QuestionID,
+		AnswerID: e.AnswerID,
+		Payload: e.Payload,
+	}
+}
+
+// Decode unmarshals the payload, e.g. into models.Answer for answer events.
+func Decode[T any](event Event) (T, error) {
+	var res T
+	err := json.Unmarshal(event.Payload, &res)
+	return res, err
+}
+
+func newEvent(eventType string, questionID, answerID int, payload any) Event {
+	// Entities are plain structs, marshaling them cannot fail.
+	data, _ := json.Marshal(payload)
+	return Event{
+		Type: eventType,
+		QuestionID: questionID,
+		AnswerID: answerID,
+		Payload: data,
+	}
+}
@@ -0,0 +1,167 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Latest passed as lastID to Subscribe skips the replay and delivers only
+// events published afterwards.
+const Latest = ^uint64(0)
+
+type Filter func(Event) bool
+
+// Hub is an in-process pub/sub. It numbers published events and keeps the
+// most recent ones in a bounded buffer so subscribers can resume after a
+// reconnect.
+type Hub struct {
+	mu sync.Mutex
+	lastID uint64
+	buffer []Event
+	next int
+	full bool
+	subscribers map[*Subscription]struct{}
+	closed bool
+	subscriberBuffer int
+}
+
+// Subscription delivers matching events on C. C is closed when the hub shuts
+// down or when the subscriber falls so far behind that its queue fills up;
+// the subscriber is expected to resubscribe from its last seen ID.
+type Subscription struct {
+	C <-chan Event
+	ch chan Event
+	filter Filter
+	hub *Hub
+}
+
+func NewHub(bufferSize, subscriberBuffer int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	if subscriberBuffer <= 0 {
+		subscriberBuffer = 1
+	}
+	return &Hub{
+		buffer: make([]Event, bufferSize),
+		subscribers: make(map[*Subscription]struct{}),
+		subscriberBuffer: subscriberBuffer,
+	}
+}
+
+// Publish delivers the event to matching subscribers. Events relayed from
+// the outbox keep their outbox ID; others are numbered by the hub.
+func(h *Hub) Publish(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	if event.ID == 0 {
+		event.ID = h.lastID + 1
+	}
+	h.lastID = max(h.lastID, event.ID)
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	h.buffer[h.next] = event
+	h.next = (h.next + 1) % len(h.buffer)
+	if h.next == 0 {
+		h.full = true
+	}
+	for sub := range h.subscribers {
+		if sub.filter != nil && !sub.filter(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			h.drop(sub)
+		}
+	}
+}
+
+// Handle publishes events relayed by the Dispatcher.
+func(h *Hub) Handle(ctx context.Context, event Event) error {
+	h.Publish(event)
+	return nil
+}
+
+// Subscribe registers a subscriber and returns the buffered events after
+// lastID that match the filter. Replay and registration happen under one
+// lock, so nothing published in between is lost.
+func(h *Hub) Subscribe(filter Filter, lastID uint64) ([]Event, *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan Event, h.subscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, filter: filter, hub: h}
+	if h.closed {
+		close(ch)
+		return nil, sub
+	}
+	h.subscribers[sub] = struct{}{}
+
+	return h.replay(filter, lastID), sub
+}
+
+func(h *Hub) LastID() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.lastID
+}
+
+// Close ends every subscription. Later publishes are ignored.
+func(h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for sub := range h.subscribers {
+		h.drop(sub)
+	}
+}
+
+func(s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if _, ok := s.hub.subscribers[s]; ok {
+		s.hub.drop(s)
+	}
+}
+
+func(h *Hub) drop(sub *Subscription) {
+	delete(h.subscribers, sub)
+	close(sub.ch)
+}
+
+func(h *Hub) replay(filter Filter, lastID uint64) []Event {
+	if lastID >= h.lastID {
+		return nil
+	}
+	start, count := 0, h.next
+	if h.full {
+		start, count = h.next, len(h.buffer)
+	}
+	res := make([]Event, 0)
+	for i := 0; i < count; i++ {
+		event := h.buffer[(start+i)%len(h.buffer)]
+		if event.ID <= lastID {
+			continue
+		}
+		if filter != nil && !filter(event) {
+			continue
+		}
+		res = append(res, event)
+	}
+
+	return res
+}
+
+func ForQuestion(questionID int) Filter {
+	return func(event Event) bool {
+		return event.QuestionID == questionID
+	}
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"testing"
+)
+
+func TestSubscribeReceivesMatchingEvents(t *testing.T) {
+	hub := NewHub(4, 4)
+	_, sub := hub.Subscribe(ForQuestion(1), Latest)
+	defer sub.Close()
+
+	hub.Publish(Event{Type: AnswerCreated, QuestionID: 2})
+	hub.Publish(Event{Type: AnswerCreated, QuestionID: 1, AnswerID: 7})
+
+	event := <-sub.C
+	if event.QuestionID != 1 || event.AnswerID != 7 {
+		t.Errorf("Excpected event of question 1, got %+v", event)
+	}
+	if event.ID != 2 {
+		t.Errorf("Excpected event id 2, got %d", event.ID)
+	}
+}
+
+func TestSubscribeReplaysFromBoundedBuffer(t *testing.T) {
+	hub := NewHub(3, 4)
+	for i := 0; i < 5; i++ {
+		hub.Publish(Event{Type: AnswerCreated, QuestionID: 1})
+	}
+
+	replay, sub := hub.Subscribe(ForQuestion(1), 1)
+	defer sub.Close()
+
+	if len(replay) != 3 {
+		t.Fatalf("Excpected 3 buffered events, got %d", len(replay))
+	}
+	for i, event := range replay {
+		if event.ID != uint64(i+3) {
+			t.Errorf("Excpected event id %d, got %d", i+3, event.ID)
+		}
+	}
+
+	replay, sub = hub.Subscribe(nil, Latest)
+	defer sub.Close()
+	if len(replay) != 0 {
+		t.Errorf("Excpected no replay from latest, got %d", len(replay))
+	}
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	hub := NewHub(4, 1)
+	_, sub := hub.Subscribe(nil, Latest)
+
+	hub.Publish(Event{Type: AnswerCreated})
+	hub.Publish(Event{Type: AnswerCreated})
+
+	<-sub.C
+	if _, ok := <-sub.C; ok {
+		t.Error("Excpected channel of slow subscriber to be closed")
+	}
+	sub.Close()
+}
+
+func TestCloseEndsSubscriptions(t *testing.T) {
+	hub := NewHub(4, 4)
+	_, sub := hub.Subscribe(nil, Latest)
+
+	hub.Close()
+	if _, ok := <-sub.C; ok {
+		t.Error("Excpected closed channel")
+	}
+	sub.Close()
+
+	_, late := hub.Subscribe(nil, Latest)
+	if _, ok := <-late.C; ok {
+		t.Error("Excpected closed channel after hub shutdown")
+	}
+}
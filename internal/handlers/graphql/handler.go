@@ -0,0 +1,169 @@
+// Package graphql serves a read-only GraphQL view of the questions, answers
+// and users at /graphql. The code in generated.go and models_gen.go is
+// generated from schema.graphqls with gqlgen.
+package graphql
+
+//go:generate go run github.com/99designs/gqlgen generate --config gqlgen.yml
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const maxListLimit = 100
+
+var (
+	ErrInvalidID = errors.New("InvalidID")
+	ErrInvalidLimit = errors.New("InvalidLimit")
+	ErrInvalidOffset = errors.New("InvalidOffset")
+)
+
+type Service interface {
+	QuestionsPage(ctx context.Context, sort, tag string, page models.Page) (models.GetQuestionsResponse, error)
+	Answer(ctx context.Context, id int) (models.GetAnswerResponse, error)
+	QuestionsByID(ctx context.Context, ids []int) (map[int]models.Question, error)
+	AnswersByQuestion(ctx context.Context, questionIDs []int) (map[int][]models.Answer, error)
+	UsersByID(ctx context.Context, ids []string) (map[string]models.User, error)
+	AnswersByUser(ctx context.Context, ids []string, page models.Page) (map[string][]models.Answer, error)
+	QuestionsByTag(ctx context.Context, tags []string, page models.Page) (map[string][]models.Question, error)
+	CommentsByQuestion(ctx context.Context, questionIDs []int, page models.Page) (map[int][]models.Comment, error)
+	CommentsByAnswer(ctx context.Context, answerIDs []int, page models.Page) (map[int][]models.Comment, error)
+}
+
+// NewHandler returns the handler of GET and POST /graphql. Every request
+// gets its own loaders, so lookups are batched and cached per request only.
+func NewHandler(log *slog.Logger, cfg *config.GraphQLConfig, service Service) (http.Handler, error) {
+	schemaConfig := Config{Resolvers: &Resolver{service: service}}
+	setComplexity(&schemaConfig.Complexity)
+
+	srv := handler.New(NewExecutableSchema(schemaConfig))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	if cfg.Introspection {
+		srv.Use(extension.Introspection{})
+	}
+	if cfg.PersistedQueries != "" || cfg.AllowlistOnly {
+		var queries map[string]string
+		if cfg.PersistedQueries != "" {
+			var err error
+			queries, err = loadPersistedQueries(cfg.PersistedQueries)
+			if err != nil {
+				return nil, err
+			}
+		}
+		srv.Use(persistedQueries{queries: queries, allowlistOnly: cfg.AllowlistOnly})
+	}
+	srv.Use(depthLimit{limit: cfg.MaxDepth})
+	srv.Use(extension.FixedComplexityLimit(cfg.MaxComplexity))
+	srv.SetRecoverFunc(func(ctx context.Context, err any) error {
+		log.Error(
+			"ResolverPanic",
+			slog.String("component", "graphql"),
+			slog.Any("error", err),
+		)
+		return errors.New("InternalServerError")
+	})
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+		defer cancel()
+		srv.ServeHTTP(writer, request.WithContext(withLoaders(ctx, service)))
+	}), nil
+}
+
+// setComplexity makes a list count as its limit times the selection of an
+// item.
+func setComplexity(complexity *ComplexityRoot) {
+	list := func(childComplexity int, limit *int) int {
+		count := maxListLimit
+		if limit != nil && *limit >= 0 && *limit < count {
+			count = *limit
+		}
+		return 1 + count * childComplexity
+	}
+	complexity.Query.Questions = func(childComplexity int, sort *QuestionSort, tag *string, limit *int, offset *int) int {
+		return list(childComplexity, limit)
+	}
+	complexity.Tag.Questions = func(childComplexity int, limit *int, offset *int) int {
+		return list(childComplexity, limit)
+	}
+	complexity.Question.Answers = func(childComplexity int, limit *int, offset *int) int {
+		return list(childComplexity, limit)
+	}
+	complexity.User.Answers = func(childComplexity int, limit *int, offset *int) int {
+		return list(childComplexity, limit)
+	}
+	complexity.Question.Comments = func(childComplexity int, limit *int, offset *int) int {
+		return list(childComplexity, limit)
+	}
+	complexity.Answer.Comments = func(childComplexity int, limit *int, offset *int) int {
+		return list(childComplexity, limit)
+	}
+}
+
+func loadQuestion(ctx context.Context, id int) (*models.Question, error) {
+	question, found, err := loadersFrom(ctx).questions.Load(ctx, id)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &question, nil
+}
+
+func loadUser(ctx context.Context, id string) (*models.User, error) {
+	user, found, err := loadersFrom(ctx).users.Load(ctx, id)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func getPage(limit, offset *int) (models.Page, error) {
+	page := models.Page{Limit: 20}
+	if limit != nil {
+		if *limit < 0 || *limit > maxListLimit {
+			return page, ErrInvalidLimit
+		}
+		page.Limit = *limit
+	}
+	if offset != nil {
+		if *offset < 0 {
+			return page, ErrInvalidOffset
+		}
+		page.Offset = *offset
+	}
+
+	return page, nil
+}
+
+func paginate[T any](items []T, page models.Page) []*T {
+	if page.Offset >= len(items) {
+		return []*T{}
+	}
+	items = items[page.Offset:]
+	if len(items) > page.Limit {
+		items = items[:page.Limit]
+	}
+	return pointers(items)
+}
+
+func pointers[T any](items []T) []*T {
+	res := make([]*T, len(items))
+	for i := range items {
+		res[i] = &items[i]
+	}
+	return res
+}
@@ -0,0 +1,375 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// countingService serves three questions with two answers each, every
+// answer by a different user, and counts the batch calls. Every question
+// is tagged go and q<id>, and every question and answer has a comment.
+type countingService struct {
+	mu sync.Mutex
+	calls map[string]int
+}
+
+func newCountingService() *countingService {
+	return &countingService{calls: make(map[string]int)}
+}
+
+func(s *countingService) count(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[name]++
+}
+
+func(s *countingService) Calls(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[name]
+}
+
+func testQuestion(id int) models.Question {
+	author := fmt.Sprintf("asker-%d", id)
+	return models.Question{ID: id, Text: fmt.Sprintf("Question %d", id), UserID: &author, Tags: []string{"go", fmt.Sprintf("q%d", id)}}
+}
+
+func(s *countingService) QuestionsPage(ctx context.Context, sort, tag string, page models.Page) (models.GetQuestionsResponse, error) {
+	s.count("QuestionsPage")
+	res := make([]models.Question, 0)
+	for id := 1; id <= 3; id++ {
+		question := testQuestion(id)
+		if tag == "" || slices.Contains(question.Tags, tag) {
+			res = append(res, question)
+		}
+	}
+	res = res[min(page.Offset, len(res)):]
+	return models.GetQuestionsResponse{Questions: res[:min(page.Limit, len(res))]}, nil
+}
+
+func(s *countingService) QuestionsByTag(ctx context.Context, tags []string, page models.Page) (map[string][]models.Question, error) {
+	s.count("QuestionsByTag")
+	res := make(map[string][]models.Question)
+	for _, tag := range tags {
+		questions, _ := s.QuestionsPage(ctx, "", tag, page)
+		res[tag] = questions.Questions
+	}
+	return res, nil
+}
+
+func(s *countingService) Answer(ctx context.Context, id int) (models.GetAnswerResponse, error) {
+	s.count("Answer")
+	return models.GetAnswerResponse{Answer: models.Answer{ID: id, QuestionID: 1, UserID: "answerer-1"}}, nil
+}
+
+func(s *countingService) QuestionsByID(ctx context.Context, ids []int) (map[int]models.Question, error) {
+	s.count("QuestionsByID")
+	res := make(map[int]models.Question)
+	for _, id := range ids {
+		if id <= 3 {
+			res[id] = testQuestion(id)
+		}
+	}
+	return res, nil
+}
+
+func(s *countingService) AnswersByQuestion(ctx context.Context, questionIDs []int) (map[int][]models.Answer, error) {
+	s.count("AnswersByQuestion")
+	res := make(map[int][]models.Answer)
+	for _, id := range questionIDs {
+		for i := 0; i < 2; i++ {
+			res[id] = append(res[id], models.Answer{
+				ID: id * 10 + i,
+				QuestionID: id,
+				UserID: fmt.Sprintf("answerer-%d", id * 10 + i),
+			})
+		}
+	}
+	return res, nil
+}
+
+func(s *countingService) UsersByID(ctx context.Context, ids []string) (map[string]models.User, error) {
+	s.count("UsersByID")
+	res := make(map[string]models.User)
+	for _, id := range ids {
+		res[id] = models.User{ID: id, DisplayName: strings.ToUpper(id)}
+	}
+	return res, nil
+}
+
+func(s *countingService) AnswersByUser(ctx context.Context, ids []string, page models.Page) (map[string][]models.Answer, error) {
+	s.count("AnswersByUser")
+	return map[string][]models.Answer{}, nil
+}
+
+func(s *countingService) CommentsByQuestion(ctx context.Context, questionIDs []int, page models.Page) (map[int][]models.Comment, error) {
+	s.count("CommentsByQuestion")
+	res := make(map[int][]models.Comment)
+	for _, id := range questionIDs {
+		res[id] = []models.Comment{{ID: id, QuestionID: &id, UserID: fmt.Sprintf("commenter-%d", id)}}
+	}
+	return res, nil
+}
+
+func(s *countingService) CommentsByAnswer(ctx context.Context, answerIDs []int, page models.Page) (map[int][]models.Comment, error) {
+	s.count("CommentsByAnswer")
+	res := make(map[int][]models.Comment)
+	for _, id := range answerIDs {
+		res[id] = []models.Comment{{ID: 100 + id, AnswerID: &id, UserID: fmt.Sprintf("commenter-%d", 100 + id)}}
+	}
+	return res, nil
+}
+
+type graphqlResponse struct {
+	Data json.RawMessage
+	Errors []struct {
+		Message string
+		Extensions map[string]any
+	}
+}
+
+func graphqlConfig() *config.GraphQLConfig {
+	return &config.GraphQLConfig{Enabled: true, MaxDepth: 8, MaxComplexity: 5000}
+}
+
+func post(t *testing.T, handler http.Handler, body map[string]any) graphqlResponse {
+	t.Helper()
+	data, _ := json.Marshal(body)
+	request := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(data))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	var res graphqlResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &res); err != nil {
+		t.Fatalf("Excpected a JSON response, got %d %s", recorder.Code, recorder.Body.String())
+	}
+	return res
+}
+
+func TestLoadersBatchLookups(t *testing.T) {
+	service := newCountingService()
+	handler, err := NewHandler(slog.Default(), graphqlConfig(), service)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := post(t, handler, map[string]any{
+		"query": `{ questions(limit: 3) { id author { displayName } answers { id author { id } question { id } } } }`,
+	})
+	if len(res.Errors) != 0 {
+		t.Fatalf("Excpected no errors, got %+v", res.Errors)
+	}
+	var data struct {
+		Questions []struct {
+			ID string
+			Author struct{ DisplayName string }
+			Answers []struct {
+				Author struct{ ID string }
+				Question struct{ ID string }
+			}
+		}
+	}
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Questions) != 3 || data.Questions[0].Author.DisplayName != "ASKER-1" {
+		t.Fatalf("Excpected 3 questions with authors, got %+v", data.Questions)
+	}
+	if len(data.Questions[2].Answers) != 2 || data.Questions[2].Answers[1].Author.ID != "answerer-31" {
+		t.Fatalf("Excpected the answers with their authors, got %+v", data.Questions[2].Answers)
+	}
+
+	if calls := service.Calls("AnswersByQuestion"); calls != 1 {
+		t.Errorf("Excpected the answers in 1 batch, got %d", calls)
+	}
+	// The question authors and the answer authors are on different levels.
+	if calls := service.Calls("UsersByID"); calls > 2 {
+		t.Errorf("Excpected the 9 users in at most 2 batches, got %d", calls)
+	}
+	// The listed questions prime the loader.
+	if calls := service.Calls("QuestionsByID"); calls != 0 {
+		t.Errorf("Excpected no question lookups, got %d", calls)
+	}
+}
+
+func TestQuestionsArePagedAndTagged(t *testing.T) {
+	service := newCountingService()
+	handler, err := NewHandler(slog.Default(), graphqlConfig(), service)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := post(t, handler, map[string]any{
+		"query": `{ questions(limit: 2, offset: 1) { id tags { name questions(limit: 5) { id } } } }`,
+	})
+	if len(res.Errors) != 0 {
+		t.Fatalf("Excpected no errors, got %+v", res.Errors)
+	}
+	var data struct {
+		Questions []struct {
+			ID string
+			Tags []struct {
+				Name string
+				Questions []struct{ ID string }
+			}
+		}
+	}
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Questions) != 2 || data.Questions[0].ID != "2" || data.Questions[1].ID != "3" {
+		t.Fatalf("Excpected questions 2 and 3, got %+v", data.Questions)
+	}
+	tags := data.Questions[0].Tags
+	if len(tags) != 2 || tags[0].Name != "go" || len(tags[0].Questions) != 3 || tags[1].Name != "q2" || len(tags[1].Questions) != 1 {
+		t.Errorf("Excpected the tags go and q2 with their questions, got %+v", tags)
+	}
+	// The tags of both questions are loaded in one batch.
+	if calls := service.Calls("QuestionsByTag"); calls != 1 {
+		t.Errorf("Excpected the tag questions in 1 batch, got %d", calls)
+	}
+
+	invalid := post(t, handler, map[string]any{"query": `{ tag(name: "not a tag") { name } }`})
+	if len(invalid.Errors) == 0 || !strings.Contains(invalid.Errors[0].Message, "InvalidTags") {
+		t.Errorf("Excpected InvalidTags, got %+v", invalid.Errors)
+	}
+	tag := post(t, handler, map[string]any{"query": `{ tag(name: "Go") { name questions { id } } }`})
+	if len(tag.Errors) != 0 || !strings.Contains(string(tag.Data), `"name":"go"`) {
+		t.Errorf("Excpected the tag go, got %s %+v", tag.Data, tag.Errors)
+	}
+}
+
+func TestCommentsAreBatched(t *testing.T) {
+	service := newCountingService()
+	handler, err := NewHandler(slog.Default(), graphqlConfig(), service)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := post(t, handler, map[string]any{
+		"query": `{ questions(limit: 3) { id comments { id author { id } } answers { id comments(limit: 5) { id } } } }`,
+	})
+	if len(res.Errors) != 0 {
+		t.Fatalf("Excpected no errors, got %+v", res.Errors)
+	}
+	var data struct {
+		Questions []struct {
+			Comments []struct {
+				ID string
+				Author struct{ ID string }
+			}
+			Answers []struct {
+				Comments []struct{ ID string }
+			}
+		}
+	}
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Questions) != 3 || len(data.Questions[1].Comments) != 1 || data.Questions[1].Comments[0].Author.ID != "commenter-2" {
+		t.Fatalf("Excpected the comments of the questions with their authors, got %+v", data.Questions)
+	}
+	if comments := data.Questions[1].Answers[0].Comments; len(comments) != 1 || comments[0].ID != "120" {
+		t.Errorf("Excpected the comment of answer 20, got %+v", comments)
+	}
+	if calls := service.Calls("CommentsByQuestion"); calls != 1 {
+		t.Errorf("Excpected the question comments in 1 batch, got %d", calls)
+	}
+	if calls := service.Calls("CommentsByAnswer"); calls != 1 {
+		t.Errorf("Excpected the answer comments in 1 batch, got %d", calls)
+	}
+}
+
+func TestDepthAndComplexityLimits(t *testing.T) {
+	cfg := graphqlConfig()
+	cfg.MaxDepth = 4
+	cfg.MaxComplexity = 200
+	handler, err := NewHandler(slog.Default(), cfg, newCountingService())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deep := post(t, handler, map[string]any{
+		"query": `{ answer(id: 1) { question { duplicateOf { author { id } } } } }`,
+	})
+	if len(deep.Errors) == 0 || deep.Errors[0].Extensions["code"] != "DEPTH_LIMIT_EXCEEDED" {
+		t.Errorf("Excpected the depth limit error, got %+v", deep.Errors)
+	}
+
+	// Fragments count toward the depth of where they are spread.
+	fragment := post(t, handler, map[string]any{
+		"query": `{ answer(id: 1) { ...A } } fragment A on Answer { question { duplicateOf { author { id } } } }`,
+	})
+	if len(fragment.Errors) == 0 || fragment.Errors[0].Extensions["code"] != "DEPTH_LIMIT_EXCEEDED" {
+		t.Errorf("Excpected the depth limit error, got %+v", fragment.Errors)
+	}
+
+	costly := post(t, handler, map[string]any{
+		"query": `{ questions(limit: 100) { id answers(limit: 100) { id } } }`,
+	})
+	if len(costly.Errors) == 0 || costly.Errors[0].Extensions["code"] != "COMPLEXITY_LIMIT_EXCEEDED" {
+		t.Errorf("Excpected the complexity limit error, got %+v", costly.Errors)
+	}
+
+	cheap := post(t, handler, map[string]any{
+		"query": `{ questions(limit: 3) { id answers(limit: 2) { id } } }`,
+	})
+	if len(cheap.Errors) != 0 {
+		t.Errorf("Excpected no errors, got %+v", cheap.Errors)
+	}
+}
+
+func TestPersistedQueryAllowlist(t *testing.T) {
+	allowed := `{ questions(limit: 1) { id } }`
+	path := filepath.Join(t.TempDir(), "persisted_queries.json")
+	data, _ := json.Marshal(map[string]string{queryHash(allowed): allowed})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := graphqlConfig()
+	cfg.PersistedQueries = path
+	cfg.AllowlistOnly = true
+	handler, err := NewHandler(slog.Default(), cfg, newCountingService())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byHash := post(t, handler, map[string]any{
+		"extensions": map[string]any{"persistedQuery": map[string]any{"version": 1, "sha256Hash": queryHash(allowed)}},
+	})
+	if len(byHash.Errors) != 0 || !strings.Contains(string(byHash.Data), `"id":"1"`) {
+		t.Errorf("Excpected the persisted query to run, got %s %+v", byHash.Data, byHash.Errors)
+	}
+
+	byText := post(t, handler, map[string]any{"query": allowed})
+	if len(byText.Errors) != 0 {
+		t.Errorf("Excpected the allowed query text to run, got %+v", byText.Errors)
+	}
+
+	adHoc := post(t, handler, map[string]any{"query": `{ questions(limit: 2) { id } }`})
+	if len(adHoc.Errors) == 0 || adHoc.Errors[0].Extensions["code"] != "PERSISTED_QUERY_REQUIRED" {
+		t.Errorf("Excpected the ad hoc query to be rejected, got %+v", adHoc.Errors)
+	}
+
+	unknown := post(t, handler, map[string]any{
+		"extensions": map[string]any{"persistedQuery": map[string]any{"version": 1, "sha256Hash": queryHash("{ x }")}},
+	})
+	if len(unknown.Errors) == 0 || unknown.Errors[0].Extensions["code"] != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Errorf("Excpected the unknown hash to be rejected, got %+v", unknown.Errors)
+	}
+}
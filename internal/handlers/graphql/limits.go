@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// depthLimit rejects operations that nest fields deeper than limit.
+// Introspection fields are not counted, clients need them deep.
+type depthLimit struct {
+	limit int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = depthLimit{}
+
+func(d depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func(d depthLimit) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func(d depthLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if depth := selectionDepth(opCtx.Operation.SelectionSet); depth > d.limit {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.limit)
+		err.Extensions = map[string]any{"code": "DEPTH_LIMIT_EXCEEDED"}
+		return err
+	}
+
+	return nil
+}
+
+// selectionDepth counts fields only. Validation has rejected fragment
+// cycles by now.
+func selectionDepth(set ast.SelectionSet) int {
+	depth := 0
+	for _, selection := range set {
+		var current int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+			current = 1 + selectionDepth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			current = selectionDepth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if selection.Definition != nil {
+				current = selectionDepth(selection.Definition.SelectionSet)
+			}
+		}
+		depth = max(depth, current)
+	}
+
+	return depth
+}
+
+// persistedQueries replaces the hash in
+// extensions.persistedQuery.sha256Hash with the query it names. In
+// allowlist mode nothing else runs: a query sent as text must be in the
+// list too.
+type persistedQueries struct {
+	queries map[string]string
+	allowlistOnly bool
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = persistedQueries{}
+
+// loadPersistedQueries reads a JSON object of queries by the hex SHA-256
+// of their text.
+func loadPersistedQueries(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var queries map[string]string
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return nil, err
+	}
+	for hash, query := range queries {
+		if queryHash(query) != strings.ToLower(hash) {
+			return nil, fmt.Errorf("persisted query %s does not match its hash", hash)
+		}
+	}
+
+	return queries, nil
+}
+
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+func(p persistedQueries) ExtensionName() string {
+	return "PersistedQueries"
+}
+
+func(p persistedQueries) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func(p persistedQueries) MutateOperationParameters(ctx context.Context, request *graphql.RawParams) *gqlerror.Error {
+	hash := persistedQueryHash(request.Extensions)
+	if hash == "" {
+		if !p.allowlistOnly {
+			return nil
+		}
+		if _, ok := p.queries[queryHash(request.Query)]; ok {
+			return nil
+		}
+		return persistedQueryError("PERSISTED_QUERY_REQUIRED", "only persisted queries are allowed")
+	}
+
+	query, ok := p.queries[strings.ToLower(hash)]
+	if !ok {
+		return persistedQueryError("PERSISTED_QUERY_NOT_FOUND", "PersistedQueryNotFound")
+	}
+	if request.Query != "" && request.Query != query {
+		return persistedQueryError("PERSISTED_QUERY_MISMATCH", "query does not match the persisted query hash")
+	}
+	request.Query = query
+
+	return nil
+}
+
+func persistedQueryHash(extensions map[string]any) string {
+	persisted, ok := extensions["persistedQuery"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	hash, _ := persisted["sha256Hash"].(string)
+
+	return hash
+}
+
+func persistedQueryError(code, message string) *gqlerror.Error {
+	err := gqlerror.Errorf("%s", message)
+	err.Extensions = map[string]any{"code": code}
+	return err
+}
@@ -0,0 +1,222 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// batchWait is how long a loader collects keys before fetching them. The
+// resolvers of a list run concurrently, so by then the siblings have
+// asked for theirs.
+const batchWait = 2 * time.Millisecond
+
+const maxBatch = 500
+
+// loader batches the lookups of one request into a single fetch and
+// caches the results for the rest of the request.
+type loader[K comparable, V any] struct {
+	ctx context.Context
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+	wait time.Duration
+	mu sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V]
+}
+
+type result[V any] struct {
+	value V
+	found bool
+	err error
+	done chan struct{}
+}
+
+type batch[K comparable, V any] struct {
+	keys []K
+	results []*result[V]
+	full chan struct{}
+}
+
+func newLoader[K comparable, V any](ctx context.Context, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		ctx: ctx,
+		fetch: fetch,
+		wait: batchWait,
+		cache: make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value of key; found is false when the fetch did not
+// return it.
+func(l *loader[K, V]) Load(ctx context.Context, key K) (V, bool, error) {
+	l.mu.Lock()
+	res, ok := l.cache[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.cache[key] = res
+		l.add(key, res)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.found, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, false, ctx.Err()
+	}
+}
+
+// Prime stores a value fetched some other way.
+func(l *loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.cache[key]; ok {
+		return
+	}
+	res := &result[V]{value: value, found: true, done: make(chan struct{})}
+	close(res.done)
+	l.cache[key] = res
+}
+
+// add must be called with mu held.
+func(l *loader[K, V]) add(key K, res *result[V]) {
+	if l.batch == nil {
+		l.batch = &batch[K, V]{full: make(chan struct{})}
+		go l.run(l.batch)
+	}
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results = append(l.batch.results, res)
+	if len(l.batch.keys) >= maxBatch {
+		close(l.batch.full)
+		l.batch = nil
+	}
+}
+
+func(l *loader[K, V]) run(b *batch[K, V]) {
+	timer := time.NewTimer(l.wait)
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		if l.batch == b {
+			l.batch = nil
+		}
+		l.mu.Unlock()
+	case <-b.full:
+		timer.Stop()
+	}
+
+	values, err := l.fetch(l.ctx, b.keys)
+	for i, key := range b.keys {
+		res := b.results[i]
+		if err != nil {
+			res.err = err
+		} else {
+			res.value, res.found = values[key]
+		}
+		close(res.done)
+	}
+}
+
+// loaders are the loaders of one request.
+type loaders struct {
+	questions *loader[int, models.Question]
+	questionAnswers *loader[int, []models.Answer]
+	users *loader[string, models.User]
+	mu sync.Mutex
+	userAnswers map[models.Page]*loader[string, []models.Answer]
+	tagQuestions map[models.Page]*loader[string, []models.Question]
+	questionComments map[models.Page]*loader[int, []models.Comment]
+	answerComments map[models.Page]*loader[int, []models.Comment]
+	service Service
+	ctx context.Context
+}
+
+func newLoaders(ctx context.Context, service Service) *loaders {
+	return &loaders{
+		questions: newLoader(ctx, service.QuestionsByID),
+		questionAnswers: newLoader(ctx, service.AnswersByQuestion),
+		users: newLoader(ctx, service.UsersByID),
+		userAnswers: make(map[models.Page]*loader[string, []models.Answer]),
+		tagQuestions: make(map[models.Page]*loader[string, []models.Question]),
+		questionComments: make(map[models.Page]*loader[int, []models.Comment]),
+		answerComments: make(map[models.Page]*loader[int, []models.Comment]),
+		service: service,
+		ctx: ctx,
+	}
+}
+
+// UserAnswers returns the loader of the answer pages of users. The page
+// is shared by the batch, so there is a loader per page.
+func(l *loaders) UserAnswers(page models.Page) *loader[string, []models.Answer] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res, ok := l.userAnswers[page]
+	if !ok {
+		res = newLoader(l.ctx, func(ctx context.Context, ids []string) (map[string][]models.Answer, error) {
+			return l.service.AnswersByUser(ctx, ids, page)
+		})
+		l.userAnswers[page] = res
+	}
+
+	return res
+}
+
+// TagQuestions returns the loader of the question pages of tags, one per
+// page like UserAnswers.
+func(l *loaders) TagQuestions(page models.Page) *loader[string, []models.Question] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res, ok := l.tagQuestions[page]
+	if !ok {
+		res = newLoader(l.ctx, func(ctx context.Context, tags []string) (map[string][]models.Question, error) {
+			return l.service.QuestionsByTag(ctx, tags, page)
+		})
+		l.tagQuestions[page] = res
+	}
+
+	return res
+}
+
+// QuestionComments returns the loader of the comment pages of questions,
+// one per page like UserAnswers.
+func(l *loaders) QuestionComments(page models.Page) *loader[int, []models.Comment] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res, ok := l.questionComments[page]
+	if !ok {
+		res = newLoader(l.ctx, func(ctx context.Context, ids []int) (map[int][]models.Comment, error) {
+			return l.service.CommentsByQuestion(ctx, ids, page)
+		})
+		l.questionComments[page] = res
+	}
+
+	return res
+}
+
+// AnswerComments returns the loader of the comment pages of answers.
+func(l *loaders) AnswerComments(page models.Page) *loader[int, []models.Comment] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res, ok := l.answerComments[page]
+	if !ok {
+		res = newLoader(l.ctx, func(ctx context.Context, ids []int) (map[int][]models.Comment, error) {
+			return l.service.CommentsByAnswer(ctx, ids, page)
+		})
+		l.answerComments[page] = res
+	}
+
+	return res
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, service Service) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(ctx, service))
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
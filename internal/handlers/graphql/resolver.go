@@ -0,0 +1,11 @@
+package graphql
+
+// Resolver is the root of the resolvers in schema.resolvers.go.
+type Resolver struct {
+	service Service
+}
+
+// Tag is a tag of questions. It has no table, so it is only its name.
+type Tag struct {
+	Name string
+}
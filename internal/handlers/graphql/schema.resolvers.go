@@ -0,0 +1,232 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+	"gorm.io/gorm"
+)
+
+// Question is the resolver for the question field.
+func (r *answerResolver) Question(ctx context.Context, obj *models.Answer) (*models.Question, error) {
+	return loadQuestion(ctx, obj.QuestionID)
+}
+
+// Author is the resolver for the author field.
+func (r *answerResolver) Author(ctx context.Context, obj *models.Answer) (*models.User, error) {
+	return loadUser(ctx, obj.UserID)
+}
+
+// Comments is the resolver for the comments field.
+func (r *answerResolver) Comments(ctx context.Context, obj *models.Answer, limit *int, offset *int) ([]*models.Comment, error) {
+	page, err := getPage(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if page.Limit == 0 {
+		return []*models.Comment{}, nil
+	}
+	comments, _, err := loadersFrom(ctx).AnswerComments(page).Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointers(comments), nil
+}
+
+// Author is the resolver for the author field.
+func (r *commentResolver) Author(ctx context.Context, obj *models.Comment) (*models.User, error) {
+	return loadUser(ctx, obj.UserID)
+}
+
+// Question is the resolver for the question field.
+func (r *queryResolver) Question(ctx context.Context, id string) (*models.Question, error) {
+	questionID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	return loadQuestion(ctx, questionID)
+}
+
+// Questions is the resolver for the questions field.
+func (r *queryResolver) Questions(ctx context.Context, sort *QuestionSort, tag *string, limit *int, offset *int) ([]*models.Question, error) {
+	page, err := getPage(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if page.Limit == 0 {
+		return []*models.Question{}, nil
+	}
+	order := models.SortDefault
+	if sort != nil && *sort == QuestionSortViews {
+		order = models.SortViews
+	}
+	var name string
+	if tag != nil {
+		name = *tag
+	}
+	res, err := r.service.QuestionsPage(ctx, string(order), name, page)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := pointers(res.Questions)
+	loaders := loadersFrom(ctx)
+	for _, question := range questions {
+		loaders.questions.Prime(question.ID, *question)
+	}
+	return questions, nil
+}
+
+// Answer is the resolver for the answer field.
+func (r *queryResolver) Answer(ctx context.Context, id string) (*models.Answer, error) {
+	answerID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+	res, err := r.service.Answer(ctx, answerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.Answer, nil
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id string) (*models.User, error) {
+	return loadUser(ctx, id)
+}
+
+// Tag is the resolver for the tag field.
+func (r *queryResolver) Tag(ctx context.Context, name string) (*Tag, error) {
+	tags, err := service.NormalizeTags([]string{name})
+	if err != nil {
+		return nil, err
+	}
+	return &Tag{Name: tags[0]}, nil
+}
+
+// Author is the resolver for the author field.
+func (r *questionResolver) Author(ctx context.Context, obj *models.Question) (*models.User, error) {
+	if obj.UserID == nil {
+		return nil, nil
+	}
+	return loadUser(ctx, *obj.UserID)
+}
+
+// DuplicateOf is the resolver for the duplicateOf field.
+func (r *questionResolver) DuplicateOf(ctx context.Context, obj *models.Question) (*models.Question, error) {
+	if obj.DuplicateOf == nil {
+		return nil, nil
+	}
+	return loadQuestion(ctx, *obj.DuplicateOf)
+}
+
+// Tags is the resolver for the tags field.
+func (r *questionResolver) Tags(ctx context.Context, obj *models.Question) ([]*Tag, error) {
+	res := make([]*Tag, len(obj.Tags))
+	for i, name := range obj.Tags {
+		res[i] = &Tag{Name: name}
+	}
+	return res, nil
+}
+
+// Answers is the resolver for the answers field.
+func (r *questionResolver) Answers(ctx context.Context, obj *models.Question, limit *int, offset *int) ([]*models.Answer, error) {
+	page, err := getPage(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	answers, _, err := loadersFrom(ctx).questionAnswers.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(answers, page), nil
+}
+
+// Comments is the resolver for the comments field.
+func (r *questionResolver) Comments(ctx context.Context, obj *models.Question, limit *int, offset *int) ([]*models.Comment, error) {
+	page, err := getPage(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if page.Limit == 0 {
+		return []*models.Comment{}, nil
+	}
+	comments, _, err := loadersFrom(ctx).QuestionComments(page).Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointers(comments), nil
+}
+
+// Questions is the resolver for the questions field.
+func (r *tagResolver) Questions(ctx context.Context, obj *Tag, limit *int, offset *int) ([]*models.Question, error) {
+	page, err := getPage(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if page.Limit == 0 {
+		return []*models.Question{}, nil
+	}
+	loaders := loadersFrom(ctx)
+	questions, _, err := loaders.TagQuestions(page).Load(ctx, obj.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, question := range questions {
+		loaders.questions.Prime(question.ID, question)
+	}
+
+	return pointers(questions), nil
+}
+
+// Answers is the resolver for the answers field.
+func (r *userResolver) Answers(ctx context.Context, obj *models.User, limit *int, offset *int) ([]*models.Answer, error) {
+	page, err := getPage(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if page.Limit == 0 {
+		return []*models.Answer{}, nil
+	}
+	answers, _, err := loadersFrom(ctx).UserAnswers(page).Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointers(answers), nil
+}
+
+// Answer returns AnswerResolver implementation.
+func (r *Resolver) Answer() AnswerResolver { return &answerResolver{r} }
+
+// Comment returns CommentResolver implementation.
+func (r *Resolver) Comment() CommentResolver { return &commentResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Question returns QuestionResolver implementation.
+func (r *Resolver) Question() QuestionResolver { return &questionResolver{r} }
+
+// Tag returns TagResolver implementation.
+func (r *Resolver) Tag() TagResolver { return &tagResolver{r} }
+
+// User returns UserResolver implementation.
+func (r *Resolver) User() UserResolver { return &userResolver{r} }
+
+type answerResolver struct{ *Resolver }
+type commentResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type questionResolver struct{ *Resolver }
+type tagResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
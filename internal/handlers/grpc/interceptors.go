@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func logUnary(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		res, err := handler(ctx, request)
+		logCall(log, info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+func logStream(log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		logCall(log, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logCall(log *slog.Logger, method string, start time.Time, err error) {
+	log.Info(
+		fmt.Sprintf("Recive gRPC call %s", method),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
+
+// authUnary and authStream check the bearer token of the call.
+func authUnary(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorize(ctx, token); err != nil {
+			return nil, err
+		}
+		return handler(ctx, request)
+	}
+}
+
+func authStream(token string) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(stream.Context(), token); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func authorize(ctx context.Context, token string) error {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), []byte("Bearer " + token)) != 1 {
+		return status.Error(codes.Unauthenticated, "TokenRequired")
+	}
+
+	return nil
+}
+
+func errorsUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, request)
+		return res, toStatus(err)
+	}
+}
+
+func errorsStream() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toStatus(handler(srv, stream))
+	}
+}
+
+// toStatus maps the errors of the service to codes as the REST server maps
+// them to status codes. Errors that already are statuses are kept.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := codes.Internal
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		code = codes.NotFound
+	case errors.Is(err, service.ErrInvalidUser), errors.Is(err, service.ErrInvalidVote), errors.Is(err, service.ErrInvalidSort),
+		errors.Is(err, service.ErrInvalidDuplicate), errors.Is(err, service.ErrInvalidMove), errors.Is(err, service.ErrInvalidTags):
+		code = codes.InvalidArgument
+	case errors.Is(err, service.ErrPrivilegeRequired):
+		code = codes.PermissionDenied
+	case errors.Is(err, service.ErrVersionMismatch):
+		code = codes.FailedPrecondition
+	case errors.Is(err, service.ErrDuplicateQuestion):
+		code = codes.AlreadyExists
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	}
+
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,188 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/handlers/grpc/qav1"
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func(s *Server) CreateQuestion(ctx context.Context, request *qav1.CreateQuestionRequest) (*qav1.CreateQuestionResponse, error) {
+	if request.GetText() == "" {
+		return nil, status.Error(codes.InvalidArgument, "EmptyText")
+	}
+	data, err := json.Marshal(models.CreateQuestionRequest{Text: request.GetText(), UserID: request.GetUserId(), Tags: request.GetTags()})
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.service.NewQuestion(ctx, data, request.GetStrict())
+	if err != nil && !errors.Is(err, service.ErrDuplicateQuestion) {
+		return nil, err
+	}
+	response := &qav1.CreateQuestionResponse{
+		Duplicates: make([]*qav1.SimilarQuestion, 0, len(res.Duplicates)),
+	}
+	for _, duplicate := range res.Duplicates {
+		response.Duplicates = append(response.Duplicates, &qav1.SimilarQuestion{
+			Id: int64(duplicate.ID),
+			Text: duplicate.Text,
+			Similarity: duplicate.Similarity,
+		})
+	}
+	if errors.Is(err, service.ErrDuplicateQuestion) {
+		st, detailsErr := status.New(codes.AlreadyExists, err.Error()).WithDetails(response)
+		if detailsErr != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+	response.Question = toQuestion(res.Question)
+
+	return response, nil
+}
+
+func(s *Server) GetQuestion(ctx context.Context, request *qav1.GetQuestionRequest) (*qav1.GetQuestionResponse, error) {
+	res, err := s.service.Question(ctx, int(request.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	response := &qav1.GetQuestionResponse{
+		Question: toQuestion(res.Question),
+		Answers: make([]*qav1.Answer, 0, len(res.Answers)),
+	}
+	for _, answer := range res.Answers {
+		response.Answers = append(response.Answers, toAnswer(answer))
+	}
+
+	return response, nil
+}
+
+// ListQuestions and ListAnswers send the rows as the storage reads them,
+// as the NDJSON routes do, instead of loading the table first.
+func(s *Server) ListQuestions(request *qav1.ListQuestionsRequest, stream grpc.ServerStreamingServer[qav1.Question]) error {
+	return s.service.StreamQuestions(stream.Context(), request.GetSort(), func(question models.Question) error {
+		return stream.Send(toQuestion(question))
+	})
+}
+
+// DeleteQuestion and DeleteAnswer need the version, as the REST deletes
+// need If-Match: the service takes 0 for an unconditional delete.
+func(s *Server) DeleteQuestion(ctx context.Context, request *qav1.DeleteQuestionRequest) (*emptypb.Empty, error) {
+	if request.GetVersion() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "VersionRequired")
+	}
+	if err := s.service.DeleteQuestion(ctx, int(request.GetId()), int(request.GetVersion())); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func(s *Server) CreateAnswers(ctx context.Context, request *qav1.CreateAnswersRequest) (*qav1.CreateAnswersResponse, error) {
+	if len(request.GetTexts()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "EmptyTexts")
+	}
+	for _, text := range request.GetTexts() {
+		if text == "" {
+			return nil, status.Error(codes.InvalidArgument, "EmptyText")
+		}
+	}
+	data, err := json.Marshal(models.CreateAnswerRequest{UserID: request.GetUserId(), Texts: request.GetTexts()})
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.service.NewAnswer(ctx, data, int(request.GetQuestionId()))
+	if err != nil {
+		return nil, err
+	}
+	response := &qav1.CreateAnswersResponse{Answers: make([]*qav1.Answer, 0, len(res.Answers))}
+	for _, answer := range res.Answers {
+		response.Answers = append(response.Answers, toAnswer(*answer))
+	}
+
+	return response, nil
+}
+
+func(s *Server) GetAnswer(ctx context.Context, request *qav1.GetAnswerRequest) (*qav1.Answer, error) {
+	res, err := s.service.Answer(ctx, int(request.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return toAnswer(res.Answer), nil
+}
+
+func(s *Server) ListAnswers(request *qav1.ListAnswersRequest, stream grpc.ServerStreamingServer[qav1.Answer]) error {
+	return s.service.StreamAnswers(stream.Context(), int(request.GetQuestionId()), func(answer models.Answer) error {
+		return stream.Send(toAnswer(answer))
+	})
+}
+
+func(s *Server) DeleteAnswer(ctx context.Context, request *qav1.DeleteAnswerRequest) (*emptypb.Empty, error) {
+	if request.GetVersion() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "VersionRequired")
+	}
+	if err := s.service.DeleteAnswer(ctx, int(request.GetId()), int(request.GetVersion())); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func toQuestion(question models.Question) *qav1.Question {
+	res := &qav1.Question{
+		Id: int64(question.ID),
+		Text: question.Text,
+		TextHtml: question.TextHTML,
+		UserId: question.UserID,
+		Version: int32(question.Version),
+		ViewCount: int64(question.ViewCount),
+		Tags: question.Tags,
+		CreatedAt: toTimestamp(question.CreatedAt),
+	}
+	if question.DuplicateOf != nil {
+		duplicateOf := int64(*question.DuplicateOf)
+		res.DuplicateOf = &duplicateOf
+	}
+
+	return res
+}
+
+func toAnswer(answer models.Answer) *qav1.Answer {
+	res := &qav1.Answer{
+		Id: int64(answer.ID),
+		QuestionId: int64(answer.QuestionID),
+		UserId: answer.UserID,
+		Text: answer.Text,
+		TextHtml: answer.TextHTML,
+		Score: int32(answer.Score),
+		Accepted: answer.Accepted,
+		Version: int32(answer.Version),
+		CreatedAt: toTimestamp(answer.CreatedAt),
+	}
+	if answer.Author != nil {
+		res.Author = &qav1.Author{
+			Id: answer.Author.ID,
+			DisplayName: answer.Author.DisplayName,
+			AvatarUrl: answer.Author.AvatarURL,
+		}
+	}
+
+	return res
+}
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
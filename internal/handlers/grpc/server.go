@@ -0,0 +1,93 @@
+// Package grpc serves the qa.v1 API over gRPC with the same service as the
+// REST server.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/handlers/grpc/qav1"
+	"github.com/behummble/Questions-answers/internal/models"
+	"google.golang.org/grpc"
+)
+
+type Service interface {
+	NewQuestion(ctx context.Context, data []byte, strict bool) (models.CreateQuestionResponse, error)
+	Question(ctx context.Context, id int) (models.GetQuestionResponse, error)
+	StreamQuestions(ctx context.Context, sort string, yield func(models.Question) error) error
+	DeleteQuestion(ctx context.Context, id, version int) (error)
+	NewAnswer(ctx context.Context, answer []byte, questionID int) (models.CreateAnswerResponse, error)
+	Answer(ctx context.Context, id int) (models.GetAnswerResponse, error)
+	StreamAnswers(ctx context.Context, questionID int, yield func(models.Answer) error) error
+	DeleteAnswer(ctx context.Context, id, version int) (error)
+}
+
+type Server struct {
+	qav1.UnimplementedQAServiceServer
+	log *slog.Logger
+	addr string
+	service Service
+	server *grpc.Server
+}
+
+// NewServer fails without a token: the API deletes questions and answers,
+// and its port must not be open to anyone who reaches it.
+func NewServer(log *slog.Logger, cfg *config.GRPCConfig, service Service) (*Server, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("gRPC token is not set")
+	}
+	server := &Server{
+		log: log,
+		addr: fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		service: service,
+	}
+	// Errors are mapped first, so the log records the final code.
+	server.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logUnary(log),
+			authUnary(cfg.Token),
+			errorsUnary(),
+		),
+		grpc.ChainStreamInterceptor(
+			logStream(log),
+			authStream(cfg.Token),
+			errorsStream(),
+		),
+	)
+	qav1.RegisterQAServiceServer(server.server, server)
+
+	return server, nil
+}
+
+func(s *Server) Start() {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		panic(err)
+	}
+	if err := s.Serve(listener); err != nil {
+		panic(err)
+	}
+}
+
+func(s *Server) Serve(listener net.Listener) error {
+	return s.server.Serve(listener)
+}
+
+// Shutdown waits for the calls in progress and cancels them when ctx is
+// done first.
+func(s *Server) Shutdown(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.server.Stop()
+	}
+}
@@ -0,0 +1,300 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/handlers/grpc/qav1"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	token = "secret"
+	userID = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+)
+
+func TestQuestionsAndAnswers(t *testing.T) {
+	client := newTestClient(t)
+	ctx := authorized()
+
+	created, err := client.CreateQuestion(ctx, &qav1.CreateQuestionRequest{Text: "How do **channels** work?", UserId: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	question := created.GetQuestion()
+	if question.GetId() == 0 || question.GetTextHtml() == "" || question.GetUserId() != userID {
+		t.Errorf("Excpected a rendered question of the user, got %v", question)
+	}
+	answers, err := client.CreateAnswers(ctx, &qav1.CreateAnswersRequest{QuestionId: question.GetId(), UserId: userID, Texts: []string{"first", "second"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers.GetAnswers()) != 2 || answers.GetAnswers()[0].GetAuthor().GetId() != userID {
+		t.Errorf("Excpected 2 answers with their author, got %v", answers.GetAnswers())
+	}
+
+	got, err := client.GetQuestion(ctx, &qav1.GetQuestionRequest{Id: question.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetQuestion().GetText() != "How do **channels** work?" || len(got.GetAnswers()) != 2 {
+		t.Errorf("Excpected the question with 2 answers, got %v", got)
+	}
+	if streamed := receiveAll(t, func() (grpc.ServerStreamingClient[qav1.Answer], error) {
+		return client.ListAnswers(ctx, &qav1.ListAnswersRequest{QuestionId: question.GetId()})
+	}); len(streamed) != 2 || streamed[1].GetText() != "second" {
+		t.Errorf("Excpected 2 streamed answers, got %v", streamed)
+	}
+
+	if _, err := client.CreateQuestion(ctx, &qav1.CreateQuestionRequest{Text: "Why use generics?"}); err != nil {
+		t.Fatal(err)
+	}
+	if streamed := receiveAll(t, func() (grpc.ServerStreamingClient[qav1.Question], error) {
+		return client.ListQuestions(ctx, &qav1.ListQuestionsRequest{})
+	}); len(streamed) != 2 {
+		t.Errorf("Excpected 2 streamed questions, got %d", len(streamed))
+	}
+
+	if _, err := client.DeleteAnswer(ctx, &qav1.DeleteAnswerRequest{Id: answers.GetAnswers()[0].GetId()}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Excpected InvalidArgument for a delete without a version, got %v", err)
+	}
+	if _, err := client.DeleteAnswer(ctx, &qav1.DeleteAnswerRequest{Id: answers.GetAnswers()[0].GetId(), Version: answers.GetAnswers()[0].GetVersion()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAnswer(ctx, &qav1.GetAnswerRequest{Id: answers.GetAnswers()[0].GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("Excpected NotFound for a deleted answer, got %v", err)
+	}
+	if _, err := client.DeleteQuestion(ctx, &qav1.DeleteQuestionRequest{Id: question.GetId()}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Excpected InvalidArgument for a delete without a version, got %v", err)
+	}
+	if _, err := client.DeleteQuestion(ctx, &qav1.DeleteQuestionRequest{Id: question.GetId(), Version: question.GetVersion()}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Excpected FailedPrecondition for the version before the answers changed, got %v", err)
+	}
+	current, err := client.GetQuestion(ctx, &qav1.GetQuestionRequest{Id: question.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteQuestion(ctx, &qav1.DeleteQuestionRequest{Id: question.GetId(), Version: current.GetQuestion().GetVersion()}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQuestionTags(t *testing.T) {
+	client := newTestClient(t)
+	ctx := authorized()
+
+	created, err := client.CreateQuestion(ctx, &qav1.CreateQuestionRequest{Text: "How do channels work?", Tags: []string{"Go", "concurrency"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags := created.GetQuestion().GetTags(); !slices.Equal(tags, []string{"concurrency", "go"}) {
+		t.Errorf("Excpected the normalized tags, got %v", tags)
+	}
+	got, err := client.GetQuestion(ctx, &qav1.GetQuestionRequest{Id: created.GetQuestion().GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags := got.GetQuestion().GetTags(); !slices.Equal(tags, []string{"concurrency", "go"}) {
+		t.Errorf("Excpected the tags of the question, got %v", tags)
+	}
+	streamed := receiveAll(t, func() (grpc.ServerStreamingClient[qav1.Question], error) {
+		return client.ListQuestions(ctx, &qav1.ListQuestionsRequest{})
+	})
+	if len(streamed) != 1 || !slices.Equal(streamed[0].GetTags(), []string{"concurrency", "go"}) {
+		t.Errorf("Excpected the streamed question with its tags, got %v", streamed)
+	}
+	if _, err := client.CreateQuestion(ctx, &qav1.CreateQuestionRequest{Text: "test", Tags: []string{"not a tag"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Excpected InvalidArgument for an invalid tag, got %v", err)
+	}
+}
+
+// pausedService yields one question, then waits for release before the
+// next one.
+type pausedService struct {
+	Service
+	release chan struct{}
+}
+
+func(s *pausedService) StreamQuestions(ctx context.Context, sort string, yield func(models.Question) error) error {
+	if err := yield(models.Question{ID: 1}); err != nil {
+		return err
+	}
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return yield(models.Question{ID: 2})
+}
+
+func TestListQuestionsSendsEachRow(t *testing.T) {
+	svc := &pausedService{release: make(chan struct{})}
+	client := newClient(t, svc)
+	ctx, cancel := context.WithTimeout(authorized(), 5 * time.Second)
+	defer cancel()
+
+	stream, err := client.ListQuestions(ctx, &qav1.ListQuestionsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := stream.Recv()
+	if err != nil || first.GetId() != 1 {
+		t.Fatalf("Excpected the first question before the stream ends, got %v %v", first, err)
+	}
+	close(svc.release)
+	if second, err := stream.Recv(); err != nil || second.GetId() != 2 {
+		t.Errorf("Excpected the second question, got %v %v", second, err)
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("Excpected the end of the stream, got %v", err)
+	}
+}
+
+func TestListAnswersOfMissingQuestion(t *testing.T) {
+	client := newTestClient(t)
+	stream, err := client.ListAnswers(authorized(), &qav1.ListAnswersRequest{QuestionId: 404})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Excpected NotFound, got %v", err)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	client := newTestClient(t)
+	ctx := authorized()
+
+	for name, call := range map[string]func() error{
+		"empty text": func() error {
+			_, err := client.CreateQuestion(ctx, &qav1.CreateQuestionRequest{})
+			return err
+		},
+		"invalid user": func() error {
+			_, err := client.CreateQuestion(ctx, &qav1.CreateQuestionRequest{Text: "test", UserId: "not-a-uuid"})
+			return err
+		},
+		"invalid sort": func() error {
+			stream, err := client.ListQuestions(ctx, &qav1.ListQuestionsRequest{Sort: "votes"})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		},
+	} {
+		if code := status.Code(call()); code != codes.InvalidArgument {
+			t.Errorf("Excpected InvalidArgument for %s, got %v", name, code)
+		}
+	}
+	if _, err := client.GetQuestion(ctx, &qav1.GetQuestionRequest{Id: 404}); status.Code(err) != codes.NotFound {
+		t.Errorf("Excpected NotFound, got %v", err)
+	}
+
+	if _, err := client.CreateQuestion(ctx, &qav1.CreateQuestionRequest{Text: "How do I learn Go quickly?"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := client.CreateQuestion(ctx, &qav1.CreateQuestionRequest{Text: "How do I learn Go quickly?", Strict: true})
+	st := status.Convert(err)
+	if st.Code() != codes.AlreadyExists || len(st.Details()) != 1 {
+		t.Fatalf("Excpected AlreadyExists with details, got %v", err)
+	}
+	if details, ok := st.Details()[0].(*qav1.CreateQuestionResponse); !ok || len(details.GetDuplicates()) != 1 {
+		t.Errorf("Excpected the duplicate in the details, got %v", st.Details())
+	}
+}
+
+func TestAuth(t *testing.T) {
+	client := newTestClient(t)
+	for name, ctx := range map[string]context.Context{
+		"no token": context.Background(),
+		"wrong token": metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong"),
+	} {
+		if _, err := client.GetQuestion(ctx, &qav1.GetQuestionRequest{Id: 1}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Excpected Unauthenticated with %s, got %v", name, err)
+		}
+		stream, err := client.ListQuestions(ctx, &qav1.ListQuestionsRequest{})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Excpected Unauthenticated stream with %s, got %v", name, err)
+		}
+	}
+}
+
+func TestServerNeedsToken(t *testing.T) {
+	if _, err := NewServer(slog.Default(), &config.GRPCConfig{}, nil); err == nil {
+		t.Error("Excpected an error for a server without a token")
+	}
+}
+
+func newTestClient(t *testing.T) qav1.QAServiceClient {
+	answers := mock.NewMockStorageAnswers(10)
+	questions := mock.NewMockStorageQuestions(10, answers)
+	svc := service.NewService(slog.Default(), questions, answers, mock.NewMockStorageUsers(questions), mock.NewMockStorageComments(questions), config.ReputationConfig{}, config.DuplicatesConfig{Threshold: 0.5, Limit: 5}, nil)
+
+	return newClient(t, svc)
+}
+
+func newClient(t *testing.T, svc Service) qav1.QAServiceClient {
+	server, err := NewServer(slog.Default(), &config.GRPCConfig{Token: token}, svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return qav1.NewQAServiceClient(conn)
+}
+
+func authorized() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer " + token)
+}
+
+func receiveAll[T any](t *testing.T, open func() (grpc.ServerStreamingClient[T], error)) []*T {
+	stream, err := open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res []*T
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return res
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		res = append(res, item)
+	}
+}
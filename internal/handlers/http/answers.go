@@ -7,6 +7,9 @@ import (
 	"net/http"
 	"time"
 	"gorm.io/gorm"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
 )
 
 
@@ -50,6 +53,49 @@ func(s *Server) CreateAnswer(writer http.ResponseWriter, request *http.Request)
 	writer.Write(bytes)
 }
 
+// GetQuestionAnswers lists the answers of the question, or streams them as
+// NDJSON when the request accepts it.
+func(s *Server) GetQuestionAnswers(writer http.ResponseWriter, request *http.Request) {
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	format, err := getFormat(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	if wantsNDJSON(request) {
+		s.log.Info(fmt.Sprintf("Recive a request to stream answers of question with id: %d", id))
+		stream, ctx, ok := s.newStream(writer, request)
+		if !ok {
+			return
+		}
+		err := s.service.StreamAnswers(ctx, id, func(answer models.Answer) error {
+			formatText(&answer.Text, &answer.TextHTML, format)
+			return stream.write(answer)
+		})
+		s.endStream(writer, request, stream, err)
+		return
+	}
+
+	s.log.Info(fmt.Sprintf("Recive a request to get answers of question with id: %d", id))
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	res, err := s.service.Answers(ctx, id)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	formatAnswers(res.Answers, format)
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
 func(s *Server) GetAnswer(writer http.ResponseWriter, request *http.Request) {
 	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
 	defer cancel()
@@ -60,6 +106,12 @@ func(s *Server) GetAnswer(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 	s.log.Info(fmt.Sprintf("Recive request for get answer with id: %d", id))
+	format, err := getFormat(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
 	res, err := s.service.Answer(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -70,6 +122,10 @@ func(s *Server) GetAnswer(writer http.ResponseWriter, request *http.Request) {
 		fmt.Fprint(writer, err.Error())
 		return
 	}
+	if notModified(writer, request, answerETag(res.Answer)) {
+		return
+	}
+	formatText(&res.Answer.Text, &res.Answer.TextHTML, format)
 	bytes := prepareResponse(res, s.log)
 	writer.WriteHeader(http.StatusOK)
 	writer.Write(bytes)
@@ -85,10 +141,16 @@ func(s *Server) DeleteAnswer(writer http.ResponseWriter, request *http.Request)
 		return
 	}
 	s.log.Info(fmt.Sprintf("Recive request for delete answer with id: %d", id))
-	err = s.service.DeleteAnswer(ctx, id)
+	version, ok := s.matchAnswer(ctx, writer, request, id)
+	if !ok {
+		return
+	}
+	err = s.service.DeleteAnswer(ctx, id, version)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			writer.WriteHeader(http.StatusNotFound)
+		} else if errors.Is(err, service.ErrVersionMismatch) {
+			writer.WriteHeader(http.StatusPreconditionFailed)
 		} else {
 			writer.WriteHeader(http.StatusInternalServerError)
 		}
@@ -98,3 +160,94 @@ func(s *Server) DeleteAnswer(writer http.ResponseWriter, request *http.Request)
 	
 	writer.WriteHeader(http.StatusNoContent)
 }
+
+func(s *Server) VoteAnswer(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	data, err := executeRequestBody(request, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for vote on answer with id: %d", id))
+	res, err := s.service.VoteAnswer(ctx, id, data)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+// AcceptAnswer accepts the answer for the author of its question, the
+// user in X-User-ID.
+func(s *Server) AcceptAnswer(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for accept answer with id: %d", id))
+	res, err := s.service.AcceptAnswer(ctx, id, userID)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	writer.Header().Set("ETag", answerETag(res.Answer))
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) PatchAnswer(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for patch answer with id: %d", id))
+	data, err := executeRequestBody(request, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	if len(data) == 0 {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, "Empty body")
+		return
+	}
+
+	version, ok := s.matchAnswer(ctx, writer, request, id)
+	if !ok {
+		return
+	}
+
+	res, err := s.service.PatchAnswer(ctx, id, version, data)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	writer.Header().Set("ETag", answerETag(res.Answer))
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
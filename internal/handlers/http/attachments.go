@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/attachments"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// multipartOverhead is what the form adds to the file: the boundaries, the
+// part headers and the UserID field.
+const multipartOverhead = 64 << 10
+
+type Attachments interface {
+	Upload(ctx context.Context, attachment models.Attachment, body io.Reader) (models.CreateAttachmentResponse, error)
+	QuestionAttachments(ctx context.Context, id int) (models.GetAttachmentsResponse, error)
+	AnswerAttachments(ctx context.Context, id int) (models.GetAttachmentsResponse, error)
+	Download(ctx context.Context, id int, expires, signature string) (models.Attachment, io.ReadCloser, error)
+	MaxSize() int64
+}
+
+func(s *Server) CreateQuestionAttachment(writer http.ResponseWriter, request *http.Request) {
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request to attach file to question with id: %d", id))
+	s.upload(writer, request, models.Attachment{QuestionID: &id})
+}
+
+func(s *Server) CreateAnswerAttachment(writer http.ResponseWriter, request *http.Request) {
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request to attach file to answer with id: %d", id))
+	s.upload(writer, request, models.Attachment{AnswerID: &id})
+}
+
+func(s *Server) GetQuestionAttachments(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for attachments of question with id: %d", id))
+	res, err := s.attachments.QuestionAttachments(ctx, id)
+	if err != nil {
+		writeAttachmentError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) GetAnswerAttachments(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for attachments of answer with id: %d", id))
+	res, err := s.attachments.AnswerAttachments(ctx, id)
+	if err != nil {
+		writeAttachmentError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+// DownloadAttachment serves the file of a signed URL. Images are shown
+// inline, everything else is downloaded; nosniff keeps browsers from
+// treating a file as anything but its checked type.
+func(s *Server) DownloadAttachment(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request to download attachment with id: %d", id))
+	query := request.URL.Query()
+	attachment, body, err := s.attachments.Download(ctx, id, query.Get("expires"), query.Get("signature"))
+	if err != nil {
+		writeAttachmentError(writer, err)
+		return
+	}
+	defer body.Close()
+	disposition := "attachment"
+	if strings.HasPrefix(attachment.ContentType, "image/") {
+		disposition = "inline"
+	}
+	header := writer.Header()
+	header.Set("Content-Type", attachment.ContentType)
+	header.Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}))
+	header.Set("X-Content-Type-Options", "nosniff")
+	// Download has checked expires, and a cached copy must not outlive it.
+	expires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+	header.Set("Cache-Control", fmt.Sprintf("private, max-age=%d", max(expires - time.Now().Unix(), 0)))
+	writer.WriteHeader(http.StatusOK)
+	io.Copy(writer, body)
+}
+
+// upload reads the multipart form: a UserID field and the file in a file
+// field. The body is capped, so an oversized upload fails early.
+func(s *Server) upload(writer http.ResponseWriter, request *http.Request, attachment models.Attachment) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	request.Body = http.MaxBytesReader(writer, request.Body, s.attachments.MaxSize() + multipartOverhead)
+	if err := request.ParseMultipartForm(multipartOverhead); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writer.WriteHeader(http.StatusRequestEntityTooLarge)
+			fmt.Fprint(writer, attachments.ErrAttachmentTooLarge.Error())
+			return
+		}
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, attachments.ErrInvalidAttachment.Error())
+		return
+	}
+	defer request.MultipartForm.RemoveAll()
+	file, header, err := request.FormFile("file")
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, attachments.ErrInvalidAttachment.Error())
+		return
+	}
+	defer file.Close()
+	attachment.UserID = request.FormValue("user_id")
+	if attachment.UserID == "" {
+		attachment.UserID = request.FormValue("UserID")
+	}
+	attachment.Filename = header.Filename
+
+	res, err := s.attachments.Upload(ctx, attachment, file)
+	if err != nil {
+		writeAttachmentError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusCreated)
+	writer.Write(bytes)
+}
+
+func writeAttachmentError(writer http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, attachments.ErrBlobNotFound):
+		writer.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, attachments.ErrInvalidAttachment):
+		writer.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, attachments.ErrInvalidSignature):
+		writer.WriteHeader(http.StatusForbidden)
+	case errors.Is(err, attachments.ErrAttachmentTooLarge):
+		writer.WriteHeader(http.StatusRequestEntityTooLarge)
+	case errors.Is(err, attachments.ErrUnsupportedType):
+		writer.WriteHeader(http.StatusUnsupportedMediaType)
+	default:
+		writer.WriteHeader(http.StatusInternalServerError)
+	}
+	fmt.Fprint(writer, err.Error())
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+type Badges interface {
+	Badges(ctx context.Context) (models.GetBadgesResponse, error)
+	UserBadges(ctx context.Context, userID string) (models.GetUserBadgesResponse, error)
+}
+
+func(s *Server) GetBadges(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	s.log.Info("Recive request for get all badges")
+	res, err := s.badges.Badges(ctx)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) GetUserBadges(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id := request.PathValue("id")
+	s.log.Info(fmt.Sprintf("Recive request for get badges of user with id: %s", id))
+	if _, err := s.service.User(ctx, id); err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	res, err := s.badges.UserBadges(ctx, id)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+func(s *Server) CreateQuestionComment(writer http.ResponseWriter, request *http.Request) {
+	s.createComment(writer, request, "question", s.service.CommentQuestion)
+}
+
+func(s *Server) CreateAnswerComment(writer http.ResponseWriter, request *http.Request) {
+	s.createComment(writer, request, "answer", s.service.CommentAnswer)
+}
+
+func(s *Server) createComment(writer http.ResponseWriter, request *http.Request, parent string, comment func(context.Context, int, []byte) (models.CreateCommentResponse, error)) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	data, err := executeRequestBody(request, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	if len(data) == 0 {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, "Empty body")
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for create comment on %s with id: %d", parent, id))
+	res, err := comment(ctx, id, data)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusCreated)
+	writer.Write(bytes)
+}
+
+func(s *Server) GetQuestionComments(writer http.ResponseWriter, request *http.Request) {
+	s.getComments(writer, request, "question", s.service.QuestionComments)
+}
+
+func(s *Server) GetAnswerComments(writer http.ResponseWriter, request *http.Request) {
+	s.getComments(writer, request, "answer", s.service.AnswerComments)
+}
+
+func(s *Server) getComments(writer http.ResponseWriter, request *http.Request, parent string, comments func(context.Context, int, models.Page) (models.GetCommentsResponse, error)) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	page, err := getPage(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for get comments of %s with id: %d", parent, id))
+	res, err := comments(ctx, id, page)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+// DeleteComment removes the comment of the user in X-User-ID.
+func(s *Server) DeleteComment(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for delete comment with id: %d", id))
+	if err := s.service.DeleteComment(ctx, id, userID); err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,187 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	serv "github.com/behummble/Questions-answers/internal/handlers/http"
+)
+
+// The responses of every server built by the tests are validated against
+// docs/swagger/swagger.yaml. A response the spec does not describe fails
+// the package in TestMain, after the tests that served it.
+
+const specPath = "../../../docs/swagger/swagger.yaml"
+
+type contractServer struct {
+	*serv.Server
+}
+
+// checked validates the responses of s against the spec.
+func checked(s *serv.Server) contractServer {
+	return contractServer{Server: s}
+}
+
+func(s contractServer) GetHandler() http.Handler {
+	handler := s.Server.GetHandler()
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if strings.HasSuffix(request.URL.Path, "/ws") {
+			// A websocket upgrade, which OpenAPI can not describe.
+			handler.ServeHTTP(writer, request)
+			return
+		}
+		var body []byte
+		if request.Body != nil {
+			body, _ = io.ReadAll(request.Body)
+			request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		recorder := &teeWriter{ResponseWriter: writer, status: http.StatusOK}
+		handler.ServeHTTP(recorder, request)
+
+		replay := request.Clone(context.Background())
+		replay.Body = io.NopCloser(bytes.NewReader(body))
+		contract.validate(replay, recorder)
+	})
+}
+
+// teeWriter passes a response on and keeps a copy to validate.
+type teeWriter struct {
+	http.ResponseWriter
+	status int
+	wroteHeader bool
+	body bytes.Buffer
+}
+
+func(w *teeWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func(w *teeWriter) Write(data []byte) (int, error) {
+	w.wroteHeader = true
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func(w *teeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func(w *teeWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+type contractChecker struct {
+	once sync.Once
+	router routers.Router
+	err error
+
+	mu sync.Mutex
+	violations []string
+}
+
+var contract = &contractChecker{}
+
+func(c *contractChecker) load() error {
+	c.once.Do(func() {
+		loader := openapi3.NewLoader()
+		spec, err := loader.LoadFromFile(specPath)
+		if err != nil {
+			c.err = err
+			return
+		}
+		if err = spec.Validate(loader.Context); err != nil {
+			c.err = err
+			return
+		}
+		// The paths are matched without their servers, /v1 and / for
+		// /graphql: the router would apply the servers of /graphql to the
+		// paths after it.
+		spec.Servers = nil
+		for _, path := range spec.Paths.Map() {
+			path.Servers = nil
+		}
+		c.router, c.err = gorillamux.NewRouter(spec)
+	})
+	return c.err
+}
+
+// validate checks a response against the operation of its request. The
+// unversioned routes are the operations of /v1, but only their status
+// codes are checked: their bodies keep the format they had before /v1.
+func(c *contractChecker) validate(request *http.Request, res *teeWriter) {
+	if err := c.load(); err != nil {
+		c.fail(fmt.Sprintf("loading %s: %v", specPath, err))
+		return
+	}
+	legacy := !strings.HasPrefix(request.URL.Path, "/v1/") && request.URL.Path != "/graphql"
+	request.URL.Path = strings.TrimPrefix(request.URL.Path, "/v1")
+	request.Host = ""
+	request.URL.Host = ""
+
+	route, params, err := c.router.FindRoute(request)
+	if err != nil {
+		if res.status == http.StatusNotFound || res.status == http.StatusMethodNotAllowed {
+			// Nothing is served there, as the spec says.
+			return
+		}
+		c.fail(fmt.Sprintf("%s %s: %v", request.Method, request.URL.Path, err))
+		return
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request: request,
+			PathParams: params,
+			Route: route,
+		},
+		Status: res.status,
+		Header: res.Header(),
+		Body: io.NopCloser(bytes.NewReader(res.body.Bytes())),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			ExcludeResponseBody: legacy || !strings.HasPrefix(res.Header().Get("Content-Type"), "application/json"),
+			MultiError: true,
+		},
+	}
+	if err := openapi3filter.ValidateResponse(context.Background(), input); err != nil {
+		c.fail(fmt.Sprintf("%s %s -> %d: %v", request.Method, request.URL.Path, res.status, err))
+	}
+}
+
+func(c *contractChecker) fail(violation string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.violations = append(c.violations, violation)
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if len(contract.violations) != 0 {
+		fmt.Fprintf(os.Stderr, "%d responses do not match %s:\n", len(contract.violations), specPath)
+		for _, violation := range contract.violations {
+			fmt.Fprintln(os.Stderr, "  " + violation)
+		}
+		if code == 0 {
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
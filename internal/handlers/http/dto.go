@@ -0,0 +1,205 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// dto puts the request and response DTOs of a version in front of a
+// handler. The handlers read and write the models types; the legacy routes
+// serve them as they are. Conversion errors are logged to log.
+type dto func(handler http.HandlerFunc, log *slog.Logger) http.HandlerFunc
+
+// accepts decodes the request body as the DTO T and passes the handler
+// the request it maps to. An empty body is passed on for the handler to
+// reject.
+func accepts[T any](toModel func(T) any) dto {
+	return func(handler http.HandlerFunc, log *slog.Logger) http.HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) {
+			if request.Body == nil {
+				handler(writer, request)
+				return
+			}
+			data, err := io.ReadAll(request.Body)
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					writer.WriteHeader(http.StatusRequestEntityTooLarge)
+					fmt.Fprint(writer, "RequestTooLarge")
+					return
+				}
+				writer.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(writer, "ReadingRequestBodyError")
+				return
+			}
+			if len(data) != 0 {
+				var body T
+				if err := json.Unmarshal(data, &body); err != nil {
+					writer.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(writer, "ParsingJSONError")
+					return
+				}
+				data, err = json.Marshal(toModel(body))
+				if err != nil {
+					log.Error(
+						"MarshalingJSONError",
+						slog.String("component", "json/marshalling"),
+						slog.Any("error", err),
+					)
+					writer.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprint(writer, "MarshalingJSONError")
+					return
+				}
+			}
+			request.Body = io.NopCloser(bytes.NewReader(data))
+			request.ContentLength = int64(len(data))
+			handler(writer, request)
+		}
+	}
+}
+
+// limited caps the request body at n bytes before the DTOs read it. The
+// handler must cap it as well for the legacy routes, which have no DTOs.
+func limited(n int64) dto {
+	return func(handler http.HandlerFunc, log *slog.Logger) http.HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) {
+			request.Body = http.MaxBytesReader(writer, request.Body, n)
+			handler(writer, request)
+		}
+	}
+}
+
+// responds writes the JSON responses of the handler, decoded as the model
+// T, as the DTO they map to. Error messages, which are plain text, and
+// empty responses are written as they are, as are redirects. Decoding what
+// the handler encoded costs a little, but keeps the handlers shared by the
+// versions.
+//
+// A successful response that does not convert is a bug of the handler or
+// the DTO: it is logged and answered 500 rather than served in the models
+// format, which clients of the version cannot read. A 409 is converted
+// when it holds a T and written as it is otherwise.
+func responds[T any](toDTO func(T) any) dto {
+	return func(handler http.HandlerFunc, log *slog.Logger) http.HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) {
+			buffer := &bufferedWriter{writer: writer, status: http.StatusOK}
+			handler(buffer, request)
+			if buffer.streaming {
+				return
+			}
+
+			body := buffer.body.Bytes()
+			status := buffer.status
+			success := status >= http.StatusOK && status < http.StatusMultipleChoices
+			if len(body) != 0 && (success || status == http.StatusConflict) {
+				converted, err := convert(body, toDTO)
+				switch {
+				case err == nil:
+					body = converted
+					writer.Header().Set("Content-Type", "application/json")
+				case success:
+					log.Error(
+						"ConvertingResponseError",
+						slog.String("component", "http/dto"),
+						slog.String("path", request.URL.Path),
+						slog.Any("error", err),
+					)
+					writer.Header().Del("ETag")
+					writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+					status = http.StatusInternalServerError
+					body = []byte("ConvertingResponseError")
+				}
+			}
+			writer.Header().Del("Content-Length")
+			writer.WriteHeader(status)
+			writer.Write(body)
+		}
+	}
+}
+
+// convert decodes the model T and encodes the DTO it maps to.
+func convert[T any](body []byte, toDTO func(T) any) ([]byte, error) {
+	var res T
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+	return json.Marshal(toDTO(res))
+}
+
+// bufferedWriter holds a response until it is converted. It shares the
+// header map of the real writer, so headers set by the handler are kept.
+// NDJSON responses are streamed: their rows are converted by streams.
+type bufferedWriter struct {
+	writer http.ResponseWriter
+	status int
+	wroteHeader bool
+	streaming bool
+	body bytes.Buffer
+}
+
+func(w *bufferedWriter) Header() http.Header {
+	return w.writer.Header()
+}
+
+func(w *bufferedWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+	if w.Header().Get("Content-Type") == ndjsonType {
+		w.streaming = true
+		w.writer.WriteHeader(status)
+	}
+}
+
+func(w *bufferedWriter) Write(data []byte) (int, error) {
+	w.WriteHeader(w.status)
+	if w.streaming {
+		return w.writer.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+// Unwrap is lineWriter.Unwrap for the streamed responses.
+func(w *bufferedWriter) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
+func(w *bufferedWriter) Flush() {
+	if !w.streaming {
+		return
+	}
+	if flusher, ok := w.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// timestamp formats the times of the DTOs as RFC 3339 in UTC.
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func optionalTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	res := timestamp(*t)
+	return &res
+}
+
+// mapSlice maps the items of a list and never returns nil, so empty lists
+// are written as [] and not null.
+func mapSlice[T, R any](items []T, toDTO func(T) R) []R {
+	res := make([]R, 0, len(items))
+	for _, item := range items {
+		res = append(res, toDTO(item))
+	}
+	return res
+}
@@ -0,0 +1,374 @@
+package http
+
+import (
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// The DTOs of /v1 for questions, answers and their attachments.
+
+type questionV1 struct {
+	ID int `json:"id"`
+	Text string `json:"text,omitempty"`
+	TextHTML string `json:"text_html,omitempty"`
+	UserID *string `json:"user_id,omitempty"`
+	Version int `json:"version"`
+	ViewCount int `json:"view_count"`
+	DuplicateOf *int `json:"duplicate_of,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+func toQuestionV1(question models.Question) questionV1 {
+	return questionV1{
+		ID: question.ID,
+		Text: question.Text,
+		TextHTML: question.TextHTML,
+		UserID: question.UserID,
+		Version: question.Version,
+		ViewCount: question.ViewCount,
+		DuplicateOf: question.DuplicateOf,
+		Tags: question.Tags,
+		CreatedAt: timestamp(question.CreatedAt),
+	}
+}
+
+type similarQuestionV1 struct {
+	ID int `json:"id"`
+	Text string `json:"text"`
+	Similarity float64 `json:"similarity"`
+}
+
+func toSimilarQuestionV1(question models.SimilarQuestion) similarQuestionV1 {
+	return similarQuestionV1{ID: question.ID, Text: question.Text, Similarity: question.Similarity}
+}
+
+type authorV1 struct {
+	ID string `json:"id"`
+	DisplayName string `json:"display_name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type answerV1 struct {
+	ID int `json:"id"`
+	QuestionID int `json:"question_id"`
+	UserID string `json:"user_id"`
+	Author *authorV1 `json:"author,omitempty"`
+	Text string `json:"text,omitempty"`
+	TextHTML string `json:"text_html,omitempty"`
+	Score int `json:"score"`
+	Accepted bool `json:"accepted"`
+	Version int `json:"version"`
+	CreatedAt string `json:"created_at"`
+}
+
+func toAnswerV1(answer models.Answer) answerV1 {
+	res := answerV1{
+		ID: answer.ID,
+		QuestionID: answer.QuestionID,
+		UserID: answer.UserID,
+		Text: answer.Text,
+		TextHTML: answer.TextHTML,
+		Score: answer.Score,
+		Accepted: answer.Accepted,
+		Version: answer.Version,
+		CreatedAt: timestamp(answer.CreatedAt),
+	}
+	if answer.Author != nil {
+		res.Author = &authorV1{
+			ID: answer.Author.ID,
+			DisplayName: answer.Author.DisplayName,
+			AvatarURL: answer.Author.AvatarURL,
+		}
+	}
+
+	return res
+}
+
+type createQuestionRequestV1 struct {
+	Text string `json:"text"`
+	UserID string `json:"user_id"`
+	Tags []string `json:"tags"`
+}
+
+func fromCreateQuestionRequestV1(request createQuestionRequestV1) any {
+	return models.CreateQuestionRequest{Text: request.Text, UserID: request.UserID, Tags: request.Tags}
+}
+
+type createQuestionResponseV1 struct {
+	Question questionV1 `json:"question"`
+	Duplicates []similarQuestionV1 `json:"duplicates,omitempty"`
+}
+
+func toCreateQuestionResponseV1(res models.CreateQuestionResponse) any {
+	dto := createQuestionResponseV1{Question: toQuestionV1(res.Question)}
+	if len(res.Duplicates) != 0 {
+		dto.Duplicates = mapSlice(res.Duplicates, toSimilarQuestionV1)
+	}
+	return dto
+}
+
+type getQuestionsResponseV1 struct {
+	Questions []questionV1 `json:"questions"`
+}
+
+func toGetQuestionsResponseV1(res models.GetQuestionsResponse) any {
+	return getQuestionsResponseV1{Questions: mapSlice(res.Questions, toQuestionV1)}
+}
+
+type getQuestionResponseV1 struct {
+	Question questionV1 `json:"question"`
+	Answers []answerV1 `json:"answers"`
+}
+
+func toGetQuestionResponseV1(res models.GetQuestionResponse) any {
+	return getQuestionResponseV1{
+		Question: toQuestionV1(res.Question),
+		Answers: mapSlice(res.Answers, toAnswerV1),
+	}
+}
+
+// moderatorRequestV1 names the moderator of a duplicate-of or merge-into
+// request.
+type moderatorRequestV1 struct {
+	UserID string `json:"user_id"`
+}
+
+func fromMarkDuplicateRequestV1(request moderatorRequestV1) any {
+	return models.MarkDuplicateRequest{UserID: request.UserID}
+}
+
+func fromMergeQuestionRequestV1(request moderatorRequestV1) any {
+	return models.MergeQuestionRequest{UserID: request.UserID}
+}
+
+type markDuplicateResponseV1 struct {
+	Question questionV1 `json:"question"`
+}
+
+func toMarkDuplicateResponseV1(res models.MarkDuplicateResponse) any {
+	return markDuplicateResponseV1{Question: toQuestionV1(res.Question)}
+}
+
+type mergeQuestionResponseV1 struct {
+	Question questionV1 `json:"question"`
+	Merged questionV1 `json:"merged"`
+	Answers []answerV1 `json:"answers"`
+}
+
+func toMergeQuestionResponseV1(res models.MergeQuestionResponse) any {
+	return mergeQuestionResponseV1{
+		Question: toQuestionV1(res.Question),
+		Merged: toQuestionV1(res.Merged),
+		Answers: mapSlice(res.Answers, toAnswerV1),
+	}
+}
+
+type batchQuestionOperationV1 struct {
+	Op models.BatchOp `json:"op"`
+	Text string `json:"text"`
+	UserID string `json:"user_id"`
+	Tags []string `json:"tags"`
+	ID int `json:"id"`
+	Version int `json:"version"`
+}
+
+type batchQuestionsRequestV1 struct {
+	Operations []batchQuestionOperationV1 `json:"operations"`
+}
+
+func fromBatchQuestionsRequestV1(request batchQuestionsRequestV1) any {
+	operations := make([]models.BatchQuestionOperation, 0, len(request.Operations))
+	for _, operation := range request.Operations {
+		operations = append(operations, models.BatchQuestionOperation{
+			Op: operation.Op,
+			Text: operation.Text,
+			UserID: operation.UserID,
+			Tags: operation.Tags,
+			ID: operation.ID,
+			Version: operation.Version,
+		})
+	}
+	return models.BatchQuestionsRequest{Operations: operations}
+}
+
+type batchQuestionResultV1 struct {
+	Op models.BatchOp `json:"op"`
+	Status int `json:"status"`
+	Question *questionV1 `json:"question,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type batchQuestionsResponseV1 struct {
+	Atomic bool `json:"atomic"`
+	Results []batchQuestionResultV1 `json:"results"`
+}
+
+func toBatchQuestionsResponseV1(res models.BatchQuestionsResponse) any {
+	results := make([]batchQuestionResultV1, 0, len(res.Results))
+	for _, result := range res.Results {
+		dto := batchQuestionResultV1{Op: result.Op, Status: result.Status, Error: result.Error}
+		if result.Question != nil {
+			question := toQuestionV1(*result.Question)
+			dto.Question = &question
+		}
+		results = append(results, dto)
+	}
+	return batchQuestionsResponseV1{Atomic: res.Atomic, Results: results}
+}
+
+type createAnswerRequestV1 struct {
+	UserID string `json:"user_id"`
+	Texts []string `json:"texts"`
+}
+
+func fromCreateAnswerRequestV1(request createAnswerRequestV1) any {
+	return models.CreateAnswerRequest{UserID: request.UserID, Texts: request.Texts}
+}
+
+type createAnswerResponseV1 struct {
+	Answers []answerV1 `json:"answers"`
+}
+
+func toCreateAnswerResponseV1(res models.CreateAnswerResponse) any {
+	answers := make([]answerV1, 0, len(res.Answers))
+	for _, answer := range res.Answers {
+		answers = append(answers, toAnswerV1(*answer))
+	}
+	return createAnswerResponseV1{Answers: answers}
+}
+
+type getAnswersResponseV1 struct {
+	Answers []answerV1 `json:"answers"`
+}
+
+func toGetAnswersResponseV1(res models.GetAnswersResponse) any {
+	return getAnswersResponseV1{Answers: mapSlice(res.Answers, toAnswerV1)}
+}
+
+type getAnswerResponseV1 struct {
+	Answer answerV1 `json:"answer"`
+}
+
+func toGetAnswerResponseV1(res models.GetAnswerResponse) any {
+	return getAnswerResponseV1{Answer: toAnswerV1(res.Answer)}
+}
+
+type patchAnswerRequestV1 struct {
+	UserID string `json:"user_id"`
+	QuestionID *int `json:"question_id"`
+}
+
+func fromPatchAnswerRequestV1(request patchAnswerRequestV1) any {
+	return models.PatchAnswerRequest{UserID: request.UserID, QuestionID: request.QuestionID}
+}
+
+type voteRequestV1 struct {
+	UserID string `json:"user_id"`
+	Value int `json:"value"`
+}
+
+func fromVoteRequestV1(request voteRequestV1) any {
+	return models.VoteRequest{UserID: request.UserID, Value: request.Value}
+}
+
+type voteResponseV1 struct {
+	Answer answerV1 `json:"answer"`
+	Value int `json:"value"`
+}
+
+func toVoteResponseV1(res models.VoteResponse) any {
+	return voteResponseV1{Answer: toAnswerV1(res.Answer), Value: res.Value}
+}
+
+type attachmentV1 struct {
+	ID int `json:"id"`
+	QuestionID *int `json:"question_id,omitempty"`
+	AnswerID *int `json:"answer_id,omitempty"`
+	UserID string `json:"user_id"`
+	Filename string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size int64 `json:"size"`
+	URL string `json:"url"`
+	CreatedAt string `json:"created_at"`
+}
+
+func toAttachmentV1(attachment models.Attachment) attachmentV1 {
+	return attachmentV1{
+		ID: attachment.ID,
+		QuestionID: attachment.QuestionID,
+		AnswerID: attachment.AnswerID,
+		UserID: attachment.UserID,
+		Filename: attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size: attachment.Size,
+		URL: attachment.URL,
+		CreatedAt: timestamp(attachment.CreatedAt),
+	}
+}
+
+type createAttachmentResponseV1 struct {
+	Attachment attachmentV1 `json:"attachment"`
+}
+
+func toCreateAttachmentResponseV1(res models.CreateAttachmentResponse) any {
+	return createAttachmentResponseV1{Attachment: toAttachmentV1(res.Attachment)}
+}
+
+type getAttachmentsResponseV1 struct {
+	Attachments []attachmentV1 `json:"attachments"`
+}
+
+func toGetAttachmentsResponseV1(res models.GetAttachmentsResponse) any {
+	return getAttachmentsResponseV1{Attachments: mapSlice(res.Attachments, toAttachmentV1)}
+}
+
+type commentV1 struct {
+	ID int `json:"id"`
+	QuestionID *int `json:"question_id,omitempty"`
+	AnswerID *int `json:"answer_id,omitempty"`
+	UserID string `json:"user_id"`
+	Text string `json:"text"`
+	CreatedAt string `json:"created_at"`
+}
+
+func toCommentV1(comment models.Comment) commentV1 {
+	return commentV1{
+		ID: comment.ID,
+		QuestionID: comment.QuestionID,
+		AnswerID: comment.AnswerID,
+		UserID: comment.UserID,
+		Text: comment.Text,
+		CreatedAt: timestamp(comment.CreatedAt),
+	}
+}
+
+type createCommentRequestV1 struct {
+	UserID string `json:"user_id"`
+	Text string `json:"text"`
+}
+
+func fromCreateCommentRequestV1(request createCommentRequestV1) any {
+	return models.CreateCommentRequest{UserID: request.UserID, Text: request.Text}
+}
+
+type createCommentResponseV1 struct {
+	Comment commentV1 `json:"comment"`
+}
+
+func toCreateCommentResponseV1(res models.CreateCommentResponse) any {
+	return createCommentResponseV1{Comment: toCommentV1(res.Comment)}
+}
+
+type getCommentsResponseV1 struct {
+	Comments []commentV1 `json:"comments"`
+	Limit int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+func toGetCommentsResponseV1(res models.GetCommentsResponse) any {
+	return getCommentsResponseV1{
+		Comments: mapSlice(res.Comments, toCommentV1),
+		Limit: res.Limit,
+		Offset: res.Offset,
+	}
+}
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// The DTOs of /v1 for users, their reputation, badges and notifications.
+
+type userV1 struct {
+	ID string `json:"id"`
+	DisplayName string `json:"display_name"`
+	Bio string `json:"bio"`
+	AvatarURL string `json:"avatar_url"`
+	Reputation int `json:"reputation"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func toUserV1(user models.User) userV1 {
+	return userV1{
+		ID: user.ID,
+		DisplayName: user.DisplayName,
+		Bio: user.Bio,
+		AvatarURL: user.AvatarURL,
+		Reputation: user.Reputation,
+		CreatedAt: timestamp(user.CreatedAt),
+		UpdatedAt: timestamp(user.UpdatedAt),
+	}
+}
+
+type updateUserRequestV1 struct {
+	DisplayName string `json:"display_name"`
+	Bio string `json:"bio"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+func fromUpdateUserRequestV1(request updateUserRequestV1) any {
+	return models.UpdateUserRequest{
+		DisplayName: request.DisplayName,
+		Bio: request.Bio,
+		AvatarURL: request.AvatarURL,
+	}
+}
+
+type getUserResponseV1 struct {
+	User userV1 `json:"user"`
+	Privileges []string `json:"privileges"`
+}
+
+func toGetUserResponseV1(res models.GetUserResponse) any {
+	privileges := res.Privileges
+	if privileges == nil {
+		privileges = []string{}
+	}
+	return getUserResponseV1{User: toUserV1(res.User), Privileges: privileges}
+}
+
+type getUserAnswersResponseV1 struct {
+	Answers []answerV1 `json:"answers"`
+	Limit int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+func toGetUserAnswersResponseV1(res models.GetUserAnswersResponse) any {
+	return getUserAnswersResponseV1{
+		Answers: mapSlice(res.Answers, toAnswerV1),
+		Limit: res.Limit,
+		Offset: res.Offset,
+	}
+}
+
+type getUserQuestionsResponseV1 struct {
+	Questions []questionV1 `json:"questions"`
+	Limit int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+func toGetUserQuestionsResponseV1(res models.GetUserQuestionsResponse) any {
+	return getUserQuestionsResponseV1{
+		Questions: mapSlice(res.Questions, toQuestionV1),
+		Limit: res.Limit,
+		Offset: res.Offset,
+	}
+}
+
+type getLeaderboardResponseV1 struct {
+	Users []userV1 `json:"users"`
+	Limit int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+func toGetLeaderboardResponseV1(res models.GetLeaderboardResponse) any {
+	return getLeaderboardResponseV1{
+		Users: mapSlice(res.Users, toUserV1),
+		Limit: res.Limit,
+		Offset: res.Offset,
+	}
+}
+
+type reputationEventV1 struct {
+	ID int64 `json:"id"`
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+	Points int `json:"points"`
+	AnswerID int `json:"answer_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+func toReputationEventV1(event models.ReputationEvent) reputationEventV1 {
+	return reputationEventV1{
+		ID: event.ID,
+		UserID: event.UserID,
+		Reason: event.Reason,
+		Points: event.Points,
+		AnswerID: event.AnswerID,
+		CreatedAt: timestamp(event.CreatedAt),
+	}
+}
+
+type getReputationResponseV1 struct {
+	Reputation int `json:"reputation"`
+	Events []reputationEventV1 `json:"events"`
+	Limit int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+func toGetReputationResponseV1(res models.GetReputationResponse) any {
+	return getReputationResponseV1{
+		Reputation: res.Reputation,
+		Events: mapSlice(res.Events, toReputationEventV1),
+		Limit: res.Limit,
+		Offset: res.Offset,
+	}
+}
+
+type badgeV1 struct {
+	ID int `json:"id"`
+	Name string `json:"name"`
+	Title string `json:"title"`
+	Description string `json:"description"`
+	CreatedAt string `json:"created_at"`
+}
+
+func toBadgeV1(badge models.Badge) badgeV1 {
+	return badgeV1{
+		ID: badge.ID,
+		Name: badge.Name,
+		Title: badge.Title,
+		Description: badge.Description,
+		CreatedAt: timestamp(badge.CreatedAt),
+	}
+}
+
+type userBadgeV1 struct {
+	UserID string `json:"user_id"`
+	BadgeID int `json:"badge_id"`
+	Badge badgeV1 `json:"badge"`
+	AwardedAt string `json:"awarded_at"`
+}
+
+func toUserBadgeV1(badge models.UserBadge) userBadgeV1 {
+	return userBadgeV1{
+		UserID: badge.UserID,
+		BadgeID: badge.BadgeID,
+		Badge: toBadgeV1(badge.Badge),
+		AwardedAt: timestamp(badge.AwardedAt),
+	}
+}
+
+type getBadgesResponseV1 struct {
+	Badges []badgeV1 `json:"badges"`
+}
+
+func toGetBadgesResponseV1(res models.GetBadgesResponse) any {
+	return getBadgesResponseV1{Badges: mapSlice(res.Badges, toBadgeV1)}
+}
+
+type getUserBadgesResponseV1 struct {
+	Badges []userBadgeV1 `json:"badges"`
+}
+
+func toGetUserBadgesResponseV1(res models.GetUserBadgesResponse) any {
+	return getUserBadgesResponseV1{Badges: mapSlice(res.Badges, toUserBadgeV1)}
+}
+
+type notificationV1 struct {
+	ID int64 `json:"id"`
+	UserID string `json:"user_id"`
+	Type string `json:"type"`
+	Reason string `json:"reason"`
+	QuestionID int `json:"question_id"`
+	AnswerID int `json:"answer_id"`
+	ActorID *string `json:"actor_id,omitempty"`
+	ReadAt *string `json:"read_at,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+func toNotificationV1(notification models.Notification) notificationV1 {
+	return notificationV1{
+		ID: notification.ID,
+		UserID: notification.UserID,
+		Type: notification.Type,
+		Reason: notification.Reason,
+		QuestionID: notification.QuestionID,
+		AnswerID: notification.AnswerID,
+		ActorID: notification.ActorID,
+		ReadAt: optionalTimestamp(notification.ReadAt),
+		CreatedAt: timestamp(notification.CreatedAt),
+	}
+}
+
+type getNotificationsResponseV1 struct {
+	Notifications []notificationV1 `json:"notifications"`
+	Unread int `json:"unread"`
+	Limit int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+func toGetNotificationsResponseV1(res models.GetNotificationsResponse) any {
+	return getNotificationsResponseV1{
+		Notifications: mapSlice(res.Notifications, toNotificationV1),
+		Unread: res.Unread,
+		Limit: res.Limit,
+		Offset: res.Offset,
+	}
+}
+
+type markNotificationsReadResponseV1 struct {
+	Marked int `json:"marked"`
+	Unread int `json:"unread"`
+}
+
+func toMarkNotificationsReadResponseV1(res models.MarkNotificationsReadResponse) any {
+	return markNotificationsReadResponseV1{Marked: res.Marked, Unread: res.Unread}
+}
+
+type notificationPreferencesV1 struct {
+	Answers bool `json:"answers"`
+	Accepted bool `json:"accepted"`
+	Followed bool `json:"followed"`
+	Comments bool `json:"comments"`
+	Email string `json:"email"`
+	EmailVerified bool `json:"email_verified"`
+	Digest string `json:"digest"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func toNotificationPreferencesV1(preferences models.NotificationPreferences) any {
+	return notificationPreferencesV1{
+		Answers: preferences.Answers,
+		Accepted: preferences.Accepted,
+		Followed: preferences.Followed,
+		Comments: preferences.Comments,
+		Email: preferences.Email,
+		EmailVerified: preferences.EmailVerifiedAt != nil,
+		Digest: preferences.Digest,
+		UpdatedAt: timestamp(preferences.UpdatedAt),
+	}
+}
+
+type followedTagsResponseV1 struct {
+	Tags []string `json:"tags"`
+}
+
+func toFollowedTagsResponseV1(res models.FollowedTagsResponse) any {
+	return followedTagsResponseV1{Tags: res.Tags}
+}
+
+type updateNotificationPreferencesRequestV1 struct {
+	Answers *bool `json:"answers"`
+	Accepted *bool `json:"accepted"`
+	Followed *bool `json:"followed"`
+	Comments *bool `json:"comments"`
+	Email *string `json:"email"`
+	Digest *string `json:"digest"`
+}
+
+func fromUpdateNotificationPreferencesRequestV1(request updateNotificationPreferencesRequestV1) any {
+	return models.UpdateNotificationPreferencesRequest{
+		Answers: request.Answers,
+		Accepted: request.Accepted,
+		Followed: request.Followed,
+		Comments: request.Comments,
+		Email: request.Email,
+		Digest: request.Digest,
+	}
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"encoding/json"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// The DTOs of /v1 for webhooks and the admin endpoints.
+
+type webhookV1 struct {
+	ID int `json:"id"`
+	URL string `json:"url"`
+	Events []string `json:"events"`
+	Active bool `json:"active"`
+	FailureCount int `json:"failure_count"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func toWebhookV1(webhook models.Webhook) webhookV1 {
+	events := webhook.Events
+	if events == nil {
+		events = []string{}
+	}
+	return webhookV1{
+		ID: webhook.ID,
+		URL: webhook.URL,
+		Events: events,
+		Active: webhook.Active,
+		FailureCount: webhook.FailureCount,
+		CreatedAt: timestamp(webhook.CreatedAt),
+		UpdatedAt: timestamp(webhook.UpdatedAt),
+	}
+}
+
+type createWebhookRequestV1 struct {
+	URL string `json:"url"`
+	Secret string `json:"secret"`
+	Events []string `json:"events"`
+}
+
+func fromCreateWebhookRequestV1(request createWebhookRequestV1) any {
+	return models.CreateWebhookRequest{URL: request.URL, Secret: request.Secret, Events: request.Events}
+}
+
+type createWebhookResponseV1 struct {
+	Webhook webhookV1 `json:"webhook"`
+	Secret string `json:"secret"`
+}
+
+func toCreateWebhookResponseV1(res models.CreateWebhookResponse) any {
+	return createWebhookResponseV1{Webhook: toWebhookV1(res.Webhook), Secret: res.Secret}
+}
+
+type updateWebhookRequestV1 struct {
+	URL string `json:"url"`
+	Events []string `json:"events"`
+	Active bool `json:"active"`
+}
+
+func fromUpdateWebhookRequestV1(request updateWebhookRequestV1) any {
+	return models.UpdateWebhookRequest{URL: request.URL, Events: request.Events, Active: request.Active}
+}
+
+type getWebhooksResponseV1 struct {
+	Webhooks []webhookV1 `json:"webhooks"`
+}
+
+func toGetWebhooksResponseV1(res models.GetWebhooksResponse) any {
+	return getWebhooksResponseV1{Webhooks: mapSlice(res.Webhooks, toWebhookV1)}
+}
+
+func toWebhookResponseV1(webhook models.Webhook) any {
+	return toWebhookV1(webhook)
+}
+
+type webhookDeliveryV1 struct {
+	ID int `json:"id"`
+	WebhookID int `json:"webhook_id"`
+	EventID int64 `json:"event_id"`
+	EventType string `json:"event_type"`
+	Payload json.RawMessage `json:"payload"`
+	Status string `json:"status"`
+	Attempts int `json:"attempts"`
+	NextAttemptAt string `json:"next_attempt_at"`
+	LastStatusCode int `json:"last_status_code"`
+	LastError string `json:"last_error"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func toWebhookDeliveryV1(delivery models.WebhookDelivery) webhookDeliveryV1 {
+	return webhookDeliveryV1{
+		ID: delivery.ID,
+		WebhookID: delivery.WebhookID,
+		EventID: delivery.EventID,
+		EventType: delivery.EventType,
+		Payload: delivery.Payload,
+		Status: delivery.Status,
+		Attempts: delivery.Attempts,
+		NextAttemptAt: timestamp(delivery.NextAttemptAt),
+		LastStatusCode: delivery.LastStatusCode,
+		LastError: delivery.LastError,
+		CreatedAt: timestamp(delivery.CreatedAt),
+		UpdatedAt: timestamp(delivery.UpdatedAt),
+	}
+}
+
+type getWebhookDeliveriesResponseV1 struct {
+	Deliveries []webhookDeliveryV1 `json:"deliveries"`
+}
+
+func toGetWebhookDeliveriesResponseV1(res models.GetWebhookDeliveriesResponse) any {
+	return getWebhookDeliveriesResponseV1{Deliveries: mapSlice(res.Deliveries, toWebhookDeliveryV1)}
+}
+
+type jobStatusV1 struct {
+	Name string `json:"name"`
+	Schedule string `json:"schedule"`
+	Local bool `json:"local"`
+	Leader bool `json:"leader"`
+	Running bool `json:"running"`
+	Runs int64 `json:"runs"`
+	Failures int64 `json:"failures"`
+	Skipped int64 `json:"skipped"`
+	LastRunAt *string `json:"last_run_at,omitempty"`
+	LastDurationMs int64 `json:"last_duration_ms"`
+	LastError string `json:"last_error,omitempty"`
+	NextRunAt *string `json:"next_run_at,omitempty"`
+}
+
+func toJobStatusV1(job models.JobStatus) jobStatusV1 {
+	return jobStatusV1{
+		Name: job.Name,
+		Schedule: job.Schedule,
+		Local: job.Local,
+		Leader: job.Leader,
+		Running: job.Running,
+		Runs: job.Runs,
+		Failures: job.Failures,
+		Skipped: job.Skipped,
+		LastRunAt: optionalTimestamp(job.LastRunAt),
+		LastDurationMs: job.LastDurationMs,
+		LastError: job.LastError,
+		NextRunAt: optionalTimestamp(job.NextRunAt),
+	}
+}
+
+type getJobsResponseV1 struct {
+	Jobs []jobStatusV1 `json:"jobs"`
+}
+
+func toGetJobsResponseV1(res models.GetJobsResponse) any {
+	return getJobsResponseV1{Jobs: mapSlice(res.Jobs, toJobStatusV1)}
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+)
+
+// questionETag covers the question version and every answer version, so
+// adding or removing an answer changes the tag of GET /questions/{id}.
+func questionETag(res models.GetQuestionResponse) string {
+	parts := make([]string, 0, len(res.Answers)+1)
+	for _, answer := range res.Answers {
+		parts = append(parts, answerPart(answer))
+	}
+	sort.Strings(parts)
+	parts = append([]string{fmt.Sprintf("q%d:%d", res.Question.ID, res.Question.Version)}, parts...)
+
+	return makeETag(parts)
+}
+
+func answerETag(answer models.Answer) string {
+	return makeETag([]string{answerPart(answer)})
+}
+
+// answerPart covers the answer version and the profile of its author, who
+// can change the embedded name and avatar without a new answer version.
+func answerPart(answer models.Answer) string {
+	if answer.Author == nil {
+		return fmt.Sprintf("a%d:%d", answer.ID, answer.Version)
+	}
+	return fmt.Sprintf("a%d:%d:%d", answer.ID, answer.Version, answer.Author.UpdatedAt.UnixNano())
+}
+
+func makeETag(parts []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, ";")))
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// etagMatches reports whether the If-Match/If-None-Match header value lists
+// the etag. With weak, as for If-None-Match, weak validators are compared by
+// their opaque part; If-Match takes the strong comparison, which no weak
+// validator passes (RFC 9110, section 13.1.1).
+func etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchQuestion checks the If-Match header of a request changing the
+// question against its current ETag and returns the version the change is
+// made at. Without the header it answers 428, so the change cannot
+// overwrite a version the client has not seen, and 412 when it does not
+// match.
+func(s *Server) matchQuestion(ctx context.Context, writer http.ResponseWriter, request *http.Request, id int) (int, bool) {
+	match, ok := ifMatch(writer, request)
+	if !ok {
+		return 0, false
+	}
+	current, err := s.service.Question(ctx, id)
+	if !preconditionHolds(writer, match, err, func() string { return questionETag(current) }) {
+		return 0, false
+	}
+
+	return current.Question.Version, true
+}
+
+// matchAnswer is matchQuestion for an answer.
+func(s *Server) matchAnswer(ctx context.Context, writer http.ResponseWriter, request *http.Request, id int) (int, bool) {
+	match, ok := ifMatch(writer, request)
+	if !ok {
+		return 0, false
+	}
+	current, err := s.service.Answer(ctx, id)
+	if !preconditionHolds(writer, match, err, func() string { return answerETag(current.Answer) }) {
+		return 0, false
+	}
+
+	return current.Answer.Version, true
+}
+
+func ifMatch(writer http.ResponseWriter, request *http.Request) (string, bool) {
+	header := request.Header.Get("If-Match")
+	if header == "" {
+		writer.WriteHeader(http.StatusPreconditionRequired)
+		fmt.Fprint(writer, "PreconditionRequired")
+		return "", false
+	}
+
+	return header, true
+}
+
+func preconditionHolds(writer http.ResponseWriter, match string, err error, etag func() string) bool {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writer.WriteHeader(http.StatusPreconditionFailed)
+		} else {
+			writer.WriteHeader(http.StatusInternalServerError)
+		}
+		fmt.Fprint(writer, err.Error())
+		return false
+	}
+	if !etagMatches(match, etag(), false) {
+		writer.WriteHeader(http.StatusPreconditionFailed)
+		fmt.Fprint(writer, service.ErrVersionMismatch.Error())
+		return false
+	}
+
+	return true
+}
+
+// notModified sets the ETag header and reports whether the client copy is
+// still fresh, in which case a 304 has already been written.
+func notModified(writer http.ResponseWriter, request *http.Request, etag string) bool {
+	writer.Header().Set("ETag", etag)
+	ifNoneMatch := request.Header.Get("If-None-Match")
+	if ifNoneMatch == "" || !etagMatches(ifNoneMatch, etag, true) {
+		return false
+	}
+	writer.WriteHeader(http.StatusNotModified)
+
+	return true
+}
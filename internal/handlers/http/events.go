@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"gorm.io/gorm"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+type EventSource interface {
+	Subscribe(filter events.Filter, lastID uint64) ([]events.Event, *events.Subscription)
+}
+
+// QuestionEvents streams answer events of one question as Server-Sent Events.
+// Reconnecting clients resume from the Last-Event-ID header.
+func(s *Server) QuestionEvents(writer http.ResponseWriter, request *http.Request) {
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive a request to stream events of question with id: %d", id))
+
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	_, err = s.service.Question(ctx, id)
+	cancel()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writer.WriteHeader(http.StatusNotFound)
+		} else {
+			writer.WriteHeader(http.StatusInternalServerError)
+		}
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+
+	flusher, ok := writer.(http.Flusher)
+	if !ok || s.events == nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(writer, "StreamingUnsupported")
+		return
+	}
+
+	lastID, err := lastEventID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+
+	replay, sub := s.events.Subscribe(events.ForQuestion(id), lastID)
+	defer sub.Close()
+
+	header := writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+
+	for _, event := range replay {
+		s.writeEvent(writer, event)
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-s.done:
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			s.writeEvent(writer, event)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(writer, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func(s *Server) writeEvent(writer http.ResponseWriter, event events.Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		s.log.Error(
+			"MarshalingJSONError", 
+			slog.String("component", "json/marshalling"),
+			slog.Any("error", err),
+		)
+		return
+	}
+	fmt.Fprintf(writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
+
+func lastEventID(request *http.Request) (uint64, error) {
+	raw := request.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = request.URL.Query().Get("last_event_id")
+	}
+	if raw == "" {
+		return events.Latest, nil
+	}
+
+	return strconv.ParseUint(raw, 10, 64)
+}
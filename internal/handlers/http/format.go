@@ -0,0 +1,45 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+const (
+	formatHTML = "html"
+	formatMarkdown = "markdown"
+)
+
+// getFormat reads the optional format query parameter. Texts come both as
+// Markdown and as HTML unless it asks for one of them.
+func getFormat(r *http.Request) (string, error) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != formatHTML && format != formatMarkdown {
+		return "", errors.New("InvalidFormat")
+	}
+
+	return format, nil
+}
+
+func formatQuestions(questions []models.Question, format string) {
+	for i := range questions {
+		formatText(&questions[i].Text, &questions[i].TextHTML, format)
+	}
+}
+
+func formatAnswers(answers []models.Answer, format string) {
+	for i := range answers {
+		formatText(&answers[i].Text, &answers[i].TextHTML, format)
+	}
+}
+
+func formatText(text, html *string, format string) {
+	switch format {
+	case formatHTML:
+		*text = ""
+	case formatMarkdown:
+		*html = ""
+	}
+}
@@ -4,14 +4,26 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"mime/multipart"
+	"net"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/behummble/Questions-answers/internal/attachments"
 	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
 	serv "github.com/behummble/Questions-answers/internal/handlers/http"
+	"github.com/behummble/Questions-answers/internal/mock"
 	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/notifications"
+	"github.com/behummble/Questions-answers/internal/service"
 )
 
 func TestCreateQuestion(t *testing.T) {
@@ -143,7 +155,7 @@ func TestGetQuestionWithIncorrectID(t *testing.T) {
 func TestDeleteQuestion(t *testing.T) {
 	s := createServer()
 
-	req, err := http.NewRequest("DELETE", "/questions/1", nil)
+	req, err := http.NewRequest("GET", "/questions/1", nil)
     if err != nil {
         t.Fatal(err)
     }
@@ -151,6 +163,15 @@ func TestDeleteQuestion(t *testing.T) {
     rr := httptest.NewRecorder()
     s.GetHandler().ServeHTTP(rr, req)
 
+	req, err = http.NewRequest("DELETE", "/questions/1", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("If-Match", rr.Header().Get("ETag"))
+
+	rr = httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
     if status := rr.Code; status != http.StatusNoContent {
         t.Errorf("handler returned wrong status code: got %v want %v",
             status, http.StatusNoContent)
@@ -336,9 +357,9 @@ func TestDeleteAnswer(t *testing.T) {
     rr := httptest.NewRecorder()
     s.GetHandler().ServeHTTP(rr, req)
 
-    if status := rr.Code; status != http.StatusNoContent {
+    if status := rr.Code; status != http.StatusPreconditionRequired {
         t.Errorf("handler returned wrong status code: got %v want %v",
-            status, http.StatusNoContent)
+            status, http.StatusPreconditionRequired)
     }
 }
 
@@ -376,19 +397,162 @@ func TestDeleteAnswerWithIncorrectID(t *testing.T) {
     }
 }
 
-func createServer() *serv.Server {
-	return serv.NewServer(
+func TestGetQuestionNotModified(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("GET", "/questions/1", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+	etag := rr.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("handler returned no ETag header")
+	}
+
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusNotModified {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusNotModified)
+    }
+	if rr.Body.Len() != 0 {
+		t.Errorf("handler returned unexpected body: got %v want empty", rr.Body.String())
+	}
+}
+
+func TestDeleteQuestionWithStaleETag(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("DELETE", "/questions/1", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("If-Match", `"stale"`)
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusPreconditionFailed {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusPreconditionFailed)
+    }
+}
+
+func TestDeleteAnswerWithWeakETag(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("GET", "/answers/1", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+	req, err = http.NewRequest("DELETE", "/answers/1", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("If-Match", "W/" + rr.Header().Get("ETag"))
+
+	rr = httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusPreconditionFailed {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusPreconditionFailed)
+    }
+}
+
+func TestDeleteAnswerWithMatchingETag(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("GET", "/answers/1", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+	req, err = http.NewRequest("DELETE", "/answers/1", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("If-Match", rr.Header().Get("ETag"))
+
+	rr = httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusNoContent {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusNoContent)
+    }
+}
+
+func TestShutdownClosesEventStreams(t *testing.T) {
+	s := createServer()
+	ts := httptest.NewServer(s.GetHandler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/questions/1/events")
+    if err != nil {
+        t.Fatal(err)
+    }
+	defer resp.Body.Close()
+
+    if status := resp.StatusCode; status != http.StatusOK {
+        t.Fatalf("handler returned wrong status code: got %v want %v",
+            status, http.StatusOK)
+    }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
+	defer cancel()
+	s.Shutdown(ctx)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadAll(resp.Body)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Excpected clean end of stream, got %v", err)
+		}
+	case <-ctx.Done():
+		t.Error("Event stream was not closed on shutdown")
+	}
+}
+
+func createServer() contractServer {
+	return checked(serv.NewServer(
 		context.Background(),
 		slog.Default(),
 		serverConfig(),
 		mockServiceLogic(),
-	)
+		serv.Deps{Events: events.NewHub(16, 16)},
+	))
 }
 
 func serverConfig() *config.ServerConfig {
 	return &config.ServerConfig{}
 }
 
+// createAdminServer serves the moderation and batch endpoints, which need
+// the admin token "secret".
+func createAdminServer() contractServer {
+	cfg := serverConfig()
+	cfg.AdminToken = "secret"
+	return checked(serv.NewServer(context.Background(), slog.Default(), cfg, mockServiceLogic(), serv.Deps{Events: events.NewHub(16, 16)}))
+}
+
 type MockService struct {
 
 }
@@ -401,22 +565,63 @@ func(s *MockService) Shutdown(ctx context.Context) {
 	
 }
 
-func(s *MockService) NewQuestion(ctx context.Context, question []byte) (models.CreateQuestionResponse, error) {
+func(s *MockService) NewQuestion(ctx context.Context, question []byte, strict bool) (models.CreateQuestionResponse, error) {
+	if strict {
+		return models.CreateQuestionResponse{Duplicates: []models.SimilarQuestion{{ID: 1, Text: "test", Similarity: 1}}}, service.ErrDuplicateQuestion
+	}
 	return models.CreateQuestionResponse{}, nil
 }
 
+func(s *MockService) MergeQuestions(ctx context.Context, id, target, version int, data []byte) (models.MergeQuestionResponse, error) {
+	return models.MergeQuestionResponse{}, service.ErrPrivilegeRequired
+}
+
+func(s *MockService) PatchAnswer(ctx context.Context, id, version int, data []byte) (models.GetAnswerResponse, error) {
+	return models.GetAnswerResponse{}, service.ErrInvalidMove
+}
+
+func(s *MockService) MarkDuplicate(ctx context.Context, id, target, version int, data []byte) (models.MarkDuplicateResponse, error) {
+	if id == target {
+		return models.MarkDuplicateResponse{}, service.ErrInvalidDuplicate
+	}
+	return models.MarkDuplicateResponse{Question: models.Question{ID: id, DuplicateOf: &target}}, nil
+}
+
 func(s *MockService) Question(ctx context.Context, id int) (models.GetQuestionResponse, error) {	
+	if id == 2 {
+		target := 1
+		return models.GetQuestionResponse{Question: models.Question{ID: id, DuplicateOf: &target}}, nil
+	}
 	return  models.GetQuestionResponse{}, nil
 }
 
-func(s *MockService) AllQuestions(ctx context.Context) (models.GetQuestionsResponse, error) {
+func(s *MockService) AllQuestions(ctx context.Context, sort string) (models.GetQuestionsResponse, error) {
+	if sort != "" && sort != "views" {
+		return models.GetQuestionsResponse{}, service.ErrInvalidSort
+	}
 	return models.GetQuestionsResponse{}, nil
 }
 
-func(s *MockService) DeleteQuestion(ctx context.Context, id int) error {
+func(s *MockService) StreamQuestions(ctx context.Context, sort string, yield func(models.Question) error) error {
+	return nil
+}
+
+func(s *MockService) Answers(ctx context.Context, questionID int) (models.GetAnswersResponse, error) {
+	return models.GetAnswersResponse{}, nil
+}
+
+func(s *MockService) StreamAnswers(ctx context.Context, questionID int, yield func(models.Answer) error) error {
+	return nil
+}
+
+func(s *MockService) DeleteQuestion(ctx context.Context, id, version int) error {
 	return nil
 }
 
+func(s *MockService) BatchQuestions(ctx context.Context, data []byte, atomic bool, limit int) (models.BatchQuestionsResponse, error) {
+	return models.BatchQuestionsResponse{Atomic: atomic}, nil
+}
+
 func(s *MockService) NewAnswer(ctx context.Context, answer []byte, questionID int) (models.CreateAnswerResponse, error) {
 	return models.CreateAnswerResponse{}, nil
 }
@@ -425,6 +630,985 @@ func(s *MockService) Answer(ctx context.Context, id int) (models.GetAnswerRespon
 	return models.GetAnswerResponse{}, nil
 }
 
-func(s *MockService) DeleteAnswer(ctx context.Context, id int) error {
+func(s *MockService) DeleteAnswer(ctx context.Context, id, version int) error {
 	return nil
-}
\ No newline at end of file
+}
+
+func(s *MockService) User(ctx context.Context, id string) (models.GetUserResponse, error) {
+	return models.GetUserResponse{}, nil
+}
+
+func(s *MockService) UpdateUser(ctx context.Context, id string, data []byte) (models.GetUserResponse, error) {
+	return models.GetUserResponse{}, nil
+}
+
+func(s *MockService) UserAnswers(ctx context.Context, id string, page models.Page) (models.GetUserAnswersResponse, error) {
+	return models.GetUserAnswersResponse{Limit: page.Limit, Offset: page.Offset}, nil
+}
+
+func(s *MockService) UserQuestions(ctx context.Context, id string, page models.Page) (models.GetUserQuestionsResponse, error) {
+	return models.GetUserQuestionsResponse{Limit: page.Limit, Offset: page.Offset}, nil
+}
+
+func(s *MockService) VoteAnswer(ctx context.Context, id int, data []byte) (models.VoteResponse, error) {
+	return models.VoteResponse{}, service.ErrPrivilegeRequired
+}
+
+func(s *MockService) AcceptAnswer(ctx context.Context, id int, userID string) (models.GetAnswerResponse, error) {
+	return models.GetAnswerResponse{}, nil
+}
+
+func(s *MockService) Leaderboard(ctx context.Context, page models.Page) (models.GetLeaderboardResponse, error) {
+	return models.GetLeaderboardResponse{Limit: page.Limit, Offset: page.Offset}, nil
+}
+
+func(s *MockService) Reputation(ctx context.Context, id string, page models.Page) (models.GetReputationResponse, error) {
+	return models.GetReputationResponse{}, nil
+}
+
+func(s *MockService) CommentQuestion(ctx context.Context, id int, data []byte) (models.CreateCommentResponse, error) {
+	return models.CreateCommentResponse{}, nil
+}
+
+func(s *MockService) CommentAnswer(ctx context.Context, id int, data []byte) (models.CreateCommentResponse, error) {
+	return models.CreateCommentResponse{}, nil
+}
+
+func(s *MockService) QuestionComments(ctx context.Context, id int, page models.Page) (models.GetCommentsResponse, error) {
+	return models.GetCommentsResponse{}, nil
+}
+
+func(s *MockService) AnswerComments(ctx context.Context, id int, page models.Page) (models.GetCommentsResponse, error) {
+	return models.GetCommentsResponse{}, nil
+}
+
+func(s *MockService) DeleteComment(ctx context.Context, id int, userID string) error {
+	return nil
+}
+
+func TestGetUserAnswersWithInvalidPage(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("GET", "/users/3fa85f64-5717-4562-b3fc-2c963f66afa6/answers?limit=-1", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusBadRequest {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusBadRequest)
+    }
+}
+
+func TestVoteAnswerWithoutPrivilege(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("PUT", "/answers/1/vote", bytes.NewReader([]byte(`{"Value":-1}`)))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusForbidden {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusForbidden)
+    }
+}
+
+func TestGetLeaderboardIsNotAUser(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("GET", "/users/leaderboard?limit=5", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+	res, _ := json.Marshal(models.GetLeaderboardResponse{Limit: 5})
+    if rr.Code != http.StatusOK || rr.Body.String() != string(res) {
+        t.Errorf("handler returned unexpected response: got %v %v want %v",
+            rr.Code, rr.Body.String(), string(res))
+    }
+}
+
+func TestGetAllQuestionsWithInvalidSort(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("GET", "/questions?sort=votes", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusBadRequest {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusBadRequest)
+    }
+}
+
+type recordingViews struct {
+	viewers []string
+}
+
+func(v *recordingViews) Record(questionID int, viewer string) {
+	v.viewers = append(v.viewers, viewer)
+}
+
+func(v *recordingViews) Trending(ctx context.Context, page models.Page) (models.GetQuestionsResponse, error) {
+	return models.GetQuestionsResponse{}, nil
+}
+
+func TestGetQuestionRecordsViewer(t *testing.T) {
+	views := &recordingViews{}
+	s := checked(serv.NewServer(context.Background(), slog.Default(), serverConfig(), mockServiceLogic(), serv.Deps{Events: events.NewHub(16, 16), Views: views}))
+
+	for _, user := range []string{"", "3fa85f64-5717-4562-b3fc-2c963f66afa6"} {
+		req, err := http.NewRequest("GET", "/questions/1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.RemoteAddr = "10.0.0.1:5000"
+		req.Header.Set("X-Forwarded-For", "192.0.2.1")
+		if user != "" {
+			req.Header.Set("X-User-ID", user)
+		}
+		s.GetHandler().ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	req, err := http.NewRequest("GET", "/questions/trending", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+    if status := rr.Code; status != http.StatusOK {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusOK)
+    }
+
+	// X-User-ID does not make another viewer of the same client.
+	excpected := []string{"ip:10.0.0.1", "ip:10.0.0.1"}
+	if len(views.viewers) != len(excpected) || views.viewers[0] != excpected[0] || views.viewers[1] != excpected[1] {
+		t.Errorf("Excpected viewers %v, got %v", excpected, views.viewers)
+	}
+}
+
+func TestViewerIsTheHopOfTheProxy(t *testing.T) {
+	views := &recordingViews{}
+	cfg := serverConfig()
+	cfg.TrustProxy = true
+	s := checked(serv.NewServer(context.Background(), slog.Default(), cfg, mockServiceLogic(), serv.Deps{Events: events.NewHub(16, 16), Views: views}))
+
+	// The client makes up hops; the proxy appends the address it sees.
+	for _, forged := range []string{"198.51.100.1", "198.51.100.2, 198.51.100.3"} {
+		req := httptest.NewRequest("GET", "/questions/1", nil)
+		req.Header.Set("X-Forwarded-For", forged + ", 192.0.2.1")
+		s.GetHandler().ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	excpected := []string{"ip:192.0.2.1", "ip:192.0.2.1"}
+	if len(views.viewers) != len(excpected) || views.viewers[0] != excpected[0] || views.viewers[1] != excpected[1] {
+		t.Errorf("Excpected viewers %v, got %v", excpected, views.viewers)
+	}
+}
+
+func TestCreateQuestionStrictWithDuplicates(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("POST", "/questions?strict=true", bytes.NewReader([]byte(`{"Text":"test"}`)))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusConflict {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusConflict)
+    }
+	var res models.CreateQuestionResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &res); err != nil || len(res.Duplicates) != 1 {
+		t.Errorf("Excpected duplicates in the body, got %s", rr.Body.String())
+	}
+}
+
+// currentETag is the ETag a GET of the path answers with.
+func currentETag(t *testing.T, s contractServer, path string) string {
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, req)
+	if rr.Header().Get("ETag") == "" {
+		t.Fatalf("GET %s returned no ETag", path)
+	}
+	return rr.Header().Get("ETag")
+}
+
+func TestDuplicateRedirectsTemporarily(t *testing.T) {
+	s := createServer()
+
+	rr := httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, httptest.NewRequest("GET", "/v1/questions/2?format=html", nil))
+
+	if rr.Code != http.StatusFound {
+		t.Errorf("Excpected %d, got %d", http.StatusFound, rr.Code)
+	}
+	if location := rr.Header().Get("Location"); location != "/v1/questions/1?format=html" {
+		t.Errorf("Excpected the duplicated question, got %q", location)
+	}
+
+	rr = httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, httptest.NewRequest("GET", "/v1/questions/2?redirect=false", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Excpected the duplicate itself with redirect=false, got %d", rr.Code)
+	}
+}
+
+func TestMarkQuestionDuplicateOfItself(t *testing.T) {
+	s := createAdminServer()
+
+	req, err := http.NewRequest("POST", "/questions/1/duplicate-of/1", bytes.NewReader([]byte(`{"UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6"}`)))
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("If-Match", currentETag(t, s, "/questions/1"))
+	req.Header.Set("Authorization", "Bearer secret")
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusBadRequest {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusBadRequest)
+    }
+}
+
+func TestAcceptAnswerWithoutUser(t *testing.T) {
+	s := createServer()
+
+	rr := httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, httptest.NewRequest("POST", "/v1/answers/1/accept", nil))
+
+	if status := rr.Code; status != http.StatusUnauthorized {
+		t.Errorf("Excpected 401 without X-User-ID, got %v", status)
+	}
+}
+
+func TestMarkDuplicateWithoutToken(t *testing.T) {
+	s := createAdminServer()
+
+	req, err := http.NewRequest("POST", "/v1/questions/2/duplicate-of/1", bytes.NewReader([]byte(`{"user_id":"3fa85f64-5717-4562-b3fc-2c963f66afa6"}`)))
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("If-Match", currentETag(t, s, "/questions/2?redirect=false"))
+
+	rr := httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnauthorized {
+		t.Errorf("Excpected 401 without the admin token, got %v", status)
+	}
+
+	rr = httptest.NewRecorder()
+	createServer().GetHandler().ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("Excpected no duplicate-of route without an admin token, got %v", status)
+	}
+}
+
+func TestMergeQuestionsWithoutToken(t *testing.T) {
+	s := createAdminServer()
+
+	req, err := http.NewRequest("POST", "/questions/2/merge-into/1", bytes.NewReader([]byte(`{"UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6"}`)))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusUnauthorized {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusUnauthorized)
+    }
+}
+
+func TestMergeQuestionsWithoutPrivilege(t *testing.T) {
+	s := createAdminServer()
+
+	req, err := http.NewRequest("POST", "/questions/2/merge-into/1", bytes.NewReader([]byte(`{"UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6"}`)))
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("If-Match", currentETag(t, s, "/questions/2?redirect=false"))
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusForbidden {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusForbidden)
+    }
+}
+
+func TestMergeQuestionsWithoutIfMatch(t *testing.T) {
+	s := createAdminServer()
+
+	req, err := http.NewRequest("POST", "/questions/2/merge-into/1", bytes.NewReader([]byte(`{"UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6"}`)))
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("Authorization", "Bearer secret")
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusPreconditionRequired {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusPreconditionRequired)
+    }
+
+	req.Header.Set("If-Match", `"stale"`)
+	req.Body = io.NopCloser(bytes.NewReader([]byte(`{"UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6"}`)))
+	rr = httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusPreconditionFailed {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusPreconditionFailed)
+    }
+}
+
+func TestPatchAnswerWithoutIfMatch(t *testing.T) {
+	s := createAdminServer()
+
+	req, err := http.NewRequest("PATCH", "/answers/1", bytes.NewReader([]byte(`{"UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6","QuestionID":2}`)))
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("Authorization", "Bearer secret")
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusPreconditionRequired {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusPreconditionRequired)
+    }
+
+	req.Header.Set("If-Match", `"stale"`)
+	req.Body = io.NopCloser(bytes.NewReader([]byte(`{"UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6","QuestionID":2}`)))
+	rr = httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusPreconditionFailed {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusPreconditionFailed)
+    }
+}
+
+func TestPatchAnswerWithoutChange(t *testing.T) {
+	s := createAdminServer()
+
+	req, err := http.NewRequest("PATCH", "/answers/1", bytes.NewReader([]byte(`{"UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6"}`)))
+    if err != nil {
+        t.Fatal(err)
+    }
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("If-Match", currentETag(t, s, "/answers/1"))
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusBadRequest {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusBadRequest)
+    }
+}
+
+func TestGetQuestionWithInvalidFormat(t *testing.T) {
+	s := createServer()
+
+	req, err := http.NewRequest("GET", "/questions/1?format=pdf", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rr := httptest.NewRecorder()
+    s.GetHandler().ServeHTTP(rr, req)
+
+    if status := rr.Code; status != http.StatusBadRequest {
+        t.Errorf("handler returned wrong status code: got %v want %v",
+            status, http.StatusBadRequest)
+    }
+}
+
+func TestUploadAttachmentLimits(t *testing.T) {
+	blobs, err := attachments.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	if err := questions.CreateQuestion(context.Background(), &models.Question{Text: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.AttachmentsConfig{MaxSize: 64, AllowedTypes: []string{"text/plain"}, URLTTL: time.Minute, SigningKey: "test"}
+	manager, err := attachments.NewManager(slog.Default(), cfg, mock.NewMockStorageAttachments(questions), blobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := checked(serv.NewServer(context.Background(), slog.Default(), serverConfig(), mockServiceLogic(), serv.Deps{Events: events.NewHub(16, 16), Attachments: manager}))
+
+	for file, status := range map[string]int{
+		"short log": http.StatusCreated,
+		"<html><body>x</body></html>": http.StatusUnsupportedMediaType,
+		strings.Repeat("log ", 1 << 15): http.StatusRequestEntityTooLarge,
+	} {
+		var form bytes.Buffer
+		writer := multipart.NewWriter(&form)
+		writer.WriteField("UserID", "3fa85f64-5717-4562-b3fc-2c963f66afa6")
+		part, _ := writer.CreateFormFile("file", "app.log")
+		part.Write([]byte(file))
+		writer.Close()
+		req, err := http.NewRequest("POST", "/questions/1/attachments", &form)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		rr := httptest.NewRecorder()
+		s.GetHandler().ServeHTTP(rr, req)
+
+		if rr.Code != status {
+			t.Errorf("handler returned wrong status code for %.20q: got %v want %v", file, rr.Code, status)
+		}
+	}
+}
+
+func TestNotificationsRequireUser(t *testing.T) {
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	inbox := notifications.NewInbox(slog.Default(), mock.NewMockStorageNotifications(questions), nil)
+	s := checked(serv.NewServer(context.Background(), slog.Default(), serverConfig(), mockServiceLogic(), serv.Deps{Events: events.NewHub(16, 16), Notifications: inbox}))
+
+	for user, status := range map[string]int{
+		"": http.StatusUnauthorized,
+		"not-a-uuid": http.StatusBadRequest,
+		"3fa85f64-5717-4562-b3fc-2c963f66afa6": http.StatusOK,
+	} {
+		req, err := http.NewRequest("GET", "/me/notifications?unread=true", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user != "" {
+			req.Header.Set("X-User-ID", user)
+		}
+
+		rr := httptest.NewRecorder()
+		s.GetHandler().ServeHTTP(rr, req)
+
+		if rr.Code != status {
+			t.Errorf("handler returned wrong status code for %q: got %v want %v", user, rr.Code, status)
+		}
+	}
+
+	// The verification link is opened without X-User-ID.
+	req, err := http.NewRequest("GET", "/notifications/verify?token=unknown", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code for an unknown token: got %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+type staticJobs []models.JobStatus
+
+func(j staticJobs) Jobs() []models.JobStatus {
+	return j
+}
+
+func TestAdminJobsRequireToken(t *testing.T) {
+	cfg := serverConfig()
+	cfg.AdminToken = "secret"
+	jobs := staticJobs{{Name: "views_flush", Schedule: "@every 10s", Local: true, Runs: 3}}
+	s := checked(serv.NewServer(context.Background(), slog.Default(), cfg, mockServiceLogic(), serv.Deps{Events: events.NewHub(16, 16), Jobs: jobs}))
+
+	for token, status := range map[string]int{
+		"": http.StatusUnauthorized,
+		"Bearer wrong": http.StatusUnauthorized,
+		"Bearer secret": http.StatusOK,
+	} {
+		req, err := http.NewRequest("GET", "/admin/jobs", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+
+		rr := httptest.NewRecorder()
+		s.GetHandler().ServeHTTP(rr, req)
+
+		if rr.Code != status {
+			t.Errorf("handler returned wrong status code for %q: got %v want %v", token, rr.Code, status)
+		}
+		if status != http.StatusOK {
+			continue
+		}
+		var res models.GetJobsResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Jobs) != 1 || res.Jobs[0].Name != "views_flush" || res.Jobs[0].Runs != 3 {
+			t.Errorf("Excpected the views_flush job, got %+v", res.Jobs)
+		}
+	}
+
+	for token, status := range map[string]int{"": http.StatusUnauthorized, "Bearer secret": http.StatusOK} {
+		req, err := http.NewRequest("GET", "/debug/vars", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		rr := httptest.NewRecorder()
+		s.GetHandler().ServeHTTP(rr, req)
+		if rr.Code != status {
+			t.Errorf("Excpected %v for /debug/vars with %q, got %v", status, token, rr.Code)
+		}
+	}
+
+	// Without a token the admin endpoints are not served.
+	s = checked(serv.NewServer(context.Background(), slog.Default(), serverConfig(), mockServiceLogic(), serv.Deps{Events: events.NewHub(16, 16), Jobs: jobs}))
+	for _, path := range []string{"/admin/jobs", "/debug/vars"} {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		s.GetHandler().ServeHTTP(rr, req)
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Excpected 404 for %s without an admin token, got %v", path, rr.Code)
+		}
+	}
+}
+
+func TestLegacyRoutesAreDeprecated(t *testing.T) {
+	cfg := serverConfig()
+	cfg.LegacyRoutes.Deprecated = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	cfg.LegacyRoutes.Sunset = time.Date(2027, 4, 19, 0, 0, 0, 0, time.UTC)
+	s := checked(serv.NewServer(context.Background(), slog.Default(), cfg, mockServiceLogic(), serv.Deps{Events: events.NewHub(16, 16)}))
+
+	rr := httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, httptest.NewRequest("GET", "/v1/questions/1", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	if rr.Header().Get("Deprecation") != "" {
+		t.Errorf("Excpected no Deprecation header on /v1, got %s", rr.Header().Get("Deprecation"))
+	}
+
+	rr = httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, httptest.NewRequest("GET", "/questions/1", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	if deprecation := rr.Header().Get("Deprecation"); deprecation != "@1792368000" {
+		t.Errorf("Excpected Deprecation @1792368000, got %s", deprecation)
+	}
+	if sunset := rr.Header().Get("Sunset"); sunset != "Mon, 19 Apr 2027 00:00:00 GMT" {
+		t.Errorf("Excpected the Sunset date, got %s", sunset)
+	}
+	if link := rr.Header().Get("Link"); link != `</v1/questions/1>; rel="successor-version"` {
+		t.Errorf("Excpected a link to /v1, got %s", link)
+	}
+
+	cfg.LegacyRoutes.Disabled = true
+	s = checked(serv.NewServer(context.Background(), slog.Default(), cfg, mockServiceLogic(), serv.Deps{Events: events.NewHub(16, 16)}))
+	rr = httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, httptest.NewRequest("GET", "/questions/1", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Excpected 404 with the legacy routes disabled, got %v", rr.Code)
+	}
+}
+
+func createV1Server() contractServer {
+	answers := mock.NewMockStorageAnswers(10)
+	questions := mock.NewMockStorageQuestions(10, answers)
+	svc := service.NewService(slog.Default(), questions, answers, mock.NewMockStorageUsers(questions), mock.NewMockStorageComments(questions), config.ReputationConfig{}, config.DuplicatesConfig{}, nil)
+	return checked(serv.NewServer(context.Background(), slog.Default(), serverConfig(), svc, serv.Deps{Events: events.NewHub(16, 16)}))
+}
+
+func serveJSON(t *testing.T, s contractServer, method, path, body string) (int, map[string]any) {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	rr := httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, httptest.NewRequest(method, path, reader))
+	var res map[string]any
+	if rr.Code < http.StatusBadRequest {
+		if err := json.Unmarshal(rr.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Excpected JSON from %s %s, got %s", method, path, rr.Body.String())
+		}
+	}
+	return rr.Code, res
+}
+
+// assertV1Contract checks that every field is snake_case and every
+// *_at field an RFC 3339 timestamp.
+func assertV1Contract(t *testing.T, value any, path string) {
+	t.Helper()
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if key != strings.ToLower(key) || strings.ContainsAny(key, " -") {
+				t.Errorf("Excpected a snake_case field, got %s%s", path, key)
+			}
+			if strings.HasSuffix(key, "_at") {
+				text, _ := field.(string)
+				if _, err := time.Parse(time.RFC3339, text); err != nil {
+					t.Errorf("Excpected an RFC 3339 timestamp in %s%s, got %v", path, key, field)
+				}
+			}
+			assertV1Contract(t, field, path + key + ".")
+		}
+	case []any:
+		for _, item := range value {
+			assertV1Contract(t, item, path)
+		}
+	}
+}
+
+func TestV1UsesSnakeCaseDTOs(t *testing.T) {
+	s := createV1Server()
+	userID := "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+	status, created := serveJSON(t, s, "POST", "/v1/questions", `{"text": "What is Go?", "user_id": "` + userID + `"}`)
+	if status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+	assertV1Contract(t, created, "")
+	question, _ := created["question"].(map[string]any)
+	if question["user_id"] != userID || question["text"] != "What is Go?" {
+		t.Fatalf("Excpected the question with its user_id, got %v", created)
+	}
+	id := int(question["id"].(float64))
+
+	status, answers := serveJSON(t, s, "POST", "/v1/questions/" + strconv.Itoa(id) + "/answers", `{"user_id": "` + userID + `", "texts": ["A language"]}`)
+	if status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+	assertV1Contract(t, answers, "")
+
+	status, res := serveJSON(t, s, "GET", "/v1/questions/" + strconv.Itoa(id), "")
+	if status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	assertV1Contract(t, res, "")
+	list, _ := res["answers"].([]any)
+	if len(list) != 1 || list[0].(map[string]any)["question_id"] != float64(id) {
+		t.Errorf("Excpected the answer with its question_id, got %v", res["answers"])
+	}
+
+	status, _ = serveJSON(t, s, "POST", "/v1/questions", `{"text": `)
+	if status != http.StatusBadRequest {
+		t.Errorf("Excpected 400 for malformed JSON, got %v", status)
+	}
+
+	// The unversioned routes keep the models format.
+	status, legacy := serveJSON(t, s, "GET", "/questions/" + strconv.Itoa(id), "")
+	if status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	if _, ok := legacy["Question"].(map[string]any)["CreatedAt"]; !ok {
+		t.Errorf("Excpected the legacy format, got %v", legacy)
+	}
+}
+
+func TestComments(t *testing.T) {
+	answers := mock.NewMockStorageAnswers(10)
+	questions := mock.NewMockStorageQuestions(10, answers)
+	reputation := config.ReputationConfig{Privileges: config.PrivilegesConfig{EditOthers: 2000}}
+	svc := service.NewService(slog.Default(), questions, answers, mock.NewMockStorageUsers(questions), mock.NewMockStorageComments(questions), reputation, config.DuplicatesConfig{}, nil)
+	s := checked(serv.NewServer(context.Background(), slog.Default(), serverConfig(), svc, serv.Deps{Events: events.NewHub(16, 16)}))
+	userID := "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	otherID := "9b2f6c1e-8d4a-4f3b-a7e5-1c0d2e3f4a5b"
+
+	_, created := serveJSON(t, s, "POST", "/v1/questions", `{"text": "What is Go?", "user_id": "` + userID + `"}`)
+	questionID := strconv.Itoa(int(created["question"].(map[string]any)["id"].(float64)))
+
+	status, comment := serveJSON(t, s, "POST", "/v1/questions/" + questionID + "/comments", `{"user_id": "` + userID + `", "text": " Which version? "}`)
+	if status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+	assertV1Contract(t, comment, "")
+	commentID := strconv.Itoa(int(comment["comment"].(map[string]any)["id"].(float64)))
+
+	status, _ = serveJSON(t, s, "POST", "/v1/questions/" + questionID + "/comments", `{"user_id": "` + userID + `", "text": "   "}`)
+	if status != http.StatusBadRequest {
+		t.Errorf("Excpected 400 for an empty comment, got %v", status)
+	}
+	status, _ = serveJSON(t, s, "POST", "/v1/answers/999/comments", `{"user_id": "` + userID + `", "text": "Why?"}`)
+	if status != http.StatusNotFound {
+		t.Errorf("Excpected 404 for a comment on a missing answer, got %v", status)
+	}
+
+	status, res := serveJSON(t, s, "GET", "/v1/questions/" + questionID + "/comments", "")
+	if status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	list, _ := res["comments"].([]any)
+	if len(list) != 1 || list[0].(map[string]any)["text"] != "Which version?" {
+		t.Fatalf("Excpected the trimmed comment, got %v", res)
+	}
+
+	remove := func(userID string) int {
+		req := httptest.NewRequest("DELETE", "/v1/comments/" + commentID, nil)
+		if userID != "" {
+			req.Header.Set("X-User-ID", userID)
+		}
+		rr := httptest.NewRecorder()
+		s.GetHandler().ServeHTTP(rr, req)
+		return rr.Code
+	}
+	if status := remove(""); status != http.StatusUnauthorized {
+		t.Errorf("Excpected 401 without a user, got %v", status)
+	}
+	if status := remove(otherID); status != http.StatusForbidden {
+		t.Errorf("Excpected 403 for another user, got %v", status)
+	}
+	if status := remove(userID); status != http.StatusNoContent {
+		t.Errorf("Excpected 204 for the author, got %v", status)
+	}
+	if status := remove(userID); status != http.StatusNotFound {
+		t.Errorf("Excpected 404 for a deleted comment, got %v", status)
+	}
+}
+
+func TestBatchQuestions(t *testing.T) {
+	answers := mock.NewMockStorageAnswers(10)
+	questions := mock.NewMockStorageQuestions(10, answers)
+	svc := service.NewService(slog.Default(), questions, answers, mock.NewMockStorageUsers(questions), mock.NewMockStorageComments(questions), config.ReputationConfig{}, config.DuplicatesConfig{}, nil)
+	cfg := serverConfig()
+	cfg.AdminToken = "secret"
+	cfg.Batch = config.BatchConfig{MaxOperations: 3, MaxBytes: 512}
+	s := checked(serv.NewServer(context.Background(), slog.Default(), cfg, svc, serv.Deps{Events: events.NewHub(16, 16)}))
+
+	batch := func(path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer " + token)
+		}
+		rr := httptest.NewRecorder()
+		s.GetHandler().ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := batch("/v1/questions:batch", "", `{"operations": [{"op": "create", "text": "What is Go?"}]}`)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Excpected 401 without the admin token, got %v", rr.Code)
+	}
+
+	rr = batch("/v1/questions:batch", "secret", `{"operations": [{"op": "create", "text": "What is Go?"}, {"op": "create", "text": "What is a goroutine?"}]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	var res map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	assertV1Contract(t, res, "")
+	results, _ := res["results"].([]any)
+	if len(results) != 2 || results[0].(map[string]any)["status"] != float64(http.StatusCreated) {
+		t.Fatalf("Excpected 2 created questions, got %v", res)
+	}
+	id := int(results[0].(map[string]any)["question"].(map[string]any)["id"].(float64))
+
+	// The missing question fails the atomic batch, so the first delete is
+	// not applied either.
+	rr = batch("/v1/questions:batch", "secret", `{"operations": [{"op": "delete", "id": ` + strconv.Itoa(id) + `}, {"op": "delete", "id": 404}]}`)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusConflict)
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	results, _ = res["results"].([]any)
+	if len(results) != 2 || results[0].(map[string]any)["status"] != float64(http.StatusFailedDependency) || results[1].(map[string]any)["status"] != float64(http.StatusNotFound) {
+		t.Errorf("Excpected the missing question to fail the batch, got %s", rr.Body.String())
+	}
+
+	// The legacy route keeps the models format and applies what it can.
+	rr = batch("/questions:batch?atomic=false", "secret", `{"Operations": [{"Op": "delete", "ID": ` + strconv.Itoa(id) + `}, {"Op": "delete", "ID": 404}]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	var legacy models.BatchQuestionsResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &legacy); err != nil {
+		t.Fatal(err)
+	}
+	if legacy.Atomic || legacy.Results[0].Status != http.StatusNoContent || legacy.Results[1].Status != http.StatusNotFound {
+		t.Errorf("Excpected the first delete to be applied, got %+v", legacy)
+	}
+
+	tooMany := `{"operations": [{"op": "delete", "id": 1}, {"op": "delete", "id": 2}, {"op": "delete", "id": 3}, {"op": "delete", "id": 4}]}`
+	if rr = batch("/v1/questions:batch", "secret", tooMany); rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Excpected 413 for too many operations, got %v", rr.Code)
+	}
+	tooLarge := `{"operations": [{"op": "create", "text": "` + strings.Repeat("a", 512) + `"}]}`
+	for _, path := range []string{"/v1/questions:batch", "/questions:batch"} {
+		if rr = batch(path, "secret", tooLarge); rr.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("Excpected 413 for a large body on %s, got %v", path, rr.Code)
+		}
+	}
+	if rr = batch("/v1/questions:batch?atomic=maybe", "secret", tooMany); rr.Code != http.StatusBadRequest {
+		t.Errorf("Excpected 400 for an invalid atomic, got %v", rr.Code)
+	}
+}
+
+func serveNDJSON(t *testing.T, s contractServer, path string) (int, []map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest("GET", path, nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rr := httptest.NewRecorder()
+	s.GetHandler().ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		return rr.Code, nil
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/x-ndjson" {
+		t.Fatalf("Excpected an NDJSON response, got %s", contentType)
+	}
+	var rows []map[string]any
+	decoder := json.NewDecoder(rr.Body)
+	for decoder.More() {
+		var row map[string]any
+		if err := decoder.Decode(&row); err != nil {
+			t.Fatalf("Excpected a JSON row, got %v", err)
+		}
+		rows = append(rows, row)
+	}
+	if !rr.Flushed {
+		t.Error("Excpected the stream to be flushed")
+	}
+	return rr.Code, rows
+}
+
+func TestStalledStreamFreesItsSlot(t *testing.T) {
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(2000, answers)
+	text := strings.Repeat("a", 16 << 10)
+	for range 2000 {
+		questions.CreateQuestion(context.Background(), &models.Question{Text: text, TextHTML: text})
+	}
+	svc := service.NewService(slog.Default(), questions, answers, mock.NewMockStorageUsers(questions), mock.NewMockStorageComments(questions), config.ReputationConfig{}, config.DuplicatesConfig{}, nil)
+	cfg := serverConfig()
+	cfg.Streams = config.StreamsConfig{MaxConcurrent: 1, WriteTimeout: 100 * time.Millisecond, MaxDuration: time.Minute}
+	s := checked(serv.NewServer(context.Background(), slog.Default(), cfg, svc, serv.Deps{Events: events.NewHub(16, 16)}))
+	ts := httptest.NewServer(s.GetHandler())
+	defer ts.Close()
+
+	// The client sends the request and never reads the 64 MB of rows.
+	conn, err := net.Dial("tcp", ts.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	fmt.Fprint(conn, "GET /v1/questions HTTP/1.1\r\nHost: test\r\nAccept: application/x-ndjson\r\n\r\n")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		req, _ := http.NewRequest("GET", ts.URL + "/v1/questions", nil)
+		req.Header.Set("Accept", "application/x-ndjson")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Excpected the stalled stream to time out and free its slot, got %d", resp.StatusCode)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestStreamQuestionsAndAnswers(t *testing.T) {
+	s := createV1Server()
+	userID := "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	for _, text := range []string{"What is Go?", "What is a goroutine?", "What is a channel?"} {
+		if status, _ := serveJSON(t, s, "POST", "/v1/questions", `{"text": "` + text + `"}`); status != http.StatusCreated {
+			t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+		}
+	}
+	serveJSON(t, s, "POST", "/v1/questions/1/answers", `{"user_id": "` + userID + `", "texts": ["A language", "A game"]}`)
+
+	status, rows := serveNDJSON(t, s, "/v1/questions?format=markdown")
+	if status != http.StatusOK || len(rows) != 3 {
+		t.Fatalf("Excpected 3 questions, got %v %v", status, rows)
+	}
+	for _, row := range rows {
+		assertV1Contract(t, row, "")
+		if _, ok := row["text_html"]; ok {
+			t.Errorf("Excpected the Markdown only, got %v", row)
+		}
+	}
+
+	status, rows = serveNDJSON(t, s, "/v1/questions/1/answers")
+	if status != http.StatusOK || len(rows) != 2 || rows[0]["question_id"] != float64(1) || rows[0]["author"] == nil {
+		t.Fatalf("Excpected the 2 answers with their author, got %v %v", status, rows)
+	}
+	assertV1Contract(t, rows, "")
+
+	// The unversioned routes keep the models format.
+	status, rows = serveNDJSON(t, s, "/questions/1/answers")
+	if status != http.StatusOK || len(rows) != 2 || rows[1]["QuestionID"] != float64(1) {
+		t.Errorf("Excpected the legacy format, got %v %v", status, rows)
+	}
+
+	status, rows = serveNDJSON(t, s, "/v1/questions/2/answers")
+	if status != http.StatusOK || len(rows) != 0 {
+		t.Errorf("Excpected an empty stream, got %v %v", status, rows)
+	}
+	if status, _ = serveNDJSON(t, s, "/v1/questions/404/answers"); status != http.StatusNotFound {
+		t.Errorf("Excpected 404 for a missing question, got %v", status)
+	}
+	if status, _ = serveNDJSON(t, s, "/v1/questions?sort=votes"); status != http.StatusBadRequest {
+		t.Errorf("Excpected 400 for an unknown sort, got %v", status)
+	}
+
+	// Without the Accept header the answers are a JSON list.
+	status, res := serveJSON(t, s, "GET", "/v1/questions/1/answers", "")
+	if status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	assertV1Contract(t, res, "")
+	if answers, _ := res["answers"].([]any); len(answers) != 2 {
+		t.Errorf("Excpected 2 answers, got %v", res)
+	}
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+type Jobs interface {
+	Jobs() []models.JobStatus
+}
+
+func(s *Server) GetJobs(writer http.ResponseWriter, request *http.Request) {
+	s.log.Info("Recive request for jobs")
+	bytes := prepareResponse(models.GetJobsResponse{Jobs: s.jobs.Jobs()}, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+// adminOnly serves the request when it carries the admin token.
+func(s *Server) adminOnly(handler http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		token := []byte("Bearer " + s.adminToken)
+		if subtle.ConstantTimeCompare([]byte(request.Header.Get("Authorization")), token) != 1 {
+			writer.Header().Set("WWW-Authenticate", "Bearer")
+			writer.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(writer, "AdminTokenRequired")
+			return
+		}
+		handler(writer, request)
+	}
+}
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const ndjsonType = "application/x-ndjson"
+
+// A stream is flushed every ndjsonFlushRows rows, and with the next row
+// once ndjsonFlushInterval passed since the last flush.
+const (
+	ndjsonFlushRows = 100
+	ndjsonFlushInterval = time.Second
+)
+
+// The limits of the streams when the config leaves them unset.
+const (
+	defaultMaxStreams = 8
+	defaultStreamWriteTimeout = 30 * time.Second
+	defaultStreamMaxDuration = 10 * time.Minute
+)
+
+func orDefault[T int | time.Duration](value, fallback T) T {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// wantsNDJSON reports whether the Accept header of the request lists
+// newline-delimited JSON.
+func wantsNDJSON(request *http.Request) bool {
+	for _, accept := range request.Header.Values("Accept") {
+		for _, media := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(media))
+			if err == nil && mediaType == ndjsonType && params["q"] != "0" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ndjsonStream writes rows as newline-delimited JSON. The status and the
+// headers are written with the first row, so an error before it is
+// answered as usual.
+type ndjsonStream struct {
+	log *slog.Logger
+	writer http.ResponseWriter
+	controller *http.ResponseController
+	encoder *json.Encoder
+	writeTimeout time.Duration
+	deadline time.Time
+	cancel context.CancelFunc
+	rows int
+	flushed time.Time
+	unbounded bool
+}
+
+// newStream takes one of the stream slots of the server, or answers 503
+// when they are all taken. The rows must be read with the returned
+// context, which ends after the max duration of the streams, and the
+// stream finished with endStream, which frees the slot.
+func(s *Server) newStream(writer http.ResponseWriter, request *http.Request) (*ndjsonStream, context.Context, bool) {
+	select {
+	case s.streamSlots <- struct{}{}:
+	default:
+		writer.Header().Set("Retry-After", "1")
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(writer, "TooManyStreams")
+		return nil, nil, false
+	}
+
+	deadline := time.Now().Add(s.streamMaxDuration)
+	ctx, cancel := context.WithDeadline(request.Context(), deadline)
+	return &ndjsonStream{
+		log: s.log,
+		writer: writer,
+		controller: http.NewResponseController(writer),
+		encoder: json.NewEncoder(writer),
+		writeTimeout: s.streamWriteTimeout,
+		deadline: deadline,
+		cancel: cancel,
+	}, ctx, true
+}
+
+// extend gives the client writeTimeout more to take the next row, but not
+// past the deadline of the stream. The server has no WriteTimeout, which
+// would cut a long stream however fast it is read. A writer that does not
+// unwrap to the connection cannot take a deadline; that is logged once, as
+// a client that stops reading then holds the stream until TCP gives up.
+func(s *ndjsonStream) extend() error {
+	deadline := time.Now().Add(s.writeTimeout)
+	if deadline.After(s.deadline) {
+		deadline = s.deadline
+	}
+	err := s.controller.SetWriteDeadline(deadline)
+	if errors.Is(err, http.ErrNotSupported) {
+		if !s.unbounded {
+			s.unbounded = true
+			s.log.Error(
+				"StreamDeadlineUnsupported",
+				slog.String("component", "http/ndjson"),
+				slog.String("writer", fmt.Sprintf("%T", s.writer)),
+			)
+		}
+		return nil
+	}
+	return err
+}
+
+func(s *ndjsonStream) write(row any) error {
+	if err := s.extend(); err != nil {
+		return err
+	}
+	if s.rows == 0 {
+		s.start()
+	}
+	if err := s.encoder.Encode(row); err != nil {
+		return err
+	}
+	s.rows++
+	if s.rows % ndjsonFlushRows == 0 || time.Since(s.flushed) >= ndjsonFlushInterval {
+		return s.flush()
+	}
+	return nil
+}
+
+func(s *ndjsonStream) start() {
+	header := s.writer.Header()
+	header.Set("Content-Type", ndjsonType)
+	header.Set("X-Accel-Buffering", "no")
+	s.writer.WriteHeader(http.StatusOK)
+	s.flushed = time.Now()
+}
+
+func(s *ndjsonStream) flush() error {
+	s.flushed = time.Now()
+	return s.controller.Flush()
+}
+
+// endStream finishes the stream after its last row and frees its slot. An
+// error before the first row is written as the response; after it the
+// response can only be cut short, so the client does not take a partial
+// stream for a whole one.
+func(s *Server) endStream(writer http.ResponseWriter, request *http.Request, stream *ndjsonStream, err error) {
+	defer func() { <-s.streamSlots }()
+	defer stream.cancel()
+	if err == nil {
+		if stream.rows == 0 {
+			stream.start()
+		}
+		if stream.extend() == nil {
+			stream.flush()
+		}
+		// The connection may serve other requests, which have no deadline.
+		stream.controller.SetWriteDeadline(time.Time{})
+		return
+	}
+	if request.Context().Err() != nil {
+		return
+	}
+	if stream.rows == 0 {
+		writeServiceError(writer, err)
+		return
+	}
+	s.log.Error(
+		"StreamingError",
+		slog.String("component", "http/ndjson"),
+		slog.Any("error", err),
+	)
+	panic(http.ErrAbortHandler)
+}
+
+// streams converts the rows of the NDJSON responses of the handler, each
+// a model T, to the DTO they map to. Other responses are left to
+// responds. The status is sent before the rows, so a row that does not
+// convert is logged and written as it is.
+func streams[T, R any](toDTO func(T) R) dto {
+	return func(handler http.HandlerFunc, log *slog.Logger) http.HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) {
+			handler(&lineWriter{ResponseWriter: writer, convert: func(line []byte) []byte {
+				var row T
+				err := json.Unmarshal(line, &row)
+				var converted []byte
+				if err == nil {
+					converted, err = json.Marshal(toDTO(row))
+				}
+				if err != nil {
+					log.Error(
+						"ConvertingResponseError",
+						slog.String("component", "http/dto"),
+						slog.String("path", request.URL.Path),
+						slog.Any("error", err),
+					)
+					return line
+				}
+				return converted
+			}}, request)
+		}
+	}
+}
+
+// lineWriter converts an NDJSON body line by line.
+type lineWriter struct {
+	http.ResponseWriter
+	convert func(line []byte) []byte
+	pending []byte
+}
+
+func(w *lineWriter) Write(data []byte) (int, error) {
+	if w.Header().Get("Content-Type") != ndjsonType {
+		return w.ResponseWriter.Write(data)
+	}
+	w.pending = append(w.pending, data...)
+	for {
+		end := bytes.IndexByte(w.pending, '\n')
+		if end < 0 {
+			break
+		}
+		line := append(w.convert(w.pending[:end]), '\n')
+		w.pending = w.pending[end + 1:]
+		if _, err := w.ResponseWriter.Write(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// Unwrap lets http.ResponseController reach the connection, to set the
+// write deadlines of the stream.
+func(w *lineWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func(w *lineWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,293 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/notifications"
+	"github.com/behummble/Questions-answers/internal/service"
+	"gorm.io/gorm"
+)
+
+type Notifications interface {
+	Notifications(ctx context.Context, userID string, unread bool, page models.Page) (models.GetNotificationsResponse, error)
+	MarkRead(ctx context.Context, userID string, id int64) (models.MarkNotificationsReadResponse, error)
+	Preferences(ctx context.Context, userID string) (models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userID string, data []byte) (models.NotificationPreferences, error)
+	Follow(ctx context.Context, questionID int, userID string) error
+	Unfollow(ctx context.Context, questionID int, userID string) error
+	FollowTag(ctx context.Context, tag, userID string) error
+	UnfollowTag(ctx context.Context, tag, userID string) error
+	FollowedTags(ctx context.Context, userID string) (models.FollowedTagsResponse, error)
+	VerifyEmail(ctx context.Context, token string) error
+}
+
+func(s *Server) GetNotifications(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for notifications of user with id: %s", userID))
+	page, err := getPage(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	var unread bool
+	if value := request.URL.Query().Get("unread"); value != "" {
+		unread, err = strconv.ParseBool(value)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(writer, "InvalidUnread")
+			return
+		}
+	}
+	res, err := s.notifications.Notifications(ctx, userID, unread, page)
+	if err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) MarkNotificationRead(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, "InvalidID")
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request to mark notification with id: %d as read", id))
+	s.markRead(ctx, writer, userID, id)
+}
+
+func(s *Server) MarkAllNotificationsRead(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request to mark notifications of user with id: %s as read", userID))
+	s.markRead(ctx, writer, userID, 0)
+}
+
+func(s *Server) GetNotificationPreferences(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for notification preferences of user with id: %s", userID))
+	res, err := s.notifications.Preferences(ctx, userID)
+	if err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+	res.Email = maskEmail(res.Email)
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) UpdateNotificationPreferences(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	data, err := executeRequestBody(request, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request to update notification preferences of user with id: %s", userID))
+	res, err := s.notifications.UpdatePreferences(ctx, userID, data)
+	if err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+	res.Email = maskEmail(res.Email)
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) FollowQuestion(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request to follow question with id: %d", id))
+	if err := s.notifications.Follow(ctx, id, userID); err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func(s *Server) UnfollowQuestion(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request to unfollow question with id: %d", id))
+	if err := s.notifications.Unfollow(ctx, id, userID); err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func(s *Server) FollowTag(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	tag := request.PathValue("tag")
+	s.log.Info(fmt.Sprintf("Recive request to follow tag: %s", tag))
+	if err := s.notifications.FollowTag(ctx, tag, userID); err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func(s *Server) UnfollowTag(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	tag := request.PathValue("tag")
+	s.log.Info(fmt.Sprintf("Recive request to unfollow tag: %s", tag))
+	if err := s.notifications.UnfollowTag(ctx, tag, userID); err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func(s *Server) GetFollowedTags(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	userID, ok := currentUser(writer, request)
+	if !ok {
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive request for followed tags of user with id: %s", userID))
+	res, err := s.notifications.FollowedTags(ctx, userID)
+	if err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+// VerifyNotificationEmail serves the link of the verification email, so it
+// takes the token instead of X-User-ID.
+func(s *Server) VerifyNotificationEmail(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	s.log.Info("Recive request to verify a notification email")
+	if err := s.notifications.VerifyEmail(ctx, request.URL.Query().Get("token")); err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func(s *Server) markRead(ctx context.Context, writer http.ResponseWriter, userID string, id int64) {
+	res, err := s.notifications.MarkRead(ctx, userID, id)
+	if err != nil {
+		writeNotificationError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+// currentUser reads the user of a /me request from X-User-ID and answers
+// 401 when the header is missing. The header is not authenticated: it must be
+// set by a gateway in front of the server, so nothing private is answered on it.
+func currentUser(writer http.ResponseWriter, request *http.Request) (string, bool) {
+	userID := request.Header.Get("X-User-ID")
+	if userID == "" {
+		writer.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(writer, "UserRequired")
+		return "", false
+	}
+
+	return userID, true
+}
+
+// maskEmail keeps the first letter and the domain of an address, since the
+// user of the preferences is only as trusted as X-User-ID.
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return email
+	}
+
+	return email[:1] + "***" + email[at:]
+}
+
+func writeNotificationError(writer http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		writer.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, notifications.ErrInvalidUser), errors.Is(err, notifications.ErrInvalidPreferences),
+		errors.Is(err, service.ErrInvalidTags):
+		writer.WriteHeader(http.StatusBadRequest)
+	default:
+		writer.WriteHeader(http.StatusInternalServerError)
+	}
+	fmt.Fprint(writer, err.Error())
+}
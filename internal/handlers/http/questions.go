@@ -3,10 +3,16 @@ package http
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 	"errors"
 	"gorm.io/gorm"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
 )
 
 func(s *Server) CreateQuestion(writer http.ResponseWriter, request *http.Request) {
@@ -27,10 +33,25 @@ func(s *Server) CreateQuestion(writer http.ResponseWriter, request *http.Request
 		return
 	}
 
-	res, err := s.service.NewQuestion(ctx, data)
+	var strict bool
+	if value := request.URL.Query().Get("strict"); value != "" {
+		strict, err = strconv.ParseBool(value)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(writer, "InvalidStrict")
+			return
+		}
+	}
+
+	res, err := s.service.NewQuestion(ctx, data, strict)
+	if errors.Is(err, service.ErrDuplicateQuestion) {
+		bytes := prepareResponse(res, s.log)
+		writer.WriteHeader(http.StatusConflict)
+		writer.Write(bytes)
+		return
+	}
 	if err != nil {
-		writer.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprint(writer, err.Error())
+		writeServiceError(writer, err)
 		return
 	}
 	bytes := prepareResponse(res, s.log)
@@ -41,18 +62,43 @@ func(s *Server) CreateQuestion(writer http.ResponseWriter, request *http.Request
 func(s *Server) GetAllQuestions(writer http.ResponseWriter, request *http.Request) {
 	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
 	defer cancel()
-	res, err := s.service.AllQuestions(ctx)
+	format, err := getFormat(request)
 	if err != nil {
-		writer.WriteHeader(http.StatusInternalServerError)
+		writer.WriteHeader(http.StatusBadRequest)
 		fmt.Fprint(writer, err.Error())
 		return
 	}
+	if wantsNDJSON(request) {
+		s.streamQuestions(writer, request, format)
+		return
+	}
+	res, err := s.service.AllQuestions(ctx, request.URL.Query().Get("sort"))
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
 	s.log.Info("Recive request to get all question")
+	formatQuestions(res.Questions, format)
 	bytes := prepareResponse(res, s.log)
 	writer.WriteHeader(http.StatusOK)
 	writer.Write(bytes)
 }
 
+// streamQuestions writes every question as NDJSON. The stream lasts as
+// long as the client reads it, up to the max duration of the streams.
+func(s *Server) streamQuestions(writer http.ResponseWriter, request *http.Request, format string) {
+	s.log.Info("Recive request to stream all questions")
+	stream, ctx, ok := s.newStream(writer, request)
+	if !ok {
+		return
+	}
+	err := s.service.StreamQuestions(ctx, request.URL.Query().Get("sort"), func(question models.Question) error {
+		formatText(&question.Text, &question.TextHTML, format)
+		return stream.write(question)
+	})
+	s.endStream(writer, request, stream, err)
+}
+
 func(s *Server) GetQuestion(writer http.ResponseWriter, request *http.Request) {
 	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
 	defer cancel()
@@ -63,6 +109,12 @@ func(s *Server) GetQuestion(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 	s.log.Info(fmt.Sprintf("Recive a request to get question with id: %d", id))
+	format, err := getFormat(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
 	res, err := s.service.Question(ctx, id)
 
 	if err != nil {
@@ -74,6 +126,27 @@ func(s *Server) GetQuestion(writer http.ResponseWriter, request *http.Request) {
 		fmt.Fprint(writer, err.Error())
 		return
 	}
+	// Readers of a duplicate go to the question it duplicates unless they
+	// ask for the duplicate itself.
+	if res.Question.DuplicateOf != nil && request.URL.Query().Get("redirect") != "false" {
+		// The target is served by the same version as the request.
+		location := strings.TrimSuffix(request.URL.Path, request.PathValue("id")) + strconv.Itoa(*res.Question.DuplicateOf)
+		if request.URL.RawQuery != "" {
+			location += "?" + request.URL.RawQuery
+		}
+		// Not permanent: the question can be unmarked, and a cached 301
+		// would keep sending readers away from it.
+		http.Redirect(writer, request, location, http.StatusFound)
+		return
+	}
+	// Views are not part of the version, so a revalidated question is still
+	// counted and its ETag does not change with every view.
+	s.recordView(request, id)
+	if notModified(writer, request, questionETag(res)) {
+		return
+	}
+	formatText(&res.Question.Text, &res.Question.TextHTML, format)
+	formatAnswers(res.Answers, format)
 	bytes := prepareResponse(res, s.log)
 	writer.WriteHeader(http.StatusOK)
 	writer.Write(bytes)
@@ -89,10 +162,16 @@ func(s *Server) DeleteQuestion(writer http.ResponseWriter, request *http.Request
 		return
 	}
 	s.log.Info(fmt.Sprintf("Recive a request to delete question with id: %d", id))
-	err = s.service.DeleteQuestion(ctx, id)
+	version, ok := s.matchQuestion(ctx, writer, request, id)
+	if !ok {
+		return
+	}
+	err = s.service.DeleteQuestion(ctx, id, version)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			writer.WriteHeader(http.StatusNotFound)
+		} else if errors.Is(err, service.ErrVersionMismatch) {
+			writer.WriteHeader(http.StatusPreconditionFailed)
 		} else {
 			writer.WriteHeader(http.StatusInternalServerError)
 		}
@@ -102,3 +181,153 @@ func(s *Server) DeleteQuestion(writer http.ResponseWriter, request *http.Request
 	
 	writer.WriteHeader(http.StatusNoContent)
 }
+
+func(s *Server) MarkDuplicate(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	target, err := getTarget(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive a request to mark question with id: %d as duplicate of %d", id, target))
+	data, err := executeRequestBody(request, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	if len(data) == 0 {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, "Empty body")
+		return
+	}
+
+	version, ok := s.matchQuestion(ctx, writer, request, id)
+	if !ok {
+		return
+	}
+
+	res, err := s.service.MarkDuplicate(ctx, id, target, version, data)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) MergeQuestions(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	target, err := getTarget(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive a request to merge question with id: %d into %d", id, target))
+	data, err := executeRequestBody(request, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	if len(data) == 0 {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, "Empty body")
+		return
+	}
+
+	version, ok := s.matchQuestion(ctx, writer, request, id)
+	if !ok {
+		return
+	}
+
+	res, err := s.service.MergeQuestions(ctx, id, target, version, data)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+// BatchQuestions serves POST /questions:batch, an admin endpoint. The body
+// is capped by the batch config; ?atomic=false applies the operations on
+// their own. An atomic batch that is not applied is answered 409 with the
+// results, where the failed operation has its status and the others 424.
+func(s *Server) BatchQuestions(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	s.log.Info("Recive request to write a batch of questions")
+
+	atomic := true
+	if value := request.URL.Query().Get("atomic"); value != "" {
+		var err error
+		atomic, err = strconv.ParseBool(value)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(writer, "InvalidAtomic")
+			return
+		}
+	}
+	request.Body = http.MaxBytesReader(writer, request.Body, s.batch.MaxBytes)
+	data, err := io.ReadAll(request.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writer.WriteHeader(http.StatusRequestEntityTooLarge)
+			fmt.Fprint(writer, service.ErrBatchTooLarge.Error())
+			return
+		}
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, "ReadingRequestBodyError")
+		return
+	}
+	if len(data) == 0 {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, "Empty body")
+		return
+	}
+
+	res, err := s.service.BatchQuestions(ctx, data, atomic, s.batch.MaxOperations)
+	if err != nil && !errors.Is(err, service.ErrBatchNotApplied) {
+		writeServiceError(writer, err)
+		return
+	}
+	for i := range res.Results {
+		result := &res.Results[i]
+		switch {
+		case result.Err != nil:
+			result.Status = serviceStatus(result.Err)
+			result.Error = result.Err.Error()
+		case result.Op == models.BatchCreate:
+			result.Status = http.StatusCreated
+		default:
+			result.Status = http.StatusNoContent
+		}
+	}
+	bytes := prepareResponse(res, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusConflict)
+	} else {
+		writer.WriteHeader(http.StatusOK)
+	}
+	writer.Write(bytes)
+}
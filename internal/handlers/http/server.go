@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"expvar"
 	"log/slog"
 	"net/http"
 	"fmt"
@@ -9,35 +10,111 @@ import (
 	"errors"
 	"io"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/behummble/Questions-answers/internal/config"
 	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+	"github.com/gorilla/websocket"
+	"gorm.io/gorm"
 )
 
 type Server struct {
 	log *slog.Logger
 	server *http.Server
 	service Service
+	events EventSource
+	webhooks Webhooks
+	badges Badges
+	views Views
+	attachments Attachments
+	notifications Notifications
+	jobs Jobs
+	graphql http.Handler
+	adminToken string
+	legacyRoutes config.LegacyRoutesConfig
+	batch config.BatchConfig
+	streamSlots chan struct{}
+	streamWriteTimeout time.Duration
+	streamMaxDuration time.Duration
+	trustProxy bool
+	upgrader websocket.Upgrader
+	done chan struct{}
+	streams sync.WaitGroup
 }
 
 type Service interface {
-	NewQuestion(ctx context.Context, data []byte) (models.CreateQuestionResponse, error)
+	NewQuestion(ctx context.Context, data []byte, strict bool) (models.CreateQuestionResponse, error)
 	Question(ctx context.Context, id int) (models.GetQuestionResponse, error)
-	AllQuestions(ctx context.Context) (models.GetQuestionsResponse, error)
-	DeleteQuestion(ctx context.Context, id int) (error)
+	AllQuestions(ctx context.Context, sort string) (models.GetQuestionsResponse, error)
+	StreamQuestions(ctx context.Context, sort string, yield func(models.Question) error) error
+	DeleteQuestion(ctx context.Context, id, version int) (error)
+	BatchQuestions(ctx context.Context, data []byte, atomic bool, limit int) (models.BatchQuestionsResponse, error)
+	MarkDuplicate(ctx context.Context, id, target, version int, data []byte) (models.MarkDuplicateResponse, error)
+	MergeQuestions(ctx context.Context, id, target, version int, data []byte) (models.MergeQuestionResponse, error)
 	NewAnswer(ctx context.Context, answer []byte, questionID int) (models.CreateAnswerResponse, error)
 	Answer(ctx context.Context, id int) (models.GetAnswerResponse, error) 
-	DeleteAnswer(ctx context.Context, id int) (error)
+	Answers(ctx context.Context, questionID int) (models.GetAnswersResponse, error)
+	StreamAnswers(ctx context.Context, questionID int, yield func(models.Answer) error) error
+	DeleteAnswer(ctx context.Context, id, version int) (error)
+	User(ctx context.Context, id string) (models.GetUserResponse, error)
+	UpdateUser(ctx context.Context, id string, data []byte) (models.GetUserResponse, error)
+	UserAnswers(ctx context.Context, id string, page models.Page) (models.GetUserAnswersResponse, error)
+	UserQuestions(ctx context.Context, id string, page models.Page) (models.GetUserQuestionsResponse, error)
+	VoteAnswer(ctx context.Context, id int, data []byte) (models.VoteResponse, error)
+	AcceptAnswer(ctx context.Context, id int, userID string) (models.GetAnswerResponse, error)
+	PatchAnswer(ctx context.Context, id, version int, data []byte) (models.GetAnswerResponse, error)
+	Leaderboard(ctx context.Context, page models.Page) (models.GetLeaderboardResponse, error)
+	Reputation(ctx context.Context, id string, page models.Page) (models.GetReputationResponse, error)
+	CommentQuestion(ctx context.Context, id int, data []byte) (models.CreateCommentResponse, error)
+	CommentAnswer(ctx context.Context, id int, data []byte) (models.CreateCommentResponse, error)
+	QuestionComments(ctx context.Context, id int, page models.Page) (models.GetCommentsResponse, error)
+	AnswerComments(ctx context.Context, id int, page models.Page) (models.GetCommentsResponse, error)
+	DeleteComment(ctx context.Context, id int, userID string) error
 }
 
-func NewServer(ctx context.Context, log *slog.Logger, cfg *config.ServerConfig, service Service) *Server {
+// Deps are the optional subsystems of the server. The routes of a nil one
+// are not served.
+type Deps struct {
+	Events EventSource
+	Webhooks Webhooks
+	Badges Badges
+	Views Views
+	Attachments Attachments
+	Notifications Notifications
+	Jobs Jobs
+	GraphQL http.Handler
+}
+
+func NewServer(ctx context.Context, log *slog.Logger, cfg *config.ServerConfig, service Service, deps Deps) *Server {
 	server := &Server{
 		log: log,
 		service: service,
+		events: deps.Events,
+		webhooks: deps.Webhooks,
+		badges: deps.Badges,
+		views: deps.Views,
+		attachments: deps.Attachments,
+		notifications: deps.Notifications,
+		jobs: deps.Jobs,
+		graphql: deps.GraphQL,
+		adminToken: cfg.AdminToken,
+		legacyRoutes: cfg.LegacyRoutes,
+		batch: cfg.Batch,
+		streamSlots: make(chan struct{}, orDefault(cfg.Streams.MaxConcurrent, defaultMaxStreams)),
+		streamWriteTimeout: orDefault(cfg.Streams.WriteTimeout, defaultStreamWriteTimeout),
+		streamMaxDuration: orDefault(cfg.Streams.MaxDuration, defaultStreamMaxDuration),
+		trustProxy: cfg.TrustProxy,
+		upgrader: newUpgrader(cfg.AllowedOrigins),
+		done: make(chan struct{}),
 	}
 	srv := &http.Server{
 		Addr: fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
 	}
+	// Shutdown waits for active requests, so long-lived streams must be
+	// told to finish first.
+	srv.RegisterOnShutdown(func() { close(server.done) })
 	mux := newMux(server)
 	srv.Handler = mux
 	server.server = srv
@@ -52,8 +129,22 @@ func(s *Server) Start() {
     }
 }
 
+// Shutdown stops the HTTP server and waits for hijacked WebSocket
+// connections, which http.Server does not track, to drain.
 func(s *Server) Shutdown(ctx context.Context) error {
-	return s.server.Shutdown(ctx)
+	err := s.server.Shutdown(ctx)
+	drained := make(chan struct{})
+	go func() {
+		s.streams.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return err
 }
 
 func(s *Server) GetHandler() http.Handler {
@@ -62,14 +153,24 @@ func(s *Server) GetHandler() http.Handler {
 
 func newMux(s *Server) *http.ServeMux {
 	mux := http.NewServeMux()
-	mux.HandleFunc("POST /questions", s.CreateQuestion)
-	mux.HandleFunc("GET /questions", s.GetAllQuestions)
-	mux.HandleFunc("GET /questions/{id}", s.GetQuestion)
-	mux.HandleFunc("DELETE /questions/{id}", s.DeleteQuestion)
-
-	mux.HandleFunc("POST /questions/{id}/answers", s.CreateAnswer)
-	mux.HandleFunc("GET /answers/{id}", s.GetAnswer)
-	mux.HandleFunc("DELETE /answers/{id}", s.DeleteAnswer)
+	for _, version := range versions {
+		version.routes(s, router{mux: mux, log: s.log, prefix: version.prefix})
+	}
+	if !s.legacyRoutes.Disabled {
+		oldest := versions[0]
+		oldest.routes(s, router{mux: mux, log: s.log, legacy: true, middleware: deprecated(s.legacyRoutes, oldest.prefix)})
+	}
+
+	if s.graphql != nil {
+		mux.Handle("GET /graphql", s.graphql)
+		mux.Handle("POST /graphql", s.graphql)
+	}
+
+	// The counters tell about the deployment, so they are served, like the
+	// admin routes, only with the admin token.
+	if s.adminToken != "" {
+		mux.Handle("GET /debug/vars", s.adminOnly(expvar.Handler().ServeHTTP))
+	}
 	
 	return mux
 }
@@ -109,6 +210,33 @@ func prepareResponse[T any](m T, log *slog.Logger) []byte {
 	return res
 }
 
+// writeServiceError maps the errors of the service to status codes.
+func writeServiceError(writer http.ResponseWriter, err error) {
+	writer.WriteHeader(serviceStatus(err))
+	fmt.Fprint(writer, err.Error())
+}
+
+func serviceStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidUser), errors.Is(err, service.ErrInvalidVote), errors.Is(err, service.ErrInvalidSort),
+		errors.Is(err, service.ErrInvalidDuplicate), errors.Is(err, service.ErrInvalidMove), errors.Is(err, service.ErrInvalidBatch),
+		errors.Is(err, service.ErrInvalidTags), errors.Is(err, service.ErrInvalidComment):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrPrivilegeRequired):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, service.ErrBatchTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrBatchRolledBack):
+		return http.StatusFailedDependency
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func getID(r *http.Request) (int, error) {
 	idStr := r.PathValue("id")
 	if idStr == "" {
@@ -121,4 +249,35 @@ func getID(r *http.Request) (int, error) {
 	}
 
 	return id, nil
+}
+
+func getTarget(r *http.Request) (int, error) {
+	targetStr := r.PathValue("target")
+	if targetStr == "" {
+		return 0, errors.New("ParameterNotFound")
+	}
+
+	return strconv.Atoi(targetStr)
+}
+
+// getPage reads the optional limit and offset query parameters.
+func getPage(r *http.Request) (models.Page, error) {
+	var page models.Page
+	query := r.URL.Query()
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 0 {
+			return page, errors.New("InvalidLimit")
+		}
+		page.Limit = value
+	}
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return page, errors.New("InvalidOffset")
+		}
+		page.Offset = value
+	}
+
+	return page, nil
 }
\ No newline at end of file
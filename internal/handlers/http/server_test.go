@@ -2,9 +2,16 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 	"bytes"
+	"github.com/behummble/Questions-answers/internal/events"
 	"github.com/behummble/Questions-answers/internal/models"
 )
 
@@ -44,6 +51,75 @@ func TestPrepareResponse(t *testing.T) {
             res)
 	}
 }
+func TestRespondsFailsOnUnconvertibleResponse(t *testing.T) {
+	serve := func(status int, body string) *httptest.ResponseRecorder {
+		handler := responds(toGetAnswerResponseV1)(func(writer http.ResponseWriter, request *http.Request) {
+			writer.Header().Set("ETag", `"1"`)
+			writer.WriteHeader(status)
+			fmt.Fprint(writer, body)
+		}, slog.Default())
+		rr := httptest.NewRecorder()
+		handler(rr, httptest.NewRequest("GET", "/v1/answers/1", nil))
+		return rr
+	}
+
+	rr := serve(http.StatusOK, `{"Answer": {"ID": 1}}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"id":1`) {
+		t.Errorf("Excpected the converted answer, got %d %s", rr.Code, rr.Body.String())
+	}
+	rr = serve(http.StatusOK, `not json`)
+	if rr.Code != http.StatusInternalServerError || rr.Header().Get("ETag") != "" {
+		t.Errorf("Excpected 500 without an ETag for a body that does not convert, got %d %v", rr.Code, rr.Header())
+	}
+	rr = serve(http.StatusNotFound, "record not found")
+	if rr.Code != http.StatusNotFound || rr.Body.String() != "record not found" {
+		t.Errorf("Excpected the error as it is, got %d %s", rr.Code, rr.Body.String())
+	}
+	rr = serve(http.StatusFound, `<a href="/v1/answers/2">Found</a>.`)
+	if rr.Code != http.StatusFound {
+		t.Errorf("Excpected the redirect as it is, got %d", rr.Code)
+	}
+}
+
+func TestStreamsAreCapped(t *testing.T) {
+	s := &Server{log: slog.Default(), streamSlots: make(chan struct{}, 1), streamWriteTimeout: time.Second, streamMaxDuration: time.Minute}
+	request := httptest.NewRequest("GET", "/v1/questions", nil)
+	stream, _, ok := s.newStream(httptest.NewRecorder(), request)
+	if !ok {
+		t.Fatal("Excpected a slot for the first stream")
+	}
+
+	rr := httptest.NewRecorder()
+	if _, _, ok := s.newStream(rr, request); ok || rr.Code != http.StatusServiceUnavailable || rr.Header().Get("Retry-After") == "" {
+		t.Errorf("Excpected 503 with Retry-After for the second stream, got %d %v", rr.Code, rr.Header())
+	}
+
+	s.endStream(stream.writer, request, stream, nil)
+	if _, _, ok := s.newStream(httptest.NewRecorder(), request); !ok {
+		t.Error("Excpected the ended stream to free its slot")
+	}
+}
+
+func TestStreamsEndAfterMaxDuration(t *testing.T) {
+	s := &Server{log: slog.Default(), streamSlots: make(chan struct{}, 1), streamWriteTimeout: time.Minute, streamMaxDuration: 10 * time.Millisecond}
+	request := httptest.NewRequest("GET", "/v1/questions", nil)
+	stream, ctx, ok := s.newStream(httptest.NewRecorder(), request)
+	if !ok {
+		t.Fatal("Excpected a slot for the stream")
+	}
+	if err := stream.write(map[string]int{"id": 1}); err != nil {
+		t.Fatal(err)
+	}
+	<-ctx.Done()
+
+	defer func() {
+		if recover() != http.ErrAbortHandler {
+			t.Error("Excpected a stream past its max duration to be cut")
+		}
+	}()
+	s.endStream(stream.writer, request, stream, ctx.Err())
+}
+
 func TestGetIDWithoutID(t *testing.T) {
 	req, err := http.NewRequest("POST", "/questions", bytes.NewReader([]byte("test")))
 	if err != nil {
@@ -54,4 +130,86 @@ func TestGetIDWithoutID(t *testing.T) {
 		t.Errorf("Excpect empty path value: got %s want empty",
             idStr)
 	}
-}
\ No newline at end of file
+}
+func TestHandleFeedMessage(t *testing.T) {
+	feed := newFeed()
+
+	res := handleFeedMessage(feed, wsMessage{Type: wsMessageSubscribe, Payload: []byte(`{"question_ids":[3,1]}`)})
+	if res.Type != wsMessageSubscribed || string(res.Payload) != `{"question_ids":[1,3]}` {
+		t.Errorf("Excpected subscribed to [1,3]: got %s %s", res.Type, res.Payload)
+	}
+
+	res = handleFeedMessage(feed, wsMessage{Type: wsMessageUnsubscribe, Payload: []byte(`{"question_ids":[1]}`)})
+	if string(res.Payload) != `{"question_ids":[3]}` {
+		t.Errorf("Excpected subscribed to [3]: got %s", res.Payload)
+	}
+
+	res = handleFeedMessage(feed, wsMessage{Type: wsMessageSubscribe, Payload: []byte(`{"tags":["Go","sql"]}`)})
+	if res.Type != wsMessageSubscribed || string(res.Payload) != `{"question_ids":[3],"tags":["go","sql"]}` {
+		t.Errorf("Excpected subscribed to tags [go,sql]: got %s %s", res.Type, res.Payload)
+	}
+
+	res = handleFeedMessage(feed, wsMessage{Type: wsMessageSubscribe, Payload: []byte(`{"tags":["no spaces"]}`)})
+	if res.Type != wsMessageError {
+		t.Errorf("Excpected error for invalid tag: got %s", res.Type)
+	}
+}
+
+func TestFeedMatchesSubscribedTags(t *testing.T) {
+	feed := newFeed()
+	golang := events.NewQuestionCreated(models.Question{ID: 1, Tags: []string{"go"}})
+	untagged := events.NewQuestionCreated(models.Question{ID: 2})
+	if !feed.match(golang) || !feed.match(untagged) {
+		t.Error("Excpected every new question without tag subscriptions")
+	}
+
+	handleFeedMessage(feed, wsMessage{Type: wsMessageSubscribe, Payload: []byte(`{"tags":["go"]}`)})
+	if !feed.match(golang) {
+		t.Error("Excpected new question with subscribed tag")
+	}
+	if feed.match(untagged) {
+		t.Error("Excpected no new question without subscribed tag")
+	}
+	if feed.match(events.NewAnswerCreated(models.Answer{ID: 3, QuestionID: 1})) {
+		t.Error("Excpected no answers of unsubscribed question")
+	}
+}
+
+func TestFeedUpdateNormalizesTags(t *testing.T) {
+	feed := newFeed()
+	if _, err := feed.update(wsMessageSubscribe, wsSubscription{QuestionIDs: []int{1}, Tags: []string{"no spaces"}}); err == nil {
+		t.Error("Excpected an error for an invalid tag")
+	}
+	if len(feed.questionIDs) != 0 || len(feed.tags) != 0 {
+		t.Errorf("Excpected no subscription after an invalid tag: got %v %v", feed.questionIDs, feed.tags)
+	}
+
+	res, err := feed.update(wsMessageSubscribe, wsSubscription{Tags: []string{" GO "}})
+	if err != nil || !slices.Equal(res.Tags, []string{"go"}) {
+		t.Errorf("Excpected subscribed to tag go: got %v %v", res.Tags, err)
+	}
+	if !feed.match(events.NewQuestionCreated(models.Question{ID: 1, Tags: []string{"go"}})) {
+		t.Error("Excpected new question tagged go")
+	}
+}
+
+func TestETagsCoverAuthorProfile(t *testing.T) {
+	answer := models.Answer{ID: 1, Version: 1, Author: &models.Author{ID: "user", UpdatedAt: time.Unix(1, 0)}}
+	question := models.GetQuestionResponse{Question: models.Question{ID: 1, Version: 1}, Answers: []models.Answer{answer}}
+	etag, questionTag := answerETag(answer), questionETag(question)
+
+	answer.Author = &models.Author{ID: "user", DisplayName: "Ann", UpdatedAt: time.Unix(2, 0)}
+	question.Answers = []models.Answer{answer}
+	if answerETag(answer) == etag || questionETag(question) == questionTag {
+		t.Error("Excpected the ETags to change with the profile of the author")
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	cases := map[string]string{"ann@example.com": "a***@example.com", "": "", "nobody": "nobody"}
+	for email, expected := range cases {
+		if res := maskEmail(email); res != expected {
+			t.Errorf("Excpected masked email of %q: got %q want %q", email, res, expected)
+		}
+	}
+}
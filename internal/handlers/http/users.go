@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func(s *Server) GetUser(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id := request.PathValue("id")
+	s.log.Info(fmt.Sprintf("Recive request for get user with id: %s", id))
+	res, err := s.service.User(ctx, id)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) UpdateUser(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	data, err := executeRequestBody(request, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	id := request.PathValue("id")
+	s.log.Info(fmt.Sprintf("Recive request for update user with id: %s", id))
+	res, err := s.service.UpdateUser(ctx, id, data)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) GetUserAnswers(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	page, err := getPage(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	id := request.PathValue("id")
+	s.log.Info(fmt.Sprintf("Recive request for get answers of user with id: %s", id))
+	res, err := s.service.UserAnswers(ctx, id, page)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) GetUserQuestions(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	page, err := getPage(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	id := request.PathValue("id")
+	s.log.Info(fmt.Sprintf("Recive request for get questions of user with id: %s", id))
+	res, err := s.service.UserQuestions(ctx, id, page)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) GetLeaderboard(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	page, err := getPage(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info("Recive request for get leaderboard")
+	res, err := s.service.Leaderboard(ctx, page)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) GetReputation(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	page, err := getPage(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	id := request.PathValue("id")
+	s.log.Info(fmt.Sprintf("Recive request for get reputation of user with id: %s", id))
+	res, err := s.service.Reputation(ctx, id, page)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
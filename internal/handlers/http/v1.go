@@ -0,0 +1,90 @@
+package http
+
+// routesV1 registers the /v1 API. Its requests and responses are the DTOs
+// in the dto_*.go files: snake_case fields and RFC 3339 timestamps. The
+// events of /ws and /questions/{id}/events are not converted; the NDJSON
+// rows are converted one by one by streams.
+func(s *Server) routesV1(r router) {
+	r.HandleFunc("POST /questions", s.CreateQuestion, accepts(fromCreateQuestionRequestV1), responds(toCreateQuestionResponseV1))
+	r.HandleFunc("GET /questions", s.GetAllQuestions, responds(toGetQuestionsResponseV1), streams(toQuestionV1))
+	r.HandleFunc("GET /questions/{id}", s.GetQuestion, responds(toGetQuestionResponseV1))
+	if s.views != nil {
+		r.HandleFunc("GET /questions/trending", s.GetTrendingQuestions, responds(toGetQuestionsResponseV1))
+	}
+	r.HandleFunc("DELETE /questions/{id}", s.DeleteQuestion)
+	r.HandleFunc("GET /questions/{id}/events", s.QuestionEvents)
+
+	r.HandleFunc("POST /questions/{id}/answers", s.CreateAnswer, accepts(fromCreateAnswerRequestV1), responds(toCreateAnswerResponseV1))
+	r.HandleFunc("GET /questions/{id}/answers", s.GetQuestionAnswers, responds(toGetAnswersResponseV1), streams(toAnswerV1))
+	r.HandleFunc("GET /answers/{id}", s.GetAnswer, responds(toGetAnswerResponseV1))
+	r.HandleFunc("DELETE /answers/{id}", s.DeleteAnswer)
+	r.HandleFunc("PUT /answers/{id}/vote", s.VoteAnswer, accepts(fromVoteRequestV1), responds(toVoteResponseV1))
+	r.HandleFunc("POST /answers/{id}/accept", s.AcceptAnswer, responds(toGetAnswerResponseV1))
+
+	r.HandleFunc("POST /questions/{id}/comments", s.CreateQuestionComment, accepts(fromCreateCommentRequestV1), responds(toCreateCommentResponseV1))
+	r.HandleFunc("GET /questions/{id}/comments", s.GetQuestionComments, responds(toGetCommentsResponseV1))
+	r.HandleFunc("POST /answers/{id}/comments", s.CreateAnswerComment, accepts(fromCreateCommentRequestV1), responds(toCreateCommentResponseV1))
+	r.HandleFunc("GET /answers/{id}/comments", s.GetAnswerComments, responds(toGetCommentsResponseV1))
+	r.HandleFunc("DELETE /comments/{id}", s.DeleteComment)
+
+	r.HandleFunc("GET /users/leaderboard", s.GetLeaderboard, responds(toGetLeaderboardResponseV1))
+	r.HandleFunc("GET /users/{id}", s.GetUser, responds(toGetUserResponseV1))
+	r.HandleFunc("PUT /users/{id}", s.UpdateUser, accepts(fromUpdateUserRequestV1), responds(toGetUserResponseV1))
+	r.HandleFunc("GET /users/{id}/answers", s.GetUserAnswers, responds(toGetUserAnswersResponseV1))
+	r.HandleFunc("GET /users/{id}/questions", s.GetUserQuestions, responds(toGetUserQuestionsResponseV1))
+	r.HandleFunc("GET /users/{id}/reputation", s.GetReputation, responds(toGetReputationResponseV1))
+
+	r.HandleFunc("GET /ws", s.LiveFeed)
+
+	// Webhooks read every event and make the server send requests to any
+	// URL, so they are served to the admin only.
+	if s.webhooks != nil && s.adminToken != "" {
+		r.HandleFunc("POST /webhooks", s.adminOnly(s.CreateWebhook), accepts(fromCreateWebhookRequestV1), responds(toCreateWebhookResponseV1))
+		r.HandleFunc("GET /webhooks", s.adminOnly(s.GetAllWebhooks), responds(toGetWebhooksResponseV1))
+		r.HandleFunc("GET /webhooks/{id}", s.adminOnly(s.GetWebhook), responds(toWebhookResponseV1))
+		r.HandleFunc("PUT /webhooks/{id}", s.adminOnly(s.UpdateWebhook), accepts(fromUpdateWebhookRequestV1), responds(toWebhookResponseV1))
+		r.HandleFunc("DELETE /webhooks/{id}", s.adminOnly(s.DeleteWebhook))
+		r.HandleFunc("GET /webhooks/{id}/deliveries", s.adminOnly(s.GetWebhookDeliveries), responds(toGetWebhookDeliveriesResponseV1))
+	}
+
+	if s.attachments != nil {
+		r.HandleFunc("POST /questions/{id}/attachments", s.CreateQuestionAttachment, responds(toCreateAttachmentResponseV1))
+		r.HandleFunc("GET /questions/{id}/attachments", s.GetQuestionAttachments, responds(toGetAttachmentsResponseV1))
+		r.HandleFunc("POST /answers/{id}/attachments", s.CreateAnswerAttachment, responds(toCreateAttachmentResponseV1))
+		r.HandleFunc("GET /answers/{id}/attachments", s.GetAnswerAttachments, responds(toGetAttachmentsResponseV1))
+		r.HandleFunc("GET /attachments/{id}", s.DownloadAttachment)
+	}
+
+	if s.notifications != nil {
+		r.HandleFunc("POST /questions/{id}/follow", s.FollowQuestion)
+		r.HandleFunc("DELETE /questions/{id}/follow", s.UnfollowQuestion)
+		r.HandleFunc("GET /me/notifications", s.GetNotifications, responds(toGetNotificationsResponseV1))
+		r.HandleFunc("POST /me/notifications/read", s.MarkAllNotificationsRead, responds(toMarkNotificationsReadResponseV1))
+		r.HandleFunc("POST /me/notifications/{id}/read", s.MarkNotificationRead, responds(toMarkNotificationsReadResponseV1))
+		r.HandleFunc("GET /me/notifications/preferences", s.GetNotificationPreferences, responds(toNotificationPreferencesV1))
+		r.HandleFunc("PUT /me/notifications/preferences", s.UpdateNotificationPreferences, accepts(fromUpdateNotificationPreferencesRequestV1), responds(toNotificationPreferencesV1))
+		r.HandleFunc("GET /notifications/verify", s.VerifyNotificationEmail)
+		r.HandleFunc("POST /tags/{tag}/follow", s.FollowTag)
+		r.HandleFunc("DELETE /tags/{tag}/follow", s.UnfollowTag)
+		r.HandleFunc("GET /me/tags", s.GetFollowedTags, responds(toFollowedTagsResponseV1))
+	}
+
+	if s.badges != nil {
+		r.HandleFunc("GET /badges", s.GetBadges, responds(toGetBadgesResponseV1))
+		r.HandleFunc("GET /users/{id}/badges", s.GetUserBadges, responds(toGetUserBadgesResponseV1))
+	}
+
+	// Duplicates redirect every reader, and merges and moves rewrite the
+	// answers of other users; the moderator in their body is not
+	// authenticated, so the admin token is.
+	if s.adminToken != "" {
+		r.HandleFunc("POST /questions/{id}/duplicate-of/{target}", s.adminOnly(s.MarkDuplicate), accepts(fromMarkDuplicateRequestV1), responds(toMarkDuplicateResponseV1))
+		r.HandleFunc("POST /questions/{id}/merge-into/{target}", s.adminOnly(s.MergeQuestions), accepts(fromMergeQuestionRequestV1), responds(toMergeQuestionResponseV1))
+		r.HandleFunc("PATCH /answers/{id}", s.adminOnly(s.PatchAnswer), accepts(fromPatchAnswerRequestV1), responds(toGetAnswerResponseV1))
+		r.HandleFunc("POST /questions:batch", s.adminOnly(s.BatchQuestions), limited(s.batch.MaxBytes), accepts(fromBatchQuestionsRequestV1), responds(toBatchQuestionsResponseV1))
+	}
+
+	if s.jobs != nil && s.adminToken != "" {
+		r.HandleFunc("GET /admin/jobs", s.adminOnly(s.GetJobs), responds(toGetJobsResponseV1))
+	}
+}
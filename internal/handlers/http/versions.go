@@ -0,0 +1,79 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/behummble/Questions-answers/internal/config"
+)
+
+// apiVersion is a set of routes served under a prefix. A version has its
+// own DTOs but calls the same handlers and Service, so /v2 can change the
+// wire format while /v1 keeps its own.
+type apiVersion struct {
+	prefix string
+	routes func(s *Server, r router)
+}
+
+// versions are the served API versions, oldest first. The routes of the
+// oldest are also served at the root, where they were before versioning,
+// without its DTOs: they keep the models types as their format.
+var versions = []apiVersion{
+	{prefix: "/v1", routes: (*Server).routesV1},
+}
+
+// router registers the handlers of a version under its prefix.
+type router struct {
+	mux *http.ServeMux
+	log *slog.Logger
+	prefix string
+	legacy bool
+	middleware func(http.Handler) http.Handler
+}
+
+// HandleFunc registers the handler behind the DTOs of the version, which
+// are applied in order, the request DTO first.
+func(r router) HandleFunc(pattern string, handler http.HandlerFunc, dtos ...dto) {
+	if !r.legacy {
+		for i := len(dtos) - 1; i >= 0; i-- {
+			handler = dtos[i](handler, r.log)
+		}
+	}
+	r.Handle(pattern, handler)
+}
+
+func(r router) Handle(pattern string, handler http.Handler) {
+	method, path, _ := strings.Cut(pattern, " ")
+	if r.middleware != nil {
+		handler = r.middleware(handler)
+	}
+	r.mux.Handle(method + " " + r.prefix + path, handler)
+}
+
+// deprecated marks the responses of the unversioned aliases with the
+// Deprecation (RFC 9745) and Sunset (RFC 8594) headers and links to the
+// same route under successor.
+func deprecated(cfg config.LegacyRoutesConfig, successor string) func(http.Handler) http.Handler {
+	deprecation := "true"
+	if !cfg.Deprecated.IsZero() {
+		deprecation = "@" + strconv.FormatInt(cfg.Deprecated.Unix(), 10)
+	}
+	var sunset string
+	if !cfg.Sunset.IsZero() {
+		sunset = cfg.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			header := writer.Header()
+			header.Set("Deprecation", deprecation)
+			if sunset != "" {
+				header.Set("Sunset", sunset)
+			}
+			header.Add("Link", "<" + successor + request.URL.EscapedPath() + `>; rel="successor-version"`)
+			next.ServeHTTP(writer, request)
+		})
+	}
+}
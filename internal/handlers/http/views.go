@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+type Views interface {
+	Record(questionID int, viewer string)
+	Trending(ctx context.Context, page models.Page) (models.GetQuestionsResponse, error)
+}
+
+func(s *Server) GetTrendingQuestions(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	s.log.Info("Recive request to get trending questions")
+	page, err := getPage(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	format, err := getFormat(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	res, err := s.views.Trending(ctx, page)
+	if err != nil {
+		writeServiceError(writer, err)
+		return
+	}
+	formatQuestions(res.Questions, format)
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) recordView(request *http.Request, questionID int) {
+	if s.views == nil {
+		return
+	}
+	s.views.Record(questionID, s.viewer(request))
+}
+
+// viewer identifies who views a question by the client address, the last
+// X-Forwarded-For hop behind a trusted proxy: the proxy appends the address
+// it was connected from to whatever the client sent. X-User-ID is not used,
+// any client can send a new one with every request.
+func(s *Server) viewer(request *http.Request) string {
+	if forwarded := request.Header.Values("X-Forwarded-For"); s.trustProxy && len(forwarded) != 0 {
+		hops := strings.Split(forwarded[len(forwarded) - 1], ",")
+		if client := strings.TrimSpace(hops[len(hops) - 1]); client != "" {
+			return "ip:" + client
+		}
+	}
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		host = request.RemoteAddr
+	}
+
+	return "ip:" + host
+}
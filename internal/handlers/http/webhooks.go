@@ -0,0 +1,153 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/webhooks"
+	"gorm.io/gorm"
+)
+
+type Webhooks interface {
+	CreateWebhook(ctx context.Context, data []byte) (models.CreateWebhookResponse, error)
+	Webhook(ctx context.Context, id int) (models.Webhook, error)
+	AllWebhooks(ctx context.Context) (models.GetWebhooksResponse, error)
+	UpdateWebhook(ctx context.Context, id int, data []byte) (models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int) error
+	Deliveries(ctx context.Context, id int) (models.GetWebhookDeliveriesResponse, error)
+}
+
+func(s *Server) CreateWebhook(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	data, err := executeRequestBody(request, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info("Recive request to create webhook")
+
+	res, err := s.webhooks.CreateWebhook(ctx, data)
+	if err != nil {
+		writeWebhookError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusCreated)
+	writer.Write(bytes)
+}
+
+func(s *Server) GetAllWebhooks(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	s.log.Info("Recive request to get all webhooks")
+	res, err := s.webhooks.AllWebhooks(ctx)
+	if err != nil {
+		writeWebhookError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) GetWebhook(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive a request to get webhook with id: %d", id))
+	res, err := s.webhooks.Webhook(ctx, id)
+	if err != nil {
+		writeWebhookError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) UpdateWebhook(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	data, err := executeRequestBody(request, s.log)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive a request to update webhook with id: %d", id))
+	res, err := s.webhooks.UpdateWebhook(ctx, id, data)
+	if err != nil {
+		writeWebhookError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func(s *Server) DeleteWebhook(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive a request to delete webhook with id: %d", id))
+	if err := s.webhooks.DeleteWebhook(ctx, id); err != nil {
+		writeWebhookError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func(s *Server) GetWebhookDeliveries(writer http.ResponseWriter, request *http.Request) {
+	ctx, cancel := context.WithTimeout(request.Context(), 30 * time.Second)
+	defer cancel()
+	id, err := getID(request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, err.Error())
+		return
+	}
+	s.log.Info(fmt.Sprintf("Recive a request to get deliveries of webhook with id: %d", id))
+	res, err := s.webhooks.Deliveries(ctx, id)
+	if err != nil {
+		writeWebhookError(writer, err)
+		return
+	}
+	bytes := prepareResponse(res, s.log)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(bytes)
+}
+
+func writeWebhookError(writer http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		writer.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, webhooks.ErrInvalidWebhook):
+		writer.WriteHeader(http.StatusBadRequest)
+	default:
+		writer.WriteHeader(http.StatusInternalServerError)
+	}
+	fmt.Fprint(writer, err.Error())
+}
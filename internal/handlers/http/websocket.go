@@ -0,0 +1,259 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"maps"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsWriteWait = 10 * time.Second
+	wsPongWait = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+	wsMaxMessageSize = 4096
+)
+
+const (
+	wsMessageSubscribe = "subscribe"
+	wsMessageUnsubscribe = "unsubscribe"
+	wsMessageSubscribed = "subscribed"
+	wsMessageError = "error"
+)
+
+// wsMessage is the frame format in both directions: server frames carry an
+// event type (question.created, answer.created, ...) or a control type.
+type wsMessage struct {
+	Type string `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type wsSubscription struct {
+	QuestionIDs []int `json:"question_ids,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// wsFeed is the per-connection subscription state. New questions are
+// delivered, only those with a subscribed tag once the client subscribed to
+// tags; events of a question only after subscribing to its ID.
+type wsFeed struct {
+	mu sync.RWMutex
+	questionIDs map[int]struct{}
+	tags map[string]struct{}
+}
+
+func newFeed() *wsFeed {
+	return &wsFeed{questionIDs: make(map[int]struct{}), tags: make(map[string]struct{})}
+}
+
+func(f *wsFeed) match(event events.Event) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if event.Type == events.QuestionCreated {
+		return f.matchTags(event)
+	}
+	_, ok := f.questionIDs[event.QuestionID]
+	return ok
+}
+
+func(f *wsFeed) matchTags(event events.Event) bool {
+	if len(f.tags) == 0 {
+		return true
+	}
+	question, err := events.Decode[models.Question](event)
+	if err != nil {
+		return false
+	}
+	for _, tag := range question.Tags {
+		if _, ok := f.tags[tag]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// update applies a subscribe or unsubscribe message and returns the
+// subscriptions after it. Tags are normalized as those of questions are, so
+// "Go" matches questions tagged go; invalid tags change nothing.
+func(f *wsFeed) update(msgType string, subscription wsSubscription) (wsSubscription, error) {
+	tags, err := service.NormalizeTags(subscription.Tags)
+	if err != nil {
+		return wsSubscription{}, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range subscription.QuestionIDs {
+		if msgType == wsMessageSubscribe {
+			f.questionIDs[id] = struct{}{}
+		} else {
+			delete(f.questionIDs, id)
+		}
+	}
+	for _, tag := range tags {
+		if msgType == wsMessageSubscribe {
+			f.tags[tag] = struct{}{}
+		} else {
+			delete(f.tags, tag)
+		}
+	}
+	res := wsSubscription{
+		QuestionIDs: slices.Sorted(maps.Keys(f.questionIDs)),
+		Tags: slices.Sorted(maps.Keys(f.tags)),
+	}
+
+	return res, nil
+}
+
+// LiveFeed upgrades to a WebSocket that pushes newly created questions and
+// the events of subscribed questions.
+func(s *Server) LiveFeed(writer http.ResponseWriter, request *http.Request) {
+	if s.events == nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		writer.Write([]byte("StreamingUnsupported"))
+		return
+	}
+	conn, err := s.upgrader.Upgrade(writer, request, nil)
+	if err != nil {
+		s.log.Error(
+			"WebSocketUpgradeError",
+			slog.String("component", "websocket"),
+			slog.Any("error", err),
+		)
+		return
+	}
+	s.streams.Add(1)
+	defer s.streams.Done()
+	defer conn.Close()
+	s.log.Info("Open websocket live feed", slog.String("remote", request.RemoteAddr))
+
+	feed := newFeed()
+	_, sub := s.events.Subscribe(feed.match, events.Latest)
+	defer sub.Close()
+
+	replies := make(chan wsMessage, 8)
+	readDone := make(chan struct{})
+	go s.readFeed(conn, feed, replies, readDone)
+
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-readDone:
+			return
+		case <-s.done:
+			writeClose(conn, websocket.CloseGoingAway, "server shutdown")
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				// The hub drops subscribers whose queue is full.
+				writeClose(conn, websocket.CloseTryAgainLater, "slow consumer")
+				return
+			}
+			if err := writeMessage(conn, event.Type, event); err != nil {
+				return
+			}
+		case reply := <-replies:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteJSON(reply); err != nil {
+				return
+			}
+		case <-ticker.C:
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait))
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func(s *Server) readFeed(conn *websocket.Conn, feed *wsFeed, replies chan<- wsMessage, done chan<- struct{}) {
+	defer close(done)
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	for {
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		reply := handleFeedMessage(feed, msg)
+		select {
+		case replies <- reply:
+		default:
+			// The client sends faster than it reads; drop the ack.
+		}
+	}
+}
+
+func handleFeedMessage(feed *wsFeed, msg wsMessage) wsMessage {
+	if msg.Type != wsMessageSubscribe && msg.Type != wsMessageUnsubscribe {
+		return errorMessage("UnknownMessageType")
+	}
+	var subscription wsSubscription
+	if err := json.Unmarshal(msg.Payload, &subscription); err != nil {
+		return errorMessage("ParsingJSONError")
+	}
+	res, err := feed.update(msg.Type, subscription)
+	if err != nil {
+		return errorMessage(service.ErrInvalidTags.Error())
+	}
+	payload, _ := json.Marshal(res)
+
+	return wsMessage{Type: wsMessageSubscribed, Payload: payload}
+}
+
+func errorMessage(text string) wsMessage {
+	payload, _ := json.Marshal(map[string]string{"message": text})
+	return wsMessage{Type: wsMessageError, Payload: payload}
+}
+
+func writeMessage(conn *websocket.Conn, msgType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return conn.WriteJSON(wsMessage{Type: msgType, Payload: data})
+}
+
+func writeClose(conn *websocket.Conn, code int, text string) {
+	conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, text),
+		time.Now().Add(wsWriteWait),
+	)
+}
+
+func newUpgrader(allowedOrigins []string) websocket.Upgrader {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize: 1024,
+		WriteBufferSize: 1024,
+	}
+	if len(allowedOrigins) != 0 {
+		upgrader.CheckOrigin = func(request *http.Request) bool {
+			origin := request.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+			parsed, err := url.Parse(origin)
+			if err != nil {
+				return false
+			}
+			return slices.Contains(allowedOrigins, parsed.Host) || slices.Contains(allowedOrigins, "*")
+		}
+	}
+
+	return upgrader
+}
@@ -0,0 +1,290 @@
+// Package jobs runs periodic work inside the server process. Jobs that
+// must not run twice at once across replicas are run only by the replica
+// holding their Postgres advisory lock, which it keeps until it shuts
+// down or loses its connection.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+var ErrDuplicateJob = errors.New("DuplicateJob")
+
+type Job struct {
+	Name string
+	Schedule string
+	// Local jobs work on the state of one process, like buffered views,
+	// and run on every replica.
+	Local bool
+	Run func(ctx context.Context) error
+}
+
+// Lock is a lock held by this process.
+type Lock interface {
+	// Held reports whether the lock is still held; a lock is lost with
+	// the connection that took it.
+	Held(ctx context.Context) bool
+	Release()
+}
+
+type Locker interface {
+	// TryLock takes the named lock, or returns nil when another process
+	// holds it.
+	TryLock(ctx context.Context, name string) (Lock, error)
+}
+
+type entry struct {
+	job Job
+	schedule Schedule
+	lock Lock
+	status models.JobStatus
+}
+
+// Scheduler runs the jobs added to it on their schedules. A job does not
+// overlap with itself: a run that is due while the previous one is still
+// going is skipped.
+type Scheduler struct {
+	log *slog.Logger
+	locker Locker
+	now func() time.Time
+	mu sync.Mutex
+	entries map[string]*entry
+	running sync.WaitGroup
+	cancel context.CancelFunc
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewScheduler(log *slog.Logger, locker Locker) *Scheduler {
+	return &Scheduler{
+		log: log,
+		locker: locker,
+		now: time.Now,
+		entries: make(map[string]*entry),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Add registers a job. It must be called before Run.
+func(s *Scheduler) Add(job Job) error {
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[job.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateJob, job.Name)
+	}
+	s.entries[job.Name] = &entry{
+		job: job,
+		schedule: schedule,
+		status: models.JobStatus{Name: job.Name, Schedule: job.Schedule, Local: job.Local},
+	}
+
+	return nil
+}
+
+// Locks is the number of locks the scheduler may hold at once, one per
+// job that is not local.
+func(s *Scheduler) Locks() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, e := range s.entries {
+		if !e.job.Local {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Run starts the jobs and returns once they are stopped. Running jobs get
+// a context that outlives ctx, so Shutdown can let them finish.
+func(s *Scheduler) Run(ctx context.Context) {
+	defer close(s.done)
+	runContext, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.mu.Lock()
+	s.cancel = cancel
+	entries := make([]*entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	s.mu.Unlock()
+
+	var loops sync.WaitGroup
+	for _, e := range entries {
+		loops.Add(1)
+		go func() {
+			defer loops.Done()
+			s.loop(ctx, runContext, e)
+		}()
+	}
+	loops.Wait()
+}
+
+// Shutdown stops scheduling, waits for the running jobs until ctx is done,
+// cancels the ones left and gives up the locks.
+func(s *Scheduler) Shutdown(ctx context.Context) {
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+	}
+	finished := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	for _, e := range s.entries {
+		if e.lock != nil && !e.status.Running {
+			e.lock.Release()
+			e.lock = nil
+			e.status.Leader = false
+		}
+	}
+}
+
+// Jobs returns the status of every job, by name.
+func(s *Scheduler) Jobs() []models.JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]models.JobStatus, 0, len(s.entries))
+	for _, e := range s.entries {
+		res = append(res, e.status)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+
+	return res
+}
+
+func(s *Scheduler) loop(ctx, runContext context.Context, e *entry) {
+	for {
+		now := s.now()
+		next := e.schedule.Next(now)
+		s.mu.Lock()
+		if next.IsZero() {
+			e.status.NextRunAt = nil
+		} else {
+			e.status.NextRunAt = &next
+		}
+		s.mu.Unlock()
+		if next.IsZero() {
+			return
+		}
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.run(runContext, e)
+		}
+	}
+}
+
+// run runs the job if this process may: it is not running already and,
+// unless the job is local, this process leads it.
+func(s *Scheduler) run(ctx context.Context, e *entry) error {
+	s.mu.Lock()
+	if e.status.Running {
+		e.status.Skipped++
+		s.mu.Unlock()
+		return nil
+	}
+	e.status.Running = true
+	s.running.Add(1)
+	s.mu.Unlock()
+	defer s.running.Done()
+
+	if !e.job.Local && !s.lead(ctx, e) {
+		s.mu.Lock()
+		e.status.Running = false
+		e.status.Skipped++
+		s.mu.Unlock()
+		return nil
+	}
+
+	start := s.now()
+	err := e.job.Run(ctx)
+	duration := s.now().Sub(start)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.status.Running = false
+	e.status.Runs++
+	e.status.LastRunAt = &start
+	e.status.LastDurationMs = duration.Milliseconds()
+	e.status.LastError = ""
+	select {
+	case <-s.stop:
+		// Shutdown left the lock to this run.
+		if e.lock != nil {
+			e.lock.Release()
+			e.lock = nil
+			e.status.Leader = false
+		}
+	default:
+	}
+	if err != nil {
+		e.status.Failures++
+		e.status.LastError = err.Error()
+		s.log.Error(
+			"JobError",
+			slog.String("component", "jobs"),
+			slog.String("job", e.job.Name),
+			slog.Any("error", err),
+		)
+	}
+
+	return err
+}
+
+// lead checks that this process still holds the lock of the job, or takes
+// it when it is free.
+func(s *Scheduler) lead(ctx context.Context, e *entry) bool {
+	if e.lock != nil && !e.lock.Held(ctx) {
+		s.log.Warn("Job leadership lost", slog.String("job", e.job.Name))
+		e.lock.Release()
+		e.lock = nil
+	}
+	if e.lock == nil {
+		lock, err := s.locker.TryLock(ctx, "jobs:" + e.job.Name)
+		if err != nil {
+			s.log.Error(
+				"DB_LockingError",
+				slog.String("component", "jobs"),
+				slog.String("job", e.job.Name),
+				slog.Any("error", err),
+			)
+			return false
+		}
+		e.lock = lock
+	}
+	s.mu.Lock()
+	e.status.Leader = e.lock != nil
+	s.mu.Unlock()
+
+	return e.lock != nil
+}
@@ -0,0 +1,169 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnlyLeaderRunsJob(t *testing.T) {
+	ctx := context.Background()
+	locker := newTestLocker()
+	var runs atomic.Int32
+	job := Job{Name: "digest", Schedule: "@hourly", Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}}
+	first, second := NewScheduler(slog.Default(), locker), NewScheduler(slog.Default(), locker)
+	for _, scheduler := range []*Scheduler{first, second} {
+		if err := scheduler.Add(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		first.run(ctx, first.entries["digest"])
+		second.run(ctx, second.entries["digest"])
+	}
+	if runs.Load() != 2 {
+		t.Errorf("Excpected only the leader to run, got %d runs", runs.Load())
+	}
+	leader, follower := first.Jobs()[0], second.Jobs()[0]
+	if !leader.Leader || leader.Runs != 2 || follower.Leader || follower.Skipped != 2 {
+		t.Errorf("Excpected the first scheduler to lead, got %+v and %+v", leader, follower)
+	}
+
+	// The follower takes over once the leader loses its connection.
+	locker.drop("jobs:digest")
+	second.run(ctx, second.entries["digest"])
+	first.run(ctx, first.entries["digest"])
+	leader, follower = second.Jobs()[0], first.Jobs()[0]
+	if !leader.Leader || leader.Runs != 1 || follower.Leader || follower.Skipped != 1 {
+		t.Errorf("Excpected the second scheduler to lead, got %+v and %+v", leader, follower)
+	}
+}
+
+func TestLocalJobsRunEverywhere(t *testing.T) {
+	ctx := context.Background()
+	locker := newTestLocker()
+	var runs atomic.Int32
+	job := Job{Name: "views_flush", Schedule: "@every 10s", Local: true, Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("DB_WritingError")
+	}}
+	for i := 0; i < 2; i++ {
+		scheduler := NewScheduler(slog.Default(), locker)
+		if err := scheduler.Add(job); err != nil {
+			t.Fatal(err)
+		}
+		if err := scheduler.run(ctx, scheduler.entries["views_flush"]); err == nil {
+			t.Error("Excpected the error of the job")
+		}
+		status := scheduler.Jobs()[0]
+		if status.Runs != 1 || status.Failures != 1 || status.LastError != "DB_WritingError" || status.LastRunAt == nil {
+			t.Errorf("Excpected one failed run, got %+v", status)
+		}
+	}
+	if runs.Load() != 2 {
+		t.Errorf("Excpected both schedulers to run, got %d runs", runs.Load())
+	}
+	scheduler := NewScheduler(slog.Default(), locker)
+	scheduler.Add(job)
+	if err := scheduler.Add(job); !errors.Is(err, ErrDuplicateJob) {
+		t.Errorf("Excpected ErrDuplicateJob, got %v", err)
+	}
+	scheduler.Add(Job{Name: "digest", Schedule: "@hourly", Run: job.Run})
+	if locks := scheduler.Locks(); locks != 1 {
+		t.Errorf("Excpected a lock for the digest only, got %d", locks)
+	}
+}
+
+func TestShutdownWaitsForRunningJob(t *testing.T) {
+	locker := newTestLocker()
+	started := make(chan struct{})
+	var finished atomic.Bool
+	scheduler := NewScheduler(slog.Default(), locker)
+	err := scheduler.Add(Job{Name: "slow", Schedule: "@every 1s", Run: func(ctx context.Context) error {
+		close(started)
+		select {
+		case <-time.After(200 * time.Millisecond):
+			finished.Store(true)
+		case <-ctx.Done():
+		}
+		return nil
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go scheduler.Run(ctx)
+	select {
+	case <-started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Excpected the job to start")
+	}
+	// The server context is done before Shutdown, as on SIGTERM.
+	cancel()
+
+	shutdownContext, stop := context.WithTimeout(context.Background(), 2 * time.Second)
+	defer stop()
+	scheduler.Shutdown(shutdownContext)
+	if !finished.Load() {
+		t.Error("Excpected Shutdown to wait for the running job")
+	}
+	if lock, _ := locker.TryLock(context.Background(), "jobs:slow"); lock == nil {
+		t.Error("Excpected the lock to be released")
+	}
+}
+
+// testLocker shares named locks between schedulers, as advisory locks are
+// shared by the replicas of one database.
+type testLocker struct {
+	mu sync.Mutex
+	held map[string]*testLock
+}
+
+func newTestLocker() *testLocker {
+	return &testLocker{held: make(map[string]*testLock)}
+}
+
+func(l *testLocker) TryLock(ctx context.Context, name string) (Lock, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.held[name]; ok {
+		return nil, nil
+	}
+	lock := &testLock{locker: l, name: name}
+	l.held[name] = lock
+	return lock, nil
+}
+
+// drop loses the lock as a broken connection would.
+func(l *testLocker) drop(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.held, name)
+}
+
+type testLock struct {
+	locker *testLocker
+	name string
+}
+
+func(l *testLock) Held(ctx context.Context) bool {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	return l.locker.held[l.name] == l
+}
+
+func(l *testLock) Release() {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	if l.locker.held[l.name] == l {
+		delete(l.locker.held, l.name)
+	}
+}
@@ -0,0 +1,170 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("InvalidSchedule")
+
+// Schedule returns the first run time after t, or the zero time when there
+// is none.
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule reads a five-field cron expression (minute hour
+// day-of-month month day-of-week) with *, lists, ranges and steps, one of
+// @hourly, @daily, @weekly and @monthly, or "@every <duration>". Cron
+// expressions are evaluated in the time zone of the time given to Next.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if every, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(every))
+		if err != nil || interval < time.Second {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSchedule, spec)
+		}
+		return everySchedule(interval), nil
+	}
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: %q needs five fields", ErrInvalidSchedule, spec)
+	}
+	var schedule cronSchedule
+	var err error
+	if schedule.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("%w: minute %v", ErrInvalidSchedule, err)
+	}
+	if schedule.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("%w: hour %v", ErrInvalidSchedule, err)
+	}
+	if schedule.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("%w: day of month %v", ErrInvalidSchedule, err)
+	}
+	if schedule.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("%w: month %v", ErrInvalidSchedule, err)
+	}
+	if schedule.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("%w: day of week %v", ErrInvalidSchedule, err)
+	}
+	// 7 is another name for Sunday.
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.anyDom = strings.HasPrefix(fields[2], "*")
+	schedule.anyDow = strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+type everySchedule time.Duration
+
+func(e everySchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// cronSchedule keeps the allowed values of each field as bits.
+type cronSchedule struct {
+	minute uint64
+	hour uint64
+	dom uint64
+	month uint64
+	dow uint64
+	anyDom bool
+	anyDow bool
+}
+
+// Next walks forward a field at a time: a month that does not match skips
+// to the next month, a day to the next day and so on.
+func(c cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		year, month, day := t.Date()
+		loc := t.Location()
+		switch {
+		case c.month&(1<<uint(month)) == 0:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchDay(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchDay follows cron: when both day fields are restricted, a day
+// matching either runs the job.
+func(c cronSchedule) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	}
+
+	return dom || dow
+}
+
+func parseField(field string, low, high int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			value, err := strconv.Atoi(stepPart)
+			if err != nil || value <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			step = value
+		}
+		start, end := low, high
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			value, err := strconv.Atoi(first)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			start, end = value, value
+			if isRange {
+				if end, err = strconv.Atoi(last); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if hasStep {
+				end = high
+			}
+		}
+		if start < low || end > high || start > end {
+			return 0, fmt.Errorf("%q is out of %d-%d", part, low, high)
+		}
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
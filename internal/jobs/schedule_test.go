@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// A Wednesday.
+	from := time.Date(2026, time.January, 14, 10, 17, 30, 0, time.UTC)
+	for spec, excpected := range map[string]time.Time{
+		"*/15 * * * *": time.Date(2026, time.January, 14, 10, 30, 0, 0, time.UTC),
+		"0 3 * * *": time.Date(2026, time.January, 15, 3, 0, 0, 0, time.UTC),
+		"30 9 * * 1-5": time.Date(2026, time.January, 15, 9, 30, 0, 0, time.UTC),
+		"0 0 * * 7": time.Date(2026, time.January, 18, 0, 0, 0, 0, time.UTC),
+		"0 0 31 * *": time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC),
+		"0 12 29 2 *": time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC),
+		"0 0 1 * 1": time.Date(2026, time.January, 19, 0, 0, 0, 0, time.UTC),
+		"@hourly": time.Date(2026, time.January, 14, 11, 0, 0, 0, time.UTC),
+		"@every 90s": time.Date(2026, time.January, 14, 10, 19, 0, 0, time.UTC),
+	} {
+		schedule, err := ParseSchedule(spec)
+		if err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		if next := schedule.Next(from); !next.Equal(excpected) {
+			t.Errorf("Excpected %s to run at %s, got %s", spec, excpected, next)
+		}
+	}
+}
+
+func TestScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "5-1 * * * *", "*/0 * * * *", "@every 1ms", "@yearly"} {
+		if _, err := ParseSchedule(spec); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("Excpected ErrInvalidSchedule for %q, got %v", spec, err)
+		}
+	}
+}
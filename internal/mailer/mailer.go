@@ -0,0 +1,123 @@
+// Package mailer sends email. SMTP delivers it; File and Memory keep the
+// messages for development and tests.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type Message struct {
+	From string
+	To string
+	Subject string
+	Text string
+	HTML string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// Bytes renders the message as multipart/alternative MIME with a plain
+// text and an HTML part.
+func(m Message) Bytes(date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var res bytes.Buffer
+	fmt.Fprintf(&res, "From: %s\r\n", m.From)
+	fmt.Fprintf(&res, "To: %s\r\n", m.To)
+	fmt.Fprintf(&res, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&res, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	fmt.Fprintf(&res, "Message-ID: <%s@questions-answers>\r\n", randomID())
+	res.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&res, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+	res.Write(body.Bytes())
+
+	return res.Bytes(), nil
+}
+
+// File writes every message as an .eml file to a directory.
+type File struct {
+	dir string
+}
+
+func NewFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir}, nil
+}
+
+func(f *File) Send(ctx context.Context, message Message) error {
+	now := time.Now()
+	data, err := message.Bytes(now)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), randomID())
+	return os.WriteFile(filepath.Join(f.dir, name), data, 0640)
+}
+
+// Memory keeps the messages sent, for tests.
+type Memory struct {
+	mu sync.Mutex
+	messages []Message
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func(m *Memory) Send(ctx context.Context, message Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func(m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+func randomID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
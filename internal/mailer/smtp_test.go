@@ -0,0 +1,117 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"mime"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+)
+
+func TestSMTPSend(t *testing.T) {
+	addr, received := startFakeSMTP(t)
+	host, port, _ := net.SplitHostPort(addr)
+	portNumber, _ := strconv.Atoi(port)
+	s := NewSMTP(config.SMTPConfig{Host: host, Port: portNumber, Timeout: time.Second})
+
+	err := s.Send(context.Background(), Message{
+		From: "Questions <digest@example.com>",
+		To: "alice@example.com",
+		Subject: "Your digest — 2 new answers",
+		Text: "Plain",
+		HTML: "<p>Rich</p>",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := <-received
+	if res.from != "<digest@example.com>" || res.to != "<alice@example.com>" {
+		t.Errorf("Excpected envelope digest@example.com -> alice@example.com, got %s -> %s", res.from, res.to)
+	}
+	message, err := mail.ReadMessage(strings.NewReader(res.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil || subject != "Your digest — 2 new answers" {
+		t.Errorf("Excpected the encoded subject, got %q (%v)", subject, err)
+	}
+	if !strings.HasPrefix(message.Header.Get("Content-Type"), "multipart/alternative") {
+		t.Errorf("Excpected multipart/alternative, got %s", message.Header.Get("Content-Type"))
+	}
+}
+
+type smtpReceived struct {
+	from string
+	to string
+	data string
+}
+
+// startFakeSMTP accepts one message without STARTTLS or AUTH.
+func startFakeSMTP(t *testing.T) (string, <-chan smtpReceived) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan smtpReceived, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		var res smtpReceived
+		conn.Write([]byte("220 fake ESMTP\r\n"))
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				conn.Write([]byte("250 fake\r\n"))
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				res.from = line[len("MAIL FROM:"):]
+				conn.Write([]byte("250 OK\r\n"))
+			case strings.HasPrefix(command, "RCPT TO:"):
+				res.to = line[len("RCPT TO:"):]
+				conn.Write([]byte("250 OK\r\n"))
+			case command == "DATA":
+				conn.Write([]byte("354 Go ahead\r\n"))
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(strings.TrimPrefix(line, "."))
+				}
+				res.data = data.String()
+				conn.Write([]byte("250 Queued\r\n"))
+			case command == "QUIT":
+				conn.Write([]byte("221 Bye\r\n"))
+				received <- res
+				return
+			default:
+				conn.Write([]byte("502 Unsupported\r\n"))
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
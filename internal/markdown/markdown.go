@@ -0,0 +1,44 @@
+// Package markdown renders the Markdown of questions and answers to HTML
+// that is safe to embed in a page.
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// Raw HTML in the source is dropped by goldmark, which runs without
+// html.WithUnsafe; the policy removes whatever else could run script, such
+// as javascript: links. Both are safe for concurrent use.
+var (
+	converter = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+	)
+	policy = newPolicy()
+)
+
+// Render converts CommonMark with the GFM extensions (tables, fenced code,
+// strikethrough, autolinks and task lists) to sanitized HTML.
+func Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := converter.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+
+	return policy.Sanitize(buf.String()), nil
+}
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// Fenced code keeps its language for client-side highlighting.
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	// Task list items render as disabled checkboxes.
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return p
+}
@@ -0,0 +1,123 @@
+package markdown
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestRenderGFM(t *testing.T) {
+	source := "# Title\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n```go\nfmt.Println(\"<hi>\")\n```\n\n- [x] done\n\nSee https://go.dev"
+	res, err := Render(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	excpected := []string{
+		"<h1>Title</h1>",
+		"<table>",
+		"<td>1</td>",
+		`<code class="language-go">`,
+		"fmt.Println(&#34;&lt;hi&gt;&#34;)",
+		`<input checked="" disabled="" type="checkbox"`,
+		`<a href="https://go.dev" rel="nofollow">https://go.dev</a>`,
+	}
+	for _, part := range excpected {
+		if !strings.Contains(res, part) {
+			t.Errorf("Excpected %q in %q", part, res)
+		}
+	}
+}
+
+// xssCorpus holds Markdown and raw HTML vectors that must not survive
+// rendering with anything able to run script.
+var xssCorpus = []string{
+	`<script>alert(1)</script>`,
+	`<SCRIPT SRC=https://evil.example/xss.js></SCRIPT>`,
+	`<img src=x onerror=alert(1)>`,
+	`<svg onload=alert(1)>`,
+	`<svg><script>alert(1)</script></svg>`,
+	`<iframe src="javascript:alert(1)"></iframe>`,
+	`<body onload=alert(1)>`,
+	`<a href="javascript:alert(1)">x</a>`,
+	`<a href="JaVaScRiPt:alert(1)">x</a>`,
+	`<a href="&#106;avascript:alert(1)">x</a>`,
+	`<div style="background:url(javascript:alert(1))">x</div>`,
+	`<object data="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg=="></object>`,
+	`<embed src="javascript:alert(1)">`,
+	`<form action="javascript:alert(1)"><button>x</button></form>`,
+	`<input autofocus onfocus=alert(1)>`,
+	`<details open ontoggle=alert(1)>`,
+	`<math><mtext><table><mglyph><style><img src=x onerror=alert(1)></style></mglyph></table></mtext></math>`,
+	`<meta http-equiv="refresh" content="0;url=javascript:alert(1)">`,
+	`<link rel="stylesheet" href="javascript:alert(1)">`,
+	`<base href="javascript:alert(1)//">`,
+	`[x](javascript:alert(1))`,
+	`[x](JAVASCRIPT:alert(1))`,
+	`[x](java	script:alert(1))`,
+	`[x](vbscript:msgbox(1))`,
+	`[x](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)`,
+	`![x](javascript:alert(1))`,
+	`![x](x" onerror="alert(1))`,
+	`[x](https://example.com" onclick="alert(1))`,
+	`<https://example.com/"><script>alert(1)</script>>`,
+	"```\n</code><script>alert(1)</script>\n```",
+	"| a |\n|---|\n| <img src=x onerror=alert(1)> |",
+	`<!--><script>alert(1)</script>-->`,
+	`<scr<script>ipt>alert(1)</scr</script>ipt>`,
+	"<a href=\"\x01javascript:alert(1)\">x</a>",
+}
+
+func TestRenderBlocksXSS(t *testing.T) {
+	for _, source := range xssCorpus {
+		res, err := Render(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes, err := html.ParseFragment(strings.NewReader(res), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, node := range nodes {
+			if problem := unsafeNode(node); problem != "" {
+				t.Errorf("Excpected safe rendering of %q, got %s in %q", source, problem, res)
+			}
+		}
+	}
+}
+
+var allowedSchemes = []string{"http:", "https:", "mailto:"}
+
+// unsafeNode walks the parsed output, so vectors escaped into text do not
+// count, and reports an element or attribute able to run script.
+func unsafeNode(node *html.Node) string {
+	if node.Type == html.ElementNode {
+		switch node.DataAtom {
+		case atom.Script, atom.Iframe, atom.Object, atom.Embed, atom.Form, atom.Svg, atom.Math,
+			atom.Style, atom.Meta, atom.Link, atom.Base, atom.Body:
+			return "element " + node.Data
+		}
+		for _, attr := range node.Attr {
+			key := strings.ToLower(attr.Key)
+			if strings.HasPrefix(key, "on") || key == "style" {
+				return "attribute " + key
+			}
+			if key == "href" || key == "src" || key == "action" {
+				value := strings.ToLower(strings.TrimSpace(attr.Val))
+				if scheme, _, ok := strings.Cut(value, ":"); ok && !strings.ContainsAny(scheme, "/?#") &&
+					!slices.Contains(allowedSchemes, scheme + ":") {
+					return "URL " + attr.Val
+				}
+			}
+		}
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if problem := unsafeNode(child); problem != "" {
+			return problem
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,113 @@
+package mock
+
+import (
+	"context"
+	"sort"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// MockStorageAttachments keeps attachment rows in memory. A row is orphaned
+// when its parent is gone from the question or answer mock, which stands in
+// for the ON DELETE SET NULL of the real table.
+type MockStorageAttachments struct {
+	questions *MockStorageQuestions
+	db map[int]models.Attachment
+	id int
+}
+
+func NewMockStorageAttachments(questions *MockStorageQuestions) *MockStorageAttachments {
+	return &MockStorageAttachments{
+		questions: questions,
+		db: make(map[int]models.Attachment),
+	}
+}
+
+func(s *MockStorageAttachments) CreateAttachment(ctx context.Context, data *models.Attachment) error {
+	if s.orphaned(*data) {
+		return gorm.ErrRecordNotFound
+	}
+	s.questions.storageAnswers.author(data.UserID)
+	s.id += 1
+	data.ID = s.id
+	data.CreatedAt = defaultTime()
+	s.db[data.ID] = *data
+	return nil
+}
+
+func(s *MockStorageAttachments) Attachment(ctx context.Context, id int) (models.Attachment, error) {
+	res, ok := s.db[id]
+	if !ok {
+		return res, gorm.ErrRecordNotFound
+	}
+	return s.detach(res), nil
+}
+
+func(s *MockStorageAttachments) QuestionAttachments(ctx context.Context, questionID int) ([]models.Attachment, error) {
+	if _, ok := s.questions.db[questionID]; !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.filter(func(v models.Attachment) bool {
+		return v.QuestionID != nil && *v.QuestionID == questionID
+	}), nil
+}
+
+func(s *MockStorageAttachments) AnswerAttachments(ctx context.Context, answerID int) ([]models.Attachment, error) {
+	if _, ok := s.questions.storageAnswers.db[answerID]; !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.filter(func(v models.Attachment) bool {
+		return v.AnswerID != nil && *v.AnswerID == answerID
+	}), nil
+}
+
+func(s *MockStorageAttachments) OrphanedAttachments(ctx context.Context, limit int) ([]models.Attachment, error) {
+	res := s.filter(s.orphaned)
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	for i := range res {
+		res[i] = s.detach(res[i])
+	}
+	return res, nil
+}
+
+func(s *MockStorageAttachments) DeleteAttachments(ctx context.Context, ids []int) error {
+	for _, id := range ids {
+		delete(s.db, id)
+	}
+	return nil
+}
+
+func(s *MockStorageAttachments) filter(keep func(models.Attachment) bool) []models.Attachment {
+	res := make([]models.Attachment, 0)
+	for _, v := range s.db {
+		if keep(v) {
+			res = append(res, v)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+func(s *MockStorageAttachments) orphaned(attachment models.Attachment) bool {
+	if attachment.QuestionID != nil {
+		_, ok := s.questions.db[*attachment.QuestionID]
+		return !ok
+	}
+	if attachment.AnswerID != nil {
+		_, ok := s.questions.storageAnswers.db[*attachment.AnswerID]
+		return !ok
+	}
+	return true
+}
+
+// detach clears the parent of an orphaned row, as the foreign keys do.
+func(s *MockStorageAttachments) detach(attachment models.Attachment) models.Attachment {
+	if s.orphaned(attachment) {
+		attachment.QuestionID = nil
+		attachment.AnswerID = nil
+	}
+	return attachment
+}
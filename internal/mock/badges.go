@@ -0,0 +1,127 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// MockStorageBadges computes user stats from the users mock.
+type MockStorageBadges struct {
+	users *MockStorageUsers
+	badges map[string]models.Badge
+	awards []models.UserBadge
+}
+
+func NewMockStorageBadges(users *MockStorageUsers) *MockStorageBadges {
+	return &MockStorageBadges{
+		users: users,
+		badges: make(map[string]models.Badge),
+	}
+}
+
+func(s *MockStorageBadges) SaveBadges(ctx context.Context, badges []models.Badge) ([]models.Badge, error) {
+	for i, badge := range badges {
+		if current, ok := s.badges[badge.Name]; ok {
+			badge.ID = current.ID
+			badge.CreatedAt = current.CreatedAt
+		} else {
+			badge.ID = len(s.badges) + 1
+			badge.CreatedAt = defaultTime()
+		}
+		s.badges[badge.Name] = badge
+		badges[i] = badge
+	}
+	return badges, nil
+}
+
+func(s *MockStorageBadges) AllBadges(ctx context.Context) ([]models.Badge, error) {
+	res := make([]models.Badge, 0, len(s.badges))
+	for _, v := range s.badges {
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
+func(s *MockStorageBadges) UserBadges(ctx context.Context, userID string) ([]models.UserBadge, error) {
+	res := make([]models.UserBadge, 0)
+	for _, v := range s.awards {
+		if v.UserID == userID {
+			res = append(res, v)
+		}
+	}
+	return res, nil
+}
+
+func(s *MockStorageBadges) AwardBadge(ctx context.Context, award *models.UserBadge) error {
+	for _, v := range s.awards {
+		if v.UserID == award.UserID && v.BadgeID == award.BadgeID {
+			return nil
+		}
+	}
+	for _, badge := range s.badges {
+		if badge.ID == award.BadgeID {
+			award.Badge = badge
+		}
+	}
+	award.AwardedAt = defaultTime()
+	s.awards = append(s.awards, *award)
+	return nil
+}
+
+func(s *MockStorageBadges) UserStats(ctx context.Context, userID string) (models.UserStats, error) {
+	answers := s.users.answers()
+	user, ok := answers.users[userID]
+	if !ok {
+		return models.UserStats{}, gorm.ErrRecordNotFound
+	}
+	res := models.UserStats{Reputation: user.Reputation}
+	for _, v := range answers.db {
+		if v.UserID != userID {
+			continue
+		}
+		res.Answers++
+		if v.Accepted {
+			res.AcceptedAnswers++
+		}
+		res.MaxAnswerScore = max(res.MaxAnswerScore, v.Score)
+	}
+	for _, v := range s.users.questions.db {
+		if v.UserID == nil || *v.UserID != userID {
+			continue
+		}
+		res.Questions++
+		res.MaxQuestionViews = max(res.MaxQuestionViews, v.ViewCount)
+	}
+	return res, nil
+}
+
+func(s *MockStorageBadges) QuestionAuthors(ctx context.Context, ids []int) ([]string, error) {
+	res := make([]string, 0)
+	for _, id := range ids {
+		question, ok := s.users.questions.db[id]
+		if ok && question.UserID != nil && !slices.Contains(res, *question.UserID) {
+			res = append(res, *question.UserID)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func(s *MockStorageBadges) UserIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	res := make([]string, 0)
+	for id := range s.users.answers().users {
+		if id > afterID {
+			res = append(res, id)
+		}
+	}
+	sort.Strings(res)
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
@@ -0,0 +1,61 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+func(s *MockStorageQuestions) QuestionsByID(ctx context.Context, ids []int) ([]models.Question, error) {
+	res := make([]models.Question, 0, len(ids))
+	for _, id := range ids {
+		if question, ok := s.db[id]; ok {
+			res = append(res, question)
+		}
+	}
+	return res, nil
+}
+
+func(s *MockStorageQuestions) QuestionsPage(ctx context.Context, order models.QuestionSort, tag string, page models.Page) ([]models.Question, error) {
+	res := make([]models.Question, 0)
+	for _, v := range s.db {
+		if tag == "" || slices.Contains(v.Tags, tag) {
+			res = append(res, v)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if order == models.SortViews && res[i].ViewCount != res[j].ViewCount {
+			return res[i].ViewCount > res[j].ViewCount
+		}
+		return res[i].ID > res[j].ID
+	})
+	return paginate(res, page), nil
+}
+
+func(s *MockStorageQuestions) QuestionsByTag(ctx context.Context, tags []string, page models.Page) ([]models.TaggedQuestion, error) {
+	res := make([]models.TaggedQuestion, 0)
+	for _, tag := range tags {
+		questions, _ := s.QuestionsPage(ctx, models.SortDefault, tag, page)
+		for _, question := range questions {
+			res = append(res, models.TaggedQuestion{Tag: tag, Question: question})
+		}
+	}
+	return res, nil
+}
+
+func(s *MockStorageAnswers) AnswersByQuestion(ctx context.Context, questionIDs []int) ([]models.Answer, error) {
+	wanted := make(map[int]struct{}, len(questionIDs))
+	for _, id := range questionIDs {
+		wanted[id] = struct{}{}
+	}
+	res := make([]models.Answer, 0)
+	for _, v := range s.db {
+		if _, ok := wanted[v.QuestionID]; ok {
+			res = append(res, v)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
@@ -0,0 +1,128 @@
+package mock
+
+import (
+	"context"
+	"sort"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// MockStorageComments keeps comment rows in memory. A comment whose parent
+// is gone from the question or answer mock is not listed, which stands in
+// for the ON DELETE CASCADE of the real table.
+type MockStorageComments struct {
+	questions *MockStorageQuestions
+	db map[int]models.Comment
+	id int
+}
+
+func NewMockStorageComments(questions *MockStorageQuestions) *MockStorageComments {
+	return &MockStorageComments{
+		questions: questions,
+		db: make(map[int]models.Comment),
+	}
+}
+
+func(s *MockStorageComments) CreateComment(ctx context.Context, data *models.Comment) error {
+	if s.orphaned(*data) {
+		return gorm.ErrRecordNotFound
+	}
+	answers := s.questions.storageAnswers
+	created := models.CommentCreated{}
+	if data.AnswerID != nil {
+		answer := answers.db[*data.AnswerID]
+		created.QuestionID = answer.QuestionID
+		created.ParentAuthorID = answer.UserID
+	} else {
+		question := s.questions.db[*data.QuestionID]
+		created.QuestionID = question.ID
+		if question.UserID != nil {
+			created.ParentAuthorID = *question.UserID
+		}
+	}
+	answers.author(data.UserID)
+	s.id += 1
+	data.ID = s.id
+	data.CreatedAt = defaultTime()
+	s.db[data.ID] = *data
+	created.Comment = *data
+	answers.outbox.Write(events.NewCommentCreated(created))
+	return nil
+}
+
+func(s *MockStorageComments) Comment(ctx context.Context, id int) (models.Comment, error) {
+	res, ok := s.db[id]
+	if !ok || s.orphaned(res) {
+		return models.Comment{}, gorm.ErrRecordNotFound
+	}
+	return res, nil
+}
+
+func(s *MockStorageComments) QuestionComments(ctx context.Context, questionID int, page models.Page) ([]models.Comment, error) {
+	if _, ok := s.questions.db[questionID]; !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return paginate(s.filter(func(v models.Comment) bool {
+		return v.QuestionID != nil && *v.QuestionID == questionID
+	}), page), nil
+}
+
+func(s *MockStorageComments) AnswerComments(ctx context.Context, answerID int, page models.Page) ([]models.Comment, error) {
+	if _, ok := s.questions.storageAnswers.db[answerID]; !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return paginate(s.filter(func(v models.Comment) bool {
+		return v.AnswerID != nil && *v.AnswerID == answerID
+	}), page), nil
+}
+
+func(s *MockStorageComments) DeleteComment(ctx context.Context, id int) (int, error) {
+	if _, err := s.Comment(ctx, id); err != nil {
+		return 0, nil
+	}
+	delete(s.db, id)
+	return 1, nil
+}
+
+func(s *MockStorageComments) CommentsByQuestion(ctx context.Context, questionIDs []int, page models.Page) ([]models.Comment, error) {
+	res := make([]models.Comment, 0)
+	for _, id := range questionIDs {
+		comments, _ := s.QuestionComments(ctx, id, page)
+		res = append(res, comments...)
+	}
+	return res, nil
+}
+
+func(s *MockStorageComments) CommentsByAnswer(ctx context.Context, answerIDs []int, page models.Page) ([]models.Comment, error) {
+	res := make([]models.Comment, 0)
+	for _, id := range answerIDs {
+		comments, _ := s.AnswerComments(ctx, id, page)
+		res = append(res, comments...)
+	}
+	return res, nil
+}
+
+func(s *MockStorageComments) filter(keep func(models.Comment) bool) []models.Comment {
+	res := make([]models.Comment, 0)
+	for _, v := range s.db {
+		if keep(v) {
+			res = append(res, v)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+func(s *MockStorageComments) orphaned(comment models.Comment) bool {
+	if comment.QuestionID != nil {
+		_, ok := s.questions.db[*comment.QuestionID]
+		return !ok
+	}
+	if comment.AnswerID != nil {
+		_, ok := s.questions.storageAnswers.db[*comment.AnswerID]
+		return !ok
+	}
+	return true
+}
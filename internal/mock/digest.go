@@ -0,0 +1,118 @@
+package mock
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// MockStorageDigests reads the preferences and followers of the
+// notifications mock and the answers of the answers mock.
+type MockStorageDigests struct {
+	notifications *MockStorageNotifications
+	answers *MockStorageAnswers
+}
+
+func NewMockStorageDigests(notifications *MockStorageNotifications, answers *MockStorageAnswers) *MockStorageDigests {
+	return &MockStorageDigests{notifications: notifications, answers: answers}
+}
+
+func(s *MockStorageDigests) DueDigests(ctx context.Context, frequency string, sentBefore time.Time, after string, limit int) ([]models.NotificationPreferences, error) {
+	res := make([]models.NotificationPreferences, 0)
+	for _, preferences := range s.notifications.preferences {
+		if preferences.Digest != frequency || preferences.Email == "" || preferences.EmailVerifiedAt == nil {
+			continue
+		}
+		if preferences.UserID <= after {
+			continue
+		}
+		if preferences.DigestSentAt != nil && preferences.DigestSentAt.After(sentBefore) {
+			continue
+		}
+		res = append(res, preferences)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].UserID < res[j].UserID })
+	return paginate(res, models.Page{Limit: limit}), nil
+}
+
+func(s *MockStorageDigests) FollowedAnswers(ctx context.Context, userID string, since, until time.Time) ([]models.DigestAnswer, error) {
+	res := make([]models.DigestAnswer, 0)
+	for _, answer := range s.answers.db {
+		if _, ok := s.notifications.followers[answer.QuestionID][userID]; !ok || answer.UserID == userID {
+			continue
+		}
+		if answer.CreatedAt.Before(since) || !answer.CreatedAt.Before(until) {
+			continue
+		}
+		res = append(res, models.DigestAnswer{
+			QuestionID: answer.QuestionID,
+			QuestionText: s.notifications.questions.db[answer.QuestionID].Text,
+			AnswerID: answer.ID,
+			AnswerText: answer.Text,
+			UserID: answer.UserID,
+			CreatedAt: answer.CreatedAt,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].QuestionID != res[j].QuestionID {
+			return res[i].QuestionID < res[j].QuestionID
+		}
+		return res[i].AnswerID < res[j].AnswerID
+	})
+	return res, nil
+}
+
+func(s *MockStorageDigests) UnansweredQuestions(ctx context.Context, userID string, since, until time.Time, limit int) ([]models.DigestQuestion, error) {
+	answered := make(map[int]struct{})
+	for _, answer := range s.answers.db {
+		answered[answer.QuestionID] = struct{}{}
+	}
+	res := make([]models.DigestQuestion, 0)
+	for _, question := range s.notifications.questions.db {
+		if _, ok := answered[question.ID]; ok || question.DuplicateOf != nil {
+			continue
+		}
+		if question.UserID != nil && *question.UserID == userID {
+			continue
+		}
+		if question.CreatedAt.Before(since) || !question.CreatedAt.Before(until) || !s.followsAny(userID, question.Tags) {
+			continue
+		}
+		res = append(res, models.DigestQuestion{ID: question.ID, Text: question.Text, Tags: question.Tags, CreatedAt: question.CreatedAt})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return paginate(res, models.Page{Limit: limit}), nil
+}
+
+func(s *MockStorageDigests) followsAny(userID string, tags []string) bool {
+	for _, tag := range tags {
+		if _, ok := s.notifications.tags[userID][tag]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func(s *MockStorageDigests) SwapDigestSent(ctx context.Context, userID string, previous, next *time.Time) (bool, error) {
+	preferences, ok := s.notifications.preferences[userID]
+	if !ok {
+		return false, nil
+	}
+	current := preferences.DigestSentAt
+	if (current == nil) != (previous == nil) || current != nil && !current.Equal(*previous) {
+		return false, nil
+	}
+	preferences.DigestSentAt = next
+	s.notifications.preferences[userID] = preferences
+	return true, nil
+}
+
+// Unverify clears the verification of the email of the user, as changing
+// the email does.
+func(s *MockStorageDigests) Unverify(userID string) {
+	preferences := s.notifications.preferences[userID]
+	preferences.EmailVerifiedAt = nil
+	s.notifications.preferences[userID] = preferences
+}
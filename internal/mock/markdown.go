@@ -0,0 +1,53 @@
+package mock
+
+import (
+	"context"
+	"sort"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// UnrenderedContents pages through the questions or answers without HTML,
+// as the postgres storage does.
+func(s *MockStorageQuestions) UnrenderedContents(ctx context.Context, table string, afterID, limit int) ([]models.Content, error) {
+	res := make([]models.Content, 0)
+	if table == "answers" {
+		for _, answer := range s.storageAnswers.db {
+			if answer.TextHTML == "" && answer.ID > afterID {
+				res = append(res, models.Content{ID: answer.ID, QuestionID: answer.QuestionID, Text: answer.Text})
+			}
+		}
+	} else {
+		for _, question := range s.db {
+			if question.TextHTML == "" && question.ID > afterID {
+				res = append(res, models.Content{ID: question.ID, Text: question.Text})
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func(s *MockStorageQuestions) SaveRenderedContents(ctx context.Context, table string, contents []models.Content) error {
+	for _, content := range contents {
+		if table == "answers" {
+			answer, ok := s.storageAnswers.db[content.ID]
+			if ok && answer.TextHTML == "" {
+				answer.TextHTML = content.TextHTML
+				answer.Version++
+				s.storageAnswers.db[content.ID] = answer
+			}
+			continue
+		}
+		question, ok := s.db[content.ID]
+		if ok && question.TextHTML == "" {
+			question.TextHTML = content.TextHTML
+			question.Version++
+			s.db[content.ID] = question
+		}
+	}
+	return nil
+}
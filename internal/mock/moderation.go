@@ -0,0 +1,115 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+func(s *MockStorageQuestions) MergeQuestions(ctx context.Context, id, target, version int, userID string) (models.QuestionsMerged, error) {
+	merged, ok := s.db[id]
+	if !ok || version != 0 && merged.Version != version {
+		return models.QuestionsMerged{}, gorm.ErrRecordNotFound
+	}
+	question, err := s.canonical(target, id)
+	if err != nil {
+		return models.QuestionsMerged{}, err
+	}
+	target = question.ID
+	answers := s.storageAnswers
+	targetAccepted := answers.hasAccepted(target)
+	ids := make([]int, 0)
+	res := models.QuestionsMerged{Question: question, Answers: make([]models.Answer, 0)}
+	for ind, v := range answers.db {
+		if v.QuestionID != id {
+			continue
+		}
+		if v.Accepted && targetAccepted {
+			v.Accepted = false
+			answers.outbox.Write(events.NewAnswerUnaccepted(models.AnswerUnaccepted{Answer: v, QuestionUserID: answers.questionAuthor(id)}))
+		}
+		v.QuestionID = target
+		v.Version++
+		answers.db[ind] = v
+		ids = append(ids, v.ID)
+		res.Answers = append(res.Answers, v)
+	}
+	sort.Ints(ids)
+	if len(ids) != 0 {
+		answers.bumpQuestion(target)
+		res.Question = s.db[target]
+	}
+	sort.Slice(res.Answers, func(i, j int) bool { return res.Answers[i].ID < res.Answers[j].ID })
+
+	merged.DuplicateOf = &target
+	merged.Version++
+	s.db[id] = merged
+	res.Merged = merged
+	for ind, v := range s.db {
+		if v.DuplicateOf != nil && *v.DuplicateOf == id {
+			v.DuplicateOf = &target
+			v.Version++
+			s.db[ind] = v
+			res.Repointed = append(res.Repointed, ind)
+		}
+	}
+	sort.Ints(res.Repointed)
+
+	details, _ := json.Marshal(map[string]any{"target": target, "answers": ids})
+	answers.writeAudit(models.AuditEntry{Action: models.AuditQuestionMerged, UserID: userID, QuestionID: id, Details: details})
+	answers.outbox.Write(events.NewQuestionMerged(res))
+	return res, nil
+}
+
+// MoveAnswer does not check the target question, which the answers mock does
+// not know; the service checks it first.
+func(s *MockStorageAnswers) MoveAnswer(ctx context.Context, id, target, version int, userID string) (models.AnswerMoved, error) {
+	answer, ok := s.db[id]
+	if !ok || version != 0 && answer.Version != version {
+		return models.AnswerMoved{}, gorm.ErrRecordNotFound
+	}
+	res := models.AnswerMoved{Answer: answer, From: answer.QuestionID}
+	if answer.QuestionID == target {
+		return res, nil
+	}
+	unaccepted := answer.Accepted && s.hasAccepted(target)
+	answer.Accepted = answer.Accepted && !unaccepted
+	s.bumpQuestion(res.From)
+	s.bumpQuestion(target)
+	answer.QuestionID = target
+	answer.Version++
+	s.db[id] = answer
+	res.Answer = answer
+	if unaccepted {
+		s.outbox.Write(events.NewAnswerUnaccepted(models.AnswerUnaccepted{Answer: answer, QuestionUserID: s.questionAuthor(res.From)}))
+	}
+
+	details, _ := json.Marshal(map[string]any{"from": res.From, "to": target})
+	s.writeAudit(models.AuditEntry{Action: models.AuditAnswerMoved, UserID: userID, QuestionID: res.From, AnswerID: id, Details: details})
+	s.outbox.Write(events.NewAnswerMoved(res))
+	return res, nil
+}
+
+// Audit returns the moderation actions recorded by both mocks.
+func(s *MockStorageAnswers) Audit() []models.AuditEntry {
+	return s.audit
+}
+
+func(s *MockStorageAnswers) writeAudit(entry models.AuditEntry) {
+	entry.ID = int64(len(s.audit) + 1)
+	entry.CreatedAt = defaultTime()
+	s.audit = append(s.audit, entry)
+}
+
+func(s *MockStorageAnswers) hasAccepted(questionID int) bool {
+	for _, v := range s.db {
+		if v.QuestionID == questionID && v.Accepted {
+			return true
+		}
+	}
+	return false
+}
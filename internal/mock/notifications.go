@@ -0,0 +1,178 @@
+package mock
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// MockStorageNotifications keeps notifications, followers and preferences
+// in memory next to the questions mock.
+type MockStorageNotifications struct {
+	questions *MockStorageQuestions
+	notifications map[int64]models.Notification
+	followers map[int]map[string]struct{}
+	// tags are the tags each user follows.
+	tags map[string]map[string]struct{}
+	preferences map[string]models.NotificationPreferences
+	id int64
+}
+
+func NewMockStorageNotifications(questions *MockStorageQuestions) *MockStorageNotifications {
+	return &MockStorageNotifications{
+		questions: questions,
+		notifications: make(map[int64]models.Notification),
+		followers: make(map[int]map[string]struct{}),
+		tags: make(map[string]map[string]struct{}),
+		preferences: make(map[string]models.NotificationPreferences),
+	}
+}
+
+func(s *MockStorageNotifications) QuestionAudience(ctx context.Context, questionID int) (string, []string, error) {
+	question, ok := s.questions.db[questionID]
+	if !ok {
+		return "", nil, gorm.ErrRecordNotFound
+	}
+	followers := make([]string, 0, len(s.followers[questionID]))
+	for userID := range s.followers[questionID] {
+		followers = append(followers, userID)
+	}
+	sort.Strings(followers)
+	var author string
+	if question.UserID != nil {
+		author = *question.UserID
+	}
+	return author, followers, nil
+}
+
+func(s *MockStorageNotifications) NotificationPreferences(ctx context.Context, userIDs []string) ([]models.NotificationPreferences, error) {
+	res := make([]models.NotificationPreferences, 0)
+	for _, userID := range userIDs {
+		if preferences, ok := s.preferences[userID]; ok {
+			res = append(res, preferences)
+		}
+	}
+	return res, nil
+}
+
+func(s *MockStorageNotifications) SaveNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	preferences.UpdatedAt = defaultTime()
+	s.preferences[preferences.UserID] = *preferences
+	return nil
+}
+
+func(s *MockStorageNotifications) VerifyNotificationEmail(ctx context.Context, tokenHash string, at time.Time) (int, error) {
+	for userID, preferences := range s.preferences {
+		if preferences.EmailToken != "" && preferences.EmailToken == tokenHash {
+			preferences.EmailVerifiedAt = &at
+			preferences.EmailToken = ""
+			s.preferences[userID] = preferences
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func(s *MockStorageNotifications) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+	for _, notification := range notifications {
+		if s.exists(notification.UserID, notification.EventID) {
+			continue
+		}
+		s.id += 1
+		notification.ID = s.id
+		notification.CreatedAt = defaultTime()
+		s.notifications[notification.ID] = notification
+	}
+	return nil
+}
+
+func(s *MockStorageNotifications) Notifications(ctx context.Context, userID string, unread bool, page models.Page) ([]models.Notification, error) {
+	res := make([]models.Notification, 0)
+	for _, notification := range s.notifications {
+		if notification.UserID != userID || unread && notification.ReadAt != nil {
+			continue
+		}
+		res = append(res, notification)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID > res[j].ID })
+	return paginate(res, page), nil
+}
+
+func(s *MockStorageNotifications) UnreadNotifications(ctx context.Context, userID string) (int, error) {
+	count := 0
+	for _, notification := range s.notifications {
+		if notification.UserID == userID && notification.ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func(s *MockStorageNotifications) MarkNotificationsRead(ctx context.Context, userID string, id int64, at time.Time) (int, error) {
+	if id != 0 {
+		notification, ok := s.notifications[id]
+		if !ok || notification.UserID != userID {
+			return 0, gorm.ErrRecordNotFound
+		}
+	}
+	marked := 0
+	for ind, notification := range s.notifications {
+		if notification.UserID != userID || notification.ReadAt != nil || id != 0 && ind != id {
+			continue
+		}
+		notification.ReadAt = &at
+		s.notifications[ind] = notification
+		marked++
+	}
+	return marked, nil
+}
+
+func(s *MockStorageNotifications) FollowQuestion(ctx context.Context, questionID int, userID string) error {
+	if _, ok := s.questions.db[questionID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if s.followers[questionID] == nil {
+		s.followers[questionID] = make(map[string]struct{})
+	}
+	s.followers[questionID][userID] = struct{}{}
+	return nil
+}
+
+func(s *MockStorageNotifications) UnfollowQuestion(ctx context.Context, questionID int, userID string) error {
+	delete(s.followers[questionID], userID)
+	return nil
+}
+
+func(s *MockStorageNotifications) exists(userID string, eventID int64) bool {
+	for _, notification := range s.notifications {
+		if notification.UserID == userID && notification.EventID == eventID {
+			return true
+		}
+	}
+	return false
+}
+
+func(s *MockStorageNotifications) FollowTag(ctx context.Context, tag, userID string) error {
+	if s.tags[userID] == nil {
+		s.tags[userID] = make(map[string]struct{})
+	}
+	s.tags[userID][tag] = struct{}{}
+	return nil
+}
+
+func(s *MockStorageNotifications) UnfollowTag(ctx context.Context, tag, userID string) error {
+	delete(s.tags[userID], tag)
+	return nil
+}
+
+func(s *MockStorageNotifications) FollowedTags(ctx context.Context, userID string) ([]string, error) {
+	res := make([]string, 0, len(s.tags[userID]))
+	for tag := range s.tags[userID] {
+		res = append(res, tag)
+	}
+	sort.Strings(res)
+	return res, nil
+}
@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// MockOutbox is the in-memory outbox shared by the question and answer
+// mocks. It is safe for concurrent use by the events dispatcher.
+type MockOutbox struct {
+	mu sync.Mutex
+	rows []models.OutboxEvent
+	id int64
+}
+
+func NewMockOutbox() *MockOutbox {
+	return &MockOutbox{}
+}
+
+func(o *MockOutbox) Write(event events.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.id += 1
+	row := event.Outbox()
+	row.ID = o.id
+	row.CreatedAt = defaultTime()
+	o.rows = append(o.rows, row)
+}
+
+func(o *MockOutbox) PendingOutbox(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	res := make([]models.OutboxEvent, 0)
+	for _, v := range o.rows {
+		if v.ProcessedAt == nil && len(res) < limit {
+			res = append(res, v)
+		}
+	}
+	return res, nil
+}
+
+// ClaimOutbox leases pending events like the storage does; a lease that ran
+// out makes the event claimable again.
+func(o *MockOutbox) ClaimOutbox(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.OutboxEvent, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	res := make([]models.OutboxEvent, 0)
+	for ind, v := range o.rows {
+		if len(res) == limit {
+			break
+		}
+		if v.ProcessedAt != nil || v.FailedAt != nil || v.LockedUntil != nil && v.LockedUntil.After(now) {
+			continue
+		}
+		lockedUntil := now.Add(lease)
+		o.rows[ind].LockedUntil = &lockedUntil
+		o.rows[ind].Attempts++
+		res = append(res, o.rows[ind])
+	}
+	return res, nil
+}
+
+func(o *MockOutbox) MarkOutboxProcessed(ctx context.Context, id int64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for ind := range o.rows {
+		if o.rows[ind].ID == id {
+			now := time.Now()
+			o.rows[ind].ProcessedAt = &now
+		}
+	}
+	return nil
+}
+
+func(o *MockOutbox) MarkOutboxFailed(ctx context.Context, id int64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for ind := range o.rows {
+		if o.rows[ind].ID == id {
+			now := time.Now()
+			o.rows[ind].FailedAt = &now
+		}
+	}
+	return nil
+}
+
+func(o *MockOutbox) OutboxAfter(ctx context.Context, id int64, limit int) ([]models.OutboxEvent, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	res := make([]models.OutboxEvent, 0)
+	for _, v := range o.rows {
+		if v.ID > id && len(res) < limit {
+			res = append(res, v)
+		}
+	}
+	return res, nil
+}
+
+func(o *MockOutbox) OutboxCursor(ctx context.Context) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, v := range o.rows {
+		if v.ProcessedAt == nil && v.FailedAt == nil {
+			return v.ID - 1, nil
+		}
+	}
+	return o.id, nil
+}
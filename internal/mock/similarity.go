@@ -0,0 +1,100 @@
+package mock
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+func(s *MockStorageQuestions) SimilarQuestions(ctx context.Context, text string, threshold float64, limit int) ([]models.SimilarQuestion, error) {
+	res := make([]models.SimilarQuestion, 0)
+	for _, v := range s.db {
+		if v.DuplicateOf != nil {
+			continue
+		}
+		if similarity := similarity(text, v.Text); similarity >= threshold {
+			res = append(res, models.SimilarQuestion{ID: v.ID, Text: v.Text, Similarity: similarity})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Similarity != res[j].Similarity {
+			return res[i].Similarity > res[j].Similarity
+		}
+		return res[i].ID < res[j].ID
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func(s *MockStorageQuestions) MarkDuplicate(ctx context.Context, id, target, version int) (models.Question, error) {
+	question, ok := s.db[id]
+	if !ok || version != 0 && question.Version != version {
+		return question, gorm.ErrRecordNotFound
+	}
+	canonical, err := s.canonical(target, id)
+	if err != nil {
+		return models.Question{}, err
+	}
+	target = canonical.ID
+	question.DuplicateOf = &target
+	question.Version++
+	s.db[id] = question
+	s.storageAnswers.outbox.Write(events.NewQuestionMarkedDuplicate(question))
+	return question, nil
+}
+
+// canonical follows the chain of duplicates from target as the storage
+// does.
+func(s *MockStorageQuestions) canonical(target, id int) (models.Question, error) {
+	for i := 0; i < models.MaxDuplicateHops; i++ {
+		if target == id {
+			return models.Question{}, models.ErrDuplicateChain
+		}
+		question, ok := s.db[target]
+		if !ok {
+			return question, gorm.ErrRecordNotFound
+		}
+		if question.DuplicateOf == nil {
+			return question, nil
+		}
+		target = *question.DuplicateOf
+	}
+	return models.Question{}, models.ErrDuplicateChain
+}
+
+// similarity is the pg_trgm similarity: the share of trigrams of the words,
+// lowercased and padded with spaces, that the texts have in common.
+func similarity(a, b string) float64 {
+	first, second := trigrams(a), trigrams(b)
+	if len(first) == 0 || len(second) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range first {
+		if _, ok := second[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(first) + len(second) - shared)
+}
+
+func trigrams(text string) map[string]struct{} {
+	res := make(map[string]struct{})
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i + 3 <= len(padded); i++ {
+			res[string(padded[i:i + 3])] = struct{}{}
+		}
+	}
+	return res
+}
@@ -3,6 +3,8 @@ package mock
 import (
 	"time"
 	"context"
+	"sort"
+	"github.com/behummble/Questions-answers/internal/events"
 	"github.com/behummble/Questions-answers/internal/models"
 	"gorm.io/gorm"
 )
@@ -16,33 +18,78 @@ type MockStorageQuestions struct {
 type MockStorageAnswers struct {
 	db map[int]models.Answer
 	id int
+	outbox *MockOutbox
+	users map[string]models.User
+	votes map[voteKey]int
+	audit []models.AuditEntry
+	// questions is the db of the questions mock, whose versions change
+	// with their answers.
+	questions map[int]models.Question
+}
+
+type voteKey struct {
+	answerID int
+	userID string
 }
 
 func NewMockStorageAnswers(len int) *MockStorageAnswers {
 	return &MockStorageAnswers{
 		db: make(map[int]models.Answer, len),
+		outbox: NewMockOutbox(),
+		users: make(map[string]models.User),
+		votes: make(map[voteKey]int),
 	}
 }
 
+// Outbox returns the outbox written by both mocks.
+func(s *MockStorageAnswers) Outbox() *MockOutbox {
+	return s.outbox
+}
+
 func NewMockStorageQuestions(len int, storageAnswers *MockStorageAnswers) *MockStorageQuestions {
+	db := make(map[int]models.Question, len)
+	storageAnswers.questions = db
 	return &MockStorageQuestions{
-		db: make(map[int]models.Question, len),
+		db: db,
 		storageAnswers: storageAnswers,
 	}
 }
 
 func(s *MockStorageAnswers) CreateAnswer(ctx context.Context, data []*models.Answer) error {
 	for _, v := range data {
+		s.bumpQuestion(v.QuestionID)
 		s.id += 1
 		ind := s.id
 		v.CreatedAt = defaultTime()
 		v.ID = ind
+		v.Author = s.author(v.UserID)
 		s.db[ind] = *v
+		s.outbox.Write(events.NewAnswerCreated(*v))
 	}
 	
 	return nil
 }
 
+// questionAuthor is the user who asked the question, or "".
+func(s *MockStorageAnswers) questionAuthor(id int) string {
+	question, ok := s.questions[id]
+	if !ok || question.UserID == nil {
+		return ""
+	}
+	return *question.UserID
+}
+
+// bumpQuestion raises the version of the question as the postgres storage
+// does when its answers change.
+func(s *MockStorageAnswers) bumpQuestion(id int) {
+	question, ok := s.questions[id]
+	if !ok {
+		return
+	}
+	question.Version++
+	s.questions[id] = question
+}
+
 func(s *MockStorageAnswers) GetAnswer(ctx context.Context, id int) (models.Answer, error) {
 	res, ok := s.db[id]
 	if !ok {
@@ -52,20 +99,81 @@ func(s *MockStorageAnswers) GetAnswer(ctx context.Context, id int) (models.Answe
 	return res, nil
 }
 
-func(s *MockStorageAnswers) DeleteAnswer(ctx context.Context, id int) (int, error){
-	if _, ok := s.db[id]; !ok {
+func(s *MockStorageAnswers) DeleteAnswer(ctx context.Context, id, version int) (int, error){
+	answer, ok := s.db[id]
+	if !ok {
 		return 0, gorm.ErrRecordNotFound
 	}
+	if version != 0 && answer.Version != version {
+		return 0, nil
+	}
 	delete(s.db, id)
+	s.bumpQuestion(answer.QuestionID)
+	s.outbox.Write(events.NewAnswerDeleted(answer))
 	return 1, nil
 }
 
+func(s *MockStorageAnswers) VoteAnswer(ctx context.Context, vote *models.Vote) (models.AnswerVoted, error) {
+	answer, ok := s.db[vote.AnswerID]
+	if !ok {
+		return models.AnswerVoted{}, gorm.ErrRecordNotFound
+	}
+	key := voteKey{answerID: vote.AnswerID, userID: vote.UserID}
+	previous := s.votes[key]
+	res := models.AnswerVoted{Answer: answer, VoterID: vote.UserID, Value: vote.Value, Previous: previous}
+	if vote.Value == previous {
+		return res, nil
+	}
+	s.author(vote.UserID)
+	if vote.Value == 0 {
+		delete(s.votes, key)
+	} else {
+		s.votes[key] = vote.Value
+	}
+	answer.Score += vote.Value - previous
+	answer.Version++
+	s.db[answer.ID] = answer
+	res.Answer = answer
+	s.outbox.Write(events.NewAnswerVoted(res))
+	return res, nil
+}
+
+func(s *MockStorageAnswers) AcceptAnswer(ctx context.Context, id int) (models.AnswerAccepted, error) {
+	answer, ok := s.db[id]
+	if !ok {
+		return models.AnswerAccepted{}, gorm.ErrRecordNotFound
+	}
+	res := models.AnswerAccepted{Answer: answer}
+	if answer.Accepted {
+		return res, nil
+	}
+	res.QuestionUserID = s.questionAuthor(answer.QuestionID)
+	for ind, v := range s.db {
+		if v.QuestionID == answer.QuestionID && v.Accepted {
+			v.Accepted = false
+			v.Version++
+			s.db[ind] = v
+			res.Previous = &v
+		}
+	}
+	answer.Accepted = true
+	answer.Version++
+	s.db[id] = answer
+	res.Answer = answer
+	s.outbox.Write(events.NewAnswerAccepted(res))
+	return res, nil
+}
+
 func(s *MockStorageQuestions) CreateQuestion(ctx context.Context, data *models.Question) error {
 	s.id += 1
 	ind := s.id
 	data.CreatedAt = defaultTime()
 	data.ID = ind
+	if data.UserID != nil {
+		s.storageAnswers.author(*data.UserID)
+	}
 	s.db[ind] = *data
+	s.storageAnswers.outbox.Write(events.NewQuestionCreated(*data))
 	return nil
 }
 
@@ -80,31 +188,57 @@ func(s *MockStorageQuestions) Question(ctx context.Context, id int) (models.Ques
 	return models.QuestionWithAnswers{Question: res, Answers: answers}, nil
 }
 
-func(s *MockStorageQuestions) AllQuestions(ctx context.Context) ([]models.Question, error) {
+func(s *MockStorageQuestions) AllQuestions(ctx context.Context, order models.QuestionSort) ([]models.Question, error) {
 	res := make([]models.Question, 0, len(s.db))
 	for _, v := range s.db {
 		res = append(res, v)
 	}
+	sort.Slice(res, func(i, j int) bool {
+		if order == models.SortViews && res[i].ViewCount != res[j].ViewCount {
+			return res[i].ViewCount > res[j].ViewCount
+		}
+		return res[i].ID < res[j].ID
+	})
 
 	return res, nil
 }
 
-func(s *MockStorageQuestions) DeleteQuestion(ctx context.Context, id int) (int, error) {
-	if _, ok := s.db[id]; !ok {
-		return 0, gorm.ErrRecordNotFound
+func(s *MockStorageQuestions) StreamQuestions(ctx context.Context, order models.QuestionSort, yield func(models.Question) error) error {
+	questions, _ := s.AllQuestions(ctx, order)
+	for _, question := range questions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := yield(question); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func(s *MockStorageQuestions) DeleteQuestion(ctx context.Context, id, version int) (models.QuestionDelete, error) {
+	res := models.QuestionDelete{ID: id, Version: version}
+	question, ok := s.db[id]
+	if !ok {
+		return res, gorm.ErrRecordNotFound
+	}
+	if version != 0 && question.Version != version {
+		return res, nil
 	}
 	delete(s.db, id)
-	s.storageAnswers.DeleteAllAnswers(id)
-	return 1, nil
+	res.AnswerIDs = s.storageAnswers.DeleteAllAnswers(id)
+	res.Deleted = true
+	s.storageAnswers.outbox.Write(events.NewQuestionDeleted(question))
+	return res, nil
 }
 
 func(s *MockStorageQuestions) Exist(ctx context.Context, id int) (models.Question, error) {
-	_, ok := s.db[id]
+	res, ok := s.db[id]
 	
 	if !ok {
 		return models.Question{}, gorm.ErrRecordNotFound
 	}
-	return models.Question{}, nil
+	return res, nil
 }
 
 func(s *MockStorageAnswers) AllAnswers(questionID int) []models.Answer {
@@ -114,12 +248,28 @@ func(s *MockStorageAnswers) AllAnswers(questionID int) []models.Answer {
 			res = append(res, v)
 		}
 	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
 
 	return res
 }
 
-func(s *MockStorageAnswers) DeleteAllAnswers(questionID int) {
-	del := make([]int, len(s.db))
+func(s *MockStorageAnswers) StreamAnswers(ctx context.Context, questionID int, yield func(models.Answer) error) error {
+	answers := s.AllAnswers(questionID)
+	for _, answer := range answers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := yield(answer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func(s *MockStorageAnswers) DeleteAllAnswers(questionID int) []int {
+	del := make([]int, 0)
 	for ind, v := range s.db {
 		if v.QuestionID == questionID {
 			del = append(del, ind)
@@ -128,6 +278,18 @@ func(s *MockStorageAnswers) DeleteAllAnswers(questionID int) {
 	for _, v := range del {
 		delete(s.db, v)
 	}
+	sort.Ints(del)
+	return del
+}
+
+// author creates an empty profile for a new user, as the real storage does.
+func(s *MockStorageAnswers) author(id string) *models.Author {
+	user, ok := s.users[id]
+	if !ok {
+		user = models.User{ID: id, CreatedAt: defaultTime(), UpdatedAt: defaultTime()}
+		s.users[id] = user
+	}
+	return &models.Author{ID: user.ID, DisplayName: user.DisplayName, AvatarURL: user.AvatarURL, UpdatedAt: user.UpdatedAt}
 }
 
 func(s *MockStorageAnswers) Shutdown(ctx context.Context) {
@@ -140,4 +302,31 @@ func(s *MockStorageQuestions) Shutdown(ctx context.Context) {
 
 func defaultTime() time.Time {
 	return time.Date(2000, time.January, 1, 8, 8, 8, 8, time.UTC)
-}
\ No newline at end of file
+}
+func(s *MockStorageQuestions) CreateQuestions(ctx context.Context, data []*models.Question) error {
+	for _, question := range data {
+		s.CreateQuestion(ctx, question)
+	}
+	return nil
+}
+
+// WriteQuestions checks every delete before writing, so a batch that fails
+// leaves the storage as it was.
+func(s *MockStorageQuestions) WriteQuestions(ctx context.Context, batch *models.QuestionBatch) error {
+	seen := make(map[int]struct{}, len(batch.Delete))
+	for i, item := range batch.Delete {
+		question, ok := s.db[item.ID]
+		_, twice := seen[item.ID]
+		if !ok || twice || item.Version != 0 && question.Version != item.Version {
+			return gorm.ErrRecordNotFound
+		}
+		seen[item.ID] = struct{}{}
+		batch.Delete[i].Deleted = true
+	}
+	s.CreateQuestions(ctx, batch.Create)
+	for i, item := range batch.Delete {
+		deleted, _ := s.DeleteQuestion(ctx, item.ID, item.Version)
+		batch.Delete[i].AnswerIDs = deleted.AnswerIDs
+	}
+	return nil
+}
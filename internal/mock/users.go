@@ -0,0 +1,178 @@
+package mock
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// MockStorageUsers serves the users kept by the answers mock, which creates
+// them on the first answer like the real storage.
+type MockStorageUsers struct {
+	questions *MockStorageQuestions
+	ledger []models.ReputationEvent
+}
+
+func NewMockStorageUsers(questions *MockStorageQuestions) *MockStorageUsers {
+	return &MockStorageUsers{questions: questions}
+}
+
+func(s *MockStorageUsers) User(ctx context.Context, id string) (models.User, error) {
+	res, ok := s.answers().users[id]
+	if !ok {
+		return res, gorm.ErrRecordNotFound
+	}
+	return res, nil
+}
+
+func(s *MockStorageUsers) SaveUser(ctx context.Context, data *models.User) error {
+	answers := s.answers()
+	if current, ok := answers.users[data.ID]; ok {
+		data.CreatedAt = current.CreatedAt
+		data.UpdatedAt = current.UpdatedAt.Add(time.Second)
+	} else {
+		data.CreatedAt = defaultTime()
+		data.UpdatedAt = defaultTime()
+	}
+	answers.users[data.ID] = *data
+	for ind, v := range answers.db {
+		if v.UserID == data.ID {
+			v.Author = answers.author(data.ID)
+			answers.db[ind] = v
+		}
+	}
+	return nil
+}
+
+func(s *MockStorageUsers) AuthoredAnswers(ctx context.Context, id string) ([]models.Answer, error) {
+	res := make([]models.Answer, 0)
+	for _, v := range s.answers().db {
+		if v.UserID == id {
+			res = append(res, models.Answer{ID: v.ID, QuestionID: v.QuestionID})
+		}
+	}
+	return res, nil
+}
+
+func(s *MockStorageUsers) UserAnswers(ctx context.Context, id string, page models.Page) ([]models.Answer, error) {
+	res := make([]models.Answer, 0)
+	for _, v := range s.answers().db {
+		if v.UserID == id {
+			res = append(res, v)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID > res[j].ID })
+	return paginate(res, page), nil
+}
+
+func(s *MockStorageUsers) UserQuestions(ctx context.Context, id string, page models.Page) ([]models.Question, error) {
+	res := make([]models.Question, 0)
+	for _, v := range s.questions.db {
+		if v.UserID != nil && *v.UserID == id {
+			res = append(res, v)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID > res[j].ID })
+	return paginate(res, page), nil
+}
+
+func(s *MockStorageUsers) Leaderboard(ctx context.Context, page models.Page) ([]models.User, error) {
+	res := make([]models.User, 0, len(s.answers().users))
+	for _, v := range s.answers().users {
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Reputation != res[j].Reputation {
+			return res[i].Reputation > res[j].Reputation
+		}
+		return res[i].ID < res[j].ID
+	})
+	return paginate(res, page), nil
+}
+
+func(s *MockStorageUsers) ReputationEvents(ctx context.Context, id string, page models.Page) ([]models.ReputationEvent, error) {
+	res := make([]models.ReputationEvent, 0)
+	for _, v := range s.ledger {
+		if v.UserID == id {
+			res = append(res, v)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID > res[j].ID })
+	return paginate(res, page), nil
+}
+
+func(s *MockStorageUsers) RecordReputation(ctx context.Context, entries []models.ReputationEvent) error {
+	users := s.answers().users
+	for _, entry := range entries {
+		if s.recorded(entry) {
+			continue
+		}
+		entry.ID = int64(len(s.ledger) + 1)
+		entry.CreatedAt = defaultTime()
+		s.ledger = append(s.ledger, entry)
+		user := users[entry.UserID]
+		user.Reputation += entry.Points
+		users[entry.UserID] = user
+	}
+	return nil
+}
+
+func(s *MockStorageUsers) RecomputeReputation(ctx context.Context) error {
+	users := s.answers().users
+	for id, user := range users {
+		user.Reputation = 0
+		users[id] = user
+	}
+	for _, entry := range s.ledger {
+		user := users[entry.UserID]
+		user.Reputation += entry.Points
+		users[entry.UserID] = user
+	}
+	return nil
+}
+
+func(s *MockStorageUsers) recorded(entry models.ReputationEvent) bool {
+	for _, v := range s.ledger {
+		if v.EventID == entry.EventID && v.UserID == entry.UserID && v.Reason == entry.Reason {
+			return true
+		}
+	}
+	return false
+}
+
+func(s *MockStorageUsers) answers() *MockStorageAnswers {
+	return s.questions.storageAnswers
+}
+
+func paginate[T any](items []T, page models.Page) []T {
+	if page.Offset >= len(items) {
+		return items[:0]
+	}
+	items = items[page.Offset:]
+	if len(items) > page.Limit {
+		items = items[:page.Limit]
+	}
+	return items
+}
+
+func(s *MockStorageUsers) UsersByID(ctx context.Context, ids []string) ([]models.User, error) {
+	res := make([]models.User, 0, len(ids))
+	for _, id := range ids {
+		if user, ok := s.answers().users[id]; ok {
+			res = append(res, user)
+		}
+	}
+	return res, nil
+}
+
+func(s *MockStorageUsers) AnswersByUser(ctx context.Context, ids []string, page models.Page) ([]models.Answer, error) {
+	res := make([]models.Answer, 0)
+	for _, id := range ids {
+		answers, _ := s.UserAnswers(ctx, id, page)
+		res = append(res, answers...)
+	}
+	return res, nil
+}
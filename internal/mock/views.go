@@ -0,0 +1,59 @@
+package mock
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// MockStorageViews counts views on the questions mock and keeps the
+// trending ranks the real storage keeps in a column.
+type MockStorageViews struct {
+	questions *MockStorageQuestions
+	ranks map[int]float64
+	flushes int
+}
+
+func NewMockStorageViews(questions *MockStorageQuestions) *MockStorageViews {
+	return &MockStorageViews{
+		questions: questions,
+		ranks: make(map[int]float64),
+	}
+}
+
+func(s *MockStorageViews) AddViews(ctx context.Context, views map[int]int, weight float64) error {
+	s.flushes++
+	for id, n := range views {
+		question, ok := s.questions.db[id]
+		if !ok {
+			continue
+		}
+		question.ViewCount += n
+		s.questions.db[id] = question
+		s.ranks[id] = math.Log(math.Exp(s.ranks[id] - weight) + float64(n)) + weight
+	}
+	return nil
+}
+
+func(s *MockStorageViews) TrendingQuestions(ctx context.Context, page models.Page) ([]models.Question, error) {
+	res := make([]models.Question, 0)
+	for id := range s.ranks {
+		if question, ok := s.questions.db[id]; ok {
+			res = append(res, question)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if s.ranks[res[i].ID] != s.ranks[res[j].ID] {
+			return s.ranks[res[i].ID] > s.ranks[res[j].ID]
+		}
+		return res[i].ID < res[j].ID
+	})
+	return paginate(res, page), nil
+}
+
+// Flushes is the number of batches written.
+func(s *MockStorageViews) Flushes() int {
+	return s.flushes
+}
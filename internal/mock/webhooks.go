@@ -0,0 +1,166 @@
+package mock
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// MockStorageWebhooks keeps webhooks and deliveries in memory.
+// Unlike the question and answer mocks it is safe for concurrent use, since
+// the dispatcher runs in its own goroutine.
+type MockStorageWebhooks struct {
+	mu sync.Mutex
+	webhooks map[int]models.Webhook
+	deliveries map[int]models.WebhookDelivery
+	webhookID int
+	deliveryID int
+}
+
+func NewMockStorageWebhooks() *MockStorageWebhooks {
+	return &MockStorageWebhooks{
+		webhooks: make(map[int]models.Webhook),
+		deliveries: make(map[int]models.WebhookDelivery),
+	}
+}
+
+func(s *MockStorageWebhooks) CreateWebhook(ctx context.Context, data *models.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.webhookID += 1
+	data.ID = s.webhookID
+	data.CreatedAt = defaultTime()
+	data.UpdatedAt = defaultTime()
+	s.webhooks[data.ID] = *data
+	return nil
+}
+
+func(s *MockStorageWebhooks) Webhook(ctx context.Context, id int) (models.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, ok := s.webhooks[id]
+	if !ok {
+		return res, gorm.ErrRecordNotFound
+	}
+	return res, nil
+}
+
+func(s *MockStorageWebhooks) AllWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]models.Webhook, 0, len(s.webhooks))
+	for _, v := range s.webhooks {
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
+func(s *MockStorageWebhooks) UpdateWebhook(ctx context.Context, data *models.Webhook) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.webhooks[data.ID]; !ok {
+		return 0, nil
+	}
+	s.webhooks[data.ID] = *data
+	return 1, nil
+}
+
+func(s *MockStorageWebhooks) DeleteWebhook(ctx context.Context, id int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.webhooks[id]; !ok {
+		return 0, nil
+	}
+	delete(s.webhooks, id)
+	for ind, v := range s.deliveries {
+		if v.WebhookID == id {
+			delete(s.deliveries, ind)
+		}
+	}
+	return 1, nil
+}
+
+func(s *MockStorageWebhooks) Deliveries(ctx context.Context, webhookID, limit int) ([]models.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]models.WebhookDelivery, 0)
+	for _, v := range s.deliveries {
+		if v.WebhookID == webhookID {
+			res = append(res, v)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID > res[j].ID })
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func(s *MockStorageWebhooks) EnqueueDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, delivery := range deliveries {
+		if s.hasDelivery(delivery.WebhookID, delivery.EventID) {
+			continue
+		}
+		s.deliveryID += 1
+		delivery.ID = s.deliveryID
+		delivery.CreatedAt = defaultTime()
+		s.deliveries[delivery.ID] = *delivery
+	}
+	return nil
+}
+
+func(s *MockStorageWebhooks) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]models.WebhookDelivery, 0)
+	for ind, v := range s.deliveries {
+		if v.Status != models.DeliveryPending || v.NextAttemptAt.After(now) || len(res) == limit {
+			continue
+		}
+		v.NextAttemptAt = now.Add(lease)
+		s.deliveries[ind] = v
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
+func(s *MockStorageWebhooks) SaveDelivery(ctx context.Context, data *models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries[data.ID] = *data
+	return nil
+}
+
+func(s *MockStorageWebhooks) RecordWebhookResult(ctx context.Context, id int, success bool, disableAfter int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	webhook, ok := s.webhooks[id]
+	if !ok {
+		return nil
+	}
+	if success {
+		webhook.FailureCount = 0
+	} else {
+		webhook.FailureCount += 1
+		webhook.Active = webhook.Active && webhook.FailureCount < disableAfter
+	}
+	s.webhooks[id] = webhook
+	return nil
+}
+
+func(s *MockStorageWebhooks) hasDelivery(webhookID int, eventID int64) bool {
+	for _, v := range s.deliveries {
+		if v.WebhookID == webhookID && v.EventID == eventID {
+			return true
+		}
+	}
+	return false
+}
@@ -8,7 +8,13 @@ type Answer struct {
 	ID int
 	QuestionID int
 	UserID string
+	Author *Author `gorm:"foreignKey:UserID" json:",omitempty"`
+	// Text is the Markdown source and TextHTML its sanitized rendering.
 	Text string
+	TextHTML string `json:",omitempty"`
+	Score int
+	Accepted bool
+	Version int
 	CreatedAt time.Time
 }
 
@@ -23,4 +29,53 @@ type CreateAnswerResponse struct {
 
 type GetAnswerResponse struct {
 	Answer Answer
-}
\ No newline at end of file
+}
+
+type GetAnswersResponse struct {
+	Answers []Answer
+}
+
+type Vote struct {
+	AnswerID int
+	UserID string
+	Value int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// VoteRequest sets the vote of a user: 1 up, -1 down, 0 to retract.
+type VoteRequest struct {
+	UserID string
+	Value int
+}
+
+type VoteResponse struct {
+	Answer Answer
+	Value int
+}
+
+// AnswerVoted is the payload of answer.voted events. Previous is the vote
+// the user had before, so consumers can revert its effect.
+type AnswerVoted struct {
+	Answer Answer
+	VoterID string
+	Value int
+	Previous int
+}
+
+// AnswerAccepted is the payload of answer.accepted events. Previous is the
+// answer that was accepted before, if any. QuestionUserID is the author of
+// the question, who earns nothing for accepting their own answer.
+type AnswerAccepted struct {
+	Answer Answer
+	Previous *Answer `json:",omitempty"`
+	QuestionUserID string `json:",omitempty"`
+}
+
+// AnswerUnaccepted is the payload of answer.unaccepted events: the answer,
+// with QuestionUserID as in AnswerAccepted for the question it was accepted
+// on.
+type AnswerUnaccepted struct {
+	Answer
+	QuestionUserID string `json:",omitempty"`
+}
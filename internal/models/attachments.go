@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// Attachment is a file attached to a question or an answer. The file itself
+// is a blob stored under BlobKey; URL is a signed, expiring link to it.
+type Attachment struct {
+	ID int
+	QuestionID *int `json:",omitempty"`
+	AnswerID *int `json:",omitempty"`
+	UserID string
+	Filename string
+	ContentType string
+	Size int64
+	BlobKey string `json:"-"`
+	URL string `gorm:"-"`
+	CreatedAt time.Time
+}
+
+type CreateAttachmentResponse struct {
+	Attachment Attachment
+}
+
+type GetAttachmentsResponse struct {
+	Attachments []Attachment
+}
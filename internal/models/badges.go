@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+type Badge struct {
+	ID int
+	Name string
+	Title string
+	Description string
+	CreatedAt time.Time
+}
+
+type UserBadge struct {
+	UserID string
+	BadgeID int
+	Badge Badge `gorm:"foreignKey:BadgeID"`
+	EventID int64
+	AwardedAt time.Time
+}
+
+// UserStats are the per-user metrics badge rules compare with thresholds.
+type UserStats struct {
+	Answers int
+	AcceptedAnswers int
+	MaxAnswerScore int
+	Reputation int
+	Questions int
+	MaxQuestionViews int
+}
+
+type GetBadgesResponse struct {
+	Badges []Badge
+}
+
+type GetUserBadgesResponse struct {
+	Badges []UserBadge
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// Comment is a short plain-text remark on a question or an answer; exactly
+// one of QuestionID and AnswerID is set.
+type Comment struct {
+	ID int
+	QuestionID *int `json:",omitempty"`
+	AnswerID *int `json:",omitempty"`
+	UserID string
+	Text string
+	CreatedAt time.Time
+}
+
+// CommentCreated is the payload of comment.created events. QuestionID is
+// the question of the comment or of its answer, and ParentAuthorID the
+// author of what was commented on, empty when unknown.
+type CommentCreated struct {
+	Comment Comment
+	QuestionID int
+	ParentAuthorID string
+}
+
+type CreateCommentRequest struct {
+	UserID string
+	Text string
+}
+
+type CreateCommentResponse struct {
+	Comment Comment
+}
+
+type GetCommentsResponse struct {
+	Comments []Comment
+	Limit int
+	Offset int
+}
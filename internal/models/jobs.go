@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// JobStatus reports a background job. Local jobs run on every replica; the
+// others only on the replica that is Leader for them. Skipped counts the
+// runs left to another replica.
+type JobStatus struct {
+	Name string
+	Schedule string
+	Local bool
+	Leader bool
+	Running bool
+	Runs int64
+	Failures int64
+	Skipped int64
+	LastRunAt *time.Time `json:",omitempty"`
+	LastDurationMs int64
+	LastError string `json:",omitempty"`
+	NextRunAt *time.Time `json:",omitempty"`
+}
+
+type GetJobsResponse struct {
+	Jobs []JobStatus
+}
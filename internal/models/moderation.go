@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditQuestionMerged = "question.merged"
+	AuditAnswerMoved = "answer.moved"
+)
+
+// AuditEntry records a moderation action. Details holds the JSON of the
+// change, e.g. the IDs of the moved answers.
+type AuditEntry struct {
+	ID int64
+	Action string
+	UserID string
+	QuestionID int
+	AnswerID int
+	Details json.RawMessage
+	CreatedAt time.Time
+}
+
+func(AuditEntry) TableName() string {
+	return "audit_log"
+}
+
+// MergeQuestionRequest names the moderator who merges the questions.
+type MergeQuestionRequest struct {
+	UserID string
+}
+
+// QuestionsMerged is the result of merging Merged into Question: the
+// answers moved and Merged left as a stub that redirects to Question.
+type QuestionsMerged struct {
+	Question Question
+	Merged Question
+	Answers []Answer
+	// Repointed are the duplicates of the merged question, which now
+	// duplicate Question.
+	Repointed []int `json:",omitempty"`
+}
+
+type MergeQuestionResponse struct {
+	Question Question
+	Merged Question
+	Answers []Answer
+}
+
+// PatchAnswerRequest changes an answer. Moving it to another question is
+// the only change so far.
+type PatchAnswerRequest struct {
+	UserID string
+	QuestionID *int
+}
+
+// AnswerMoved is an answer moved from the question From to its QuestionID.
+type AnswerMoved struct {
+	Answer Answer
+	From int
+}
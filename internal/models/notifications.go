@@ -0,0 +1,125 @@
+package models
+
+import (
+	"time"
+)
+
+// Reasons a user receives a notification.
+const (
+	ReasonQuestionAuthor = "question_author"
+	ReasonAnswerAuthor = "answer_author"
+	ReasonFollower = "follower"
+)
+
+// Notification tells the user about an event: Type is the event type and
+// Reason why the user was told. ActorID is the user who caused the event,
+// when known.
+type Notification struct {
+	ID int64
+	UserID string
+	Type string
+	Reason string
+	QuestionID int
+	AnswerID int
+	ActorID *string `json:",omitempty"`
+	EventID int64 `json:"-"`
+	ReadAt *time.Time `json:",omitempty"`
+	CreatedAt time.Time
+}
+
+// Digest frequencies.
+const (
+	DigestOff = "off"
+	DigestDaily = "daily"
+	DigestWeekly = "weekly"
+)
+
+// NotificationPreferences selects the notifications a user receives:
+// Answers to their questions, Accepted answers of theirs, activity on
+// Followed questions and Comments on their questions and answers. Users without a row receive everything. Digest is
+// how often the new answers on followed questions are mailed to Email, once
+// its owner opened the link mailed with EmailToken.
+type NotificationPreferences struct {
+	UserID string `gorm:"primaryKey"`
+	Answers bool
+	Accepted bool
+	Followed bool
+	Comments bool
+	Email string
+	EmailVerifiedAt *time.Time `json:",omitempty"`
+	EmailToken string `json:"-"`
+	Digest string
+	DigestSentAt *time.Time `json:"-"`
+	UpdatedAt time.Time
+}
+
+func DefaultNotificationPreferences(userID string) NotificationPreferences {
+	return NotificationPreferences{
+		UserID: userID,
+		Answers: true,
+		Accepted: true,
+		Followed: true,
+		Comments: true,
+		Digest: DigestOff,
+	}
+}
+
+// UpdateNotificationPreferencesRequest changes the fields that are set.
+type UpdateNotificationPreferencesRequest struct {
+	Answers *bool
+	Accepted *bool
+	Followed *bool
+	Comments *bool
+	Email *string
+	Digest *string
+}
+
+// DigestAnswer is an answer to a followed question listed in a digest.
+type DigestAnswer struct {
+	QuestionID int
+	QuestionText string
+	AnswerID int
+	AnswerText string
+	UserID string
+	CreatedAt time.Time
+}
+
+// QuestionFollower is a user who follows the activity on a question.
+type QuestionFollower struct {
+	QuestionID int `gorm:"primaryKey"`
+	UserID string `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+// DigestQuestion is a question without answers in a followed tag listed in
+// a digest.
+type DigestQuestion struct {
+	ID int
+	Text string
+	Tags []string `gorm:"serializer:json"`
+	CreatedAt time.Time
+}
+
+// TagFollower is a user whose digest lists the unanswered questions with
+// the tag.
+type TagFollower struct {
+	UserID string `gorm:"primaryKey"`
+	Tag string `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+type FollowedTagsResponse struct {
+	Tags []string
+}
+
+type GetNotificationsResponse struct {
+	Notifications []Notification
+	Unread int
+	Limit int
+	Offset int
+}
+
+type MarkNotificationsReadResponse struct {
+	Marked int
+	Unread int
+}
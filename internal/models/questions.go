@@ -1,27 +1,85 @@
 package models
 
 import (
+	"errors"
 	"time"
 )
 
+// MaxDuplicateHops bounds the walk to the question a chain of duplicates
+// ends at.
+const MaxDuplicateHops = 16
+
+// ErrDuplicateChain is the error of a storage asked to link a question to
+// a target whose chain of duplicates leads back to the question, or is
+// longer than MaxDuplicateHops.
+var ErrDuplicateChain = errors.New("DuplicateChain")
+
 type Question struct {
 	ID int
+	// Text is the Markdown source and TextHTML its sanitized rendering.
 	Text string
+	TextHTML string `json:",omitempty"`
+	// UserID is the author. Questions asked before authors were recorded
+	// have none.
+	UserID *string `json:",omitempty"`
+	Version int
+	ViewCount int
+	// DuplicateOf is the question this one duplicates. Readers of a
+	// duplicate are redirected to it.
+	DuplicateOf *int `json:",omitempty"`
+	// Tags are lowercase and sorted, see service.MaxTags.
+	Tags []string `gorm:"serializer:json" json:",omitempty"`
 	CreatedAt time.Time
 }
 
+// QuestionSort is the order of GET /questions.
+type QuestionSort string
+
+const (
+	SortDefault QuestionSort = ""
+	SortViews QuestionSort = "views"
+)
+
 type CreateQuestionRequest struct {
 	Text string
+	// UserID is optional; an author is notified of answers.
+	UserID string `json:",omitempty"`
+	Tags []string `json:",omitempty"`
 }
 
 type CreateQuestionResponse struct {
 	Question Question
+	// Duplicates are existing questions with a similar text.
+	Duplicates []SimilarQuestion `json:",omitempty"`
+}
+
+// SimilarQuestion is an existing question and the trigram similarity of its
+// text, from 0 to 1.
+type SimilarQuestion struct {
+	ID int
+	Text string
+	Similarity float64
+}
+
+// MarkDuplicateRequest names the moderator who marks the duplicate.
+type MarkDuplicateRequest struct {
+	UserID string
+}
+
+type MarkDuplicateResponse struct {
+	Question Question
 }
 
 type GetQuestionsResponse struct {
 	Questions []Question
 }
 
+// TaggedQuestion is a question listed under one of its tags.
+type TaggedQuestion struct {
+	Tag string
+	Question Question `gorm:"embedded"`
+}
+
 type GetQuestionResponse struct {
 	Question Question
 	Answers []Answer
@@ -30,4 +88,72 @@ type GetQuestionResponse struct {
 type QuestionWithAnswers struct {
 	Question Question
 	Answers []Answer
-}
\ No newline at end of file
+}
+// Content is the text of a question or an answer with its rendering, for
+// rendering the rows written before Markdown support. QuestionID is set for
+// answers only.
+type Content struct {
+	ID int
+	QuestionID int
+	Text string
+	TextHTML string
+}
+
+// BatchOp is the kind of an operation of POST /questions:batch.
+type BatchOp string
+
+const (
+	BatchCreate BatchOp = "create"
+	BatchDelete BatchOp = "delete"
+)
+
+type BatchQuestionsRequest struct {
+	Operations []BatchQuestionOperation
+}
+
+// BatchQuestionOperation creates a question from Text and the optional
+// UserID and Tags, or deletes the question ID. A non-zero Version makes
+// the delete conditional, as with If-Match.
+type BatchQuestionOperation struct {
+	Op BatchOp
+	Text string `json:",omitempty"`
+	UserID string `json:",omitempty"`
+	Tags []string `json:",omitempty"`
+	ID int `json:",omitempty"`
+	Version int `json:",omitempty"`
+}
+
+// BatchQuestionResult is the outcome of an operation. Err is the error of
+// the service; the handler writes it as the Status the operation would
+// have had on its own route and the Error message.
+type BatchQuestionResult struct {
+	Op BatchOp
+	Status int
+	Question *Question `json:",omitempty"`
+	Error string `json:",omitempty"`
+	Err error `json:"-"`
+}
+
+// BatchQuestionsResponse has a result for every operation, in the order of
+// the request.
+type BatchQuestionsResponse struct {
+	Atomic bool
+	Results []BatchQuestionResult
+}
+
+// QuestionBatch is written by the storage in one transaction. Deleted is
+// set on the deletes that removed their question; after a rollback, the
+// first delete without it is the one that removed nothing.
+type QuestionBatch struct {
+	Create []*Question
+	Delete []QuestionDelete
+}
+
+// QuestionDelete deletes the question ID, at Version unless it is 0. The
+// storage sets Deleted and the AnswerIDs deleted with the question.
+type QuestionDelete struct {
+	ID int
+	Version int
+	Deleted bool
+	AnswerIDs []int
+}
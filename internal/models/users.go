@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+)
+
+type User struct {
+	ID string
+	DisplayName string
+	Bio string
+	AvatarURL string
+	Reputation int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Author is the compact form of a user embedded in answers. UpdatedAt
+// changes with the profile, so the ETags of the answers can cover it.
+type Author struct {
+	ID string
+	DisplayName string
+	AvatarURL string
+	UpdatedAt time.Time
+}
+
+func(Author) TableName() string {
+	return "users"
+}
+
+type UpdateUserRequest struct {
+	DisplayName string
+	Bio string
+	AvatarURL string
+}
+
+type GetUserResponse struct {
+	User User
+	Privileges []string
+}
+
+// Page selects a slice of a list ordered from newest to oldest.
+type Page struct {
+	Limit int
+	Offset int
+}
+
+type GetUserAnswersResponse struct {
+	Answers []Answer
+	Limit int
+	Offset int
+}
+
+type GetUserQuestionsResponse struct {
+	Questions []Question
+	Limit int
+	Offset int
+}
+
+// ReputationEvent is one ledger entry. An event of the outbox adds at most
+// one entry per user and reason, so replaying it changes nothing.
+type ReputationEvent struct {
+	ID int64
+	UserID string
+	EventID int64
+	Reason string
+	Points int
+	AnswerID int
+	CreatedAt time.Time
+}
+
+type GetLeaderboardResponse struct {
+	Users []User
+	Limit int
+	Offset int
+}
+
+type GetReputationResponse struct {
+	Reputation int
+	Events []ReputationEvent
+	Limit int
+	Offset int
+}
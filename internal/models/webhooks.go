@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	DeliveryPending = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed = "failed"
+)
+
+type Webhook struct {
+	ID int
+	URL string
+	Secret string `json:"-"`
+	Events []string `gorm:"serializer:json"`
+	Active bool
+	FailureCount int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type WebhookDelivery struct {
+	ID int
+	WebhookID int
+	EventID int64
+	EventType string
+	Payload json.RawMessage
+	Status string
+	Attempts int
+	NextAttemptAt time.Time
+	LastStatusCode int
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OutboxEvent is written in the same transaction as the change it describes
+// and relayed to consumers afterwards. A replica holds a claimed event until
+// LockedUntil; FailedAt marks an event given up after too many Attempts.
+type OutboxEvent struct {
+	ID int64
+	Type string
+	QuestionID int
+	AnswerID int
+	Payload json.RawMessage
+	CreatedAt time.Time
+	ProcessedAt *time.Time
+	Attempts int
+	LockedUntil *time.Time
+	FailedAt *time.Time
+}
+
+func(OutboxEvent) TableName() string {
+	return "outbox"
+}
+
+type CreateWebhookRequest struct {
+	URL string
+	Secret string
+	Events []string
+}
+
+type CreateWebhookResponse struct {
+	Webhook Webhook
+	Secret string
+}
+
+type UpdateWebhookRequest struct {
+	URL string
+	Events []string
+	Active bool
+}
+
+type GetWebhooksResponse struct {
+	Webhooks []Webhook
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery
+}
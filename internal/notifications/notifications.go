@@ -0,0 +1,506 @@
+// Package notifications keeps the inbox of each user, filled from domain
+// events: answers to their questions, their accepted answers, comments on
+// their questions and answers, and activity on questions they follow. It also keeps the tags users follow, whose
+// unanswered questions are listed in their digests.
+package notifications
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"regexp"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidUser = errors.New("InvalidUser")
+	ErrInvalidPreferences = errors.New("InvalidPreferences")
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit = 100
+)
+
+var userIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type Storage interface {
+	// QuestionAudience returns the author of the question, empty when
+	// unknown, and its followers.
+	QuestionAudience(ctx context.Context, questionID int) (string, []string, error)
+	NotificationPreferences(ctx context.Context, userIDs []string) ([]models.NotificationPreferences, error)
+	SaveNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error
+	// CreateNotifications skips notifications already written for the
+	// user and event.
+	CreateNotifications(ctx context.Context, notifications []models.Notification) error
+	Notifications(ctx context.Context, userID string, unread bool, page models.Page) ([]models.Notification, error)
+	UnreadNotifications(ctx context.Context, userID string) (int, error)
+	// MarkNotificationsRead marks one notification of the user, or all of
+	// them when id is 0, and returns how many were unread.
+	MarkNotificationsRead(ctx context.Context, userID string, id int64, at time.Time) (int, error)
+	FollowQuestion(ctx context.Context, questionID int, userID string) error
+	UnfollowQuestion(ctx context.Context, questionID int, userID string) error
+	FollowTag(ctx context.Context, tag, userID string) error
+	UnfollowTag(ctx context.Context, tag, userID string) error
+	FollowedTags(ctx context.Context, userID string) ([]string, error)
+	// VerifyNotificationEmail marks the email with the token hash verified
+	// and returns the number of updated rows.
+	VerifyNotificationEmail(ctx context.Context, tokenHash string, at time.Time) (int, error)
+}
+
+// Verifier mails the link that proves the user owns the email address.
+type Verifier interface {
+	SendVerification(ctx context.Context, email, token string) error
+}
+
+// Inbox writes notifications and serves them to their users. Handle is
+// subscribed to the events dispatcher.
+type Inbox struct {
+	log *slog.Logger
+	storage Storage
+	verifier Verifier
+	now func() time.Time
+}
+
+// NewInbox takes the verifier of email addresses, nil when nothing is
+// mailed; addresses then stay unverified.
+func NewInbox(log *slog.Logger, storage Storage, verifier Verifier) *Inbox {
+	return &Inbox{
+		log: log,
+		storage: storage,
+		verifier: verifier,
+		now: time.Now,
+	}
+}
+
+// Handle notifies the audience of new and accepted answers and of
+// comments. Users are not notified of their own answers and comments.
+func(i *Inbox) Handle(ctx context.Context, event events.Event) error {
+	var answer models.Answer
+	switch event.Type {
+	case events.CommentCreated:
+		created, err := events.Decode[models.CommentCreated](event)
+		if err != nil {
+			return err
+		}
+		return i.handleComment(ctx, event, created)
+	case events.AnswerCreated:
+		created, err := events.Decode[models.Answer](event)
+		if err != nil {
+			return err
+		}
+		answer = created
+	case events.AnswerAccepted:
+		accepted, err := events.Decode[models.AnswerAccepted](event)
+		if err != nil {
+			return err
+		}
+		answer = accepted.Answer
+	default:
+		return nil
+	}
+
+	author, followers, err := i.storage.QuestionAudience(ctx, answer.QuestionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	reasons := make(map[string]string)
+	for _, follower := range followers {
+		reasons[follower] = models.ReasonFollower
+	}
+	notification := models.Notification{
+		Type: event.Type,
+		QuestionID: answer.QuestionID,
+		AnswerID: answer.ID,
+		EventID: int64(event.ID),
+	}
+	if event.Type == events.AnswerCreated {
+		if author != "" {
+			reasons[author] = models.ReasonQuestionAuthor
+		}
+		delete(reasons, answer.UserID)
+		notification.ActorID = &answer.UserID
+	} else {
+		// The author of the question accepted the answer.
+		delete(reasons, author)
+		if answer.UserID != author {
+			reasons[answer.UserID] = models.ReasonAnswerAuthor
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	notifications, err := i.filter(ctx, reasons, notification)
+	if err != nil || len(notifications) == 0 {
+		return err
+	}
+
+	return i.storage.CreateNotifications(ctx, notifications)
+}
+
+// handleComment notifies the author of the question or answer commented
+// on. Followers are not notified of comments.
+func(i *Inbox) handleComment(ctx context.Context, event events.Event, created models.CommentCreated) error {
+	if created.ParentAuthorID == "" || created.ParentAuthorID == created.Comment.UserID {
+		return nil
+	}
+	reason := models.ReasonQuestionAuthor
+	if created.Comment.AnswerID != nil {
+		reason = models.ReasonAnswerAuthor
+	}
+	notification := models.Notification{
+		Type: event.Type,
+		QuestionID: created.QuestionID,
+		AnswerID: event.AnswerID,
+		ActorID: &created.Comment.UserID,
+		EventID: int64(event.ID),
+	}
+	notifications, err := i.filter(ctx, map[string]string{created.ParentAuthorID: reason}, notification)
+	if err != nil || len(notifications) == 0 {
+		return err
+	}
+
+	return i.storage.CreateNotifications(ctx, notifications)
+}
+
+// Notifications lists the notifications of the user, newest first, with
+// the number of unread ones.
+func(i *Inbox) Notifications(ctx context.Context, userID string, unread bool, page models.Page) (models.GetNotificationsResponse, error) {
+	if !userIDPattern.MatchString(userID) {
+		return models.GetNotificationsResponse{}, ErrInvalidUser
+	}
+	page = normalizePage(page)
+	notifications, err := i.storage.Notifications(ctx, userID, unread, page)
+	if err != nil {
+		i.logDBError("DB_ReadingError", err)
+		return models.GetNotificationsResponse{}, errors.New("DB_ReadingError")
+	}
+	count, err := i.storage.UnreadNotifications(ctx, userID)
+	if err != nil {
+		i.logDBError("DB_ReadingError", err)
+		return models.GetNotificationsResponse{}, errors.New("DB_ReadingError")
+	}
+
+	return models.GetNotificationsResponse{
+		Notifications: notifications,
+		Unread: count,
+		Limit: page.Limit,
+		Offset: page.Offset,
+	}, nil
+}
+
+// MarkRead marks one notification of the user as read, or all of them
+// when id is 0.
+func(i *Inbox) MarkRead(ctx context.Context, userID string, id int64) (models.MarkNotificationsReadResponse, error) {
+	if !userIDPattern.MatchString(userID) {
+		return models.MarkNotificationsReadResponse{}, ErrInvalidUser
+	}
+	marked, err := i.storage.MarkNotificationsRead(ctx, userID, id, i.now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.MarkNotificationsReadResponse{}, err
+	}
+	if err != nil {
+		i.logDBError("DB_WritingError", err)
+		return models.MarkNotificationsReadResponse{}, errors.New("DB_WritingError")
+	}
+	count, err := i.storage.UnreadNotifications(ctx, userID)
+	if err != nil {
+		i.logDBError("DB_ReadingError", err)
+		return models.MarkNotificationsReadResponse{}, errors.New("DB_ReadingError")
+	}
+
+	return models.MarkNotificationsReadResponse{Marked: marked, Unread: count}, nil
+}
+
+func(i *Inbox) Preferences(ctx context.Context, userID string) (models.NotificationPreferences, error) {
+	if !userIDPattern.MatchString(userID) {
+		return models.NotificationPreferences{}, ErrInvalidUser
+	}
+	preferences, err := i.preferences(ctx, []string{userID})
+	if err != nil {
+		i.logDBError("DB_ReadingError", err)
+		return models.NotificationPreferences{}, errors.New("DB_ReadingError")
+	}
+
+	return preferences[userID], nil
+}
+
+func(i *Inbox) UpdatePreferences(ctx context.Context, userID string, data []byte) (models.NotificationPreferences, error) {
+	if !userIDPattern.MatchString(userID) {
+		return models.NotificationPreferences{}, ErrInvalidUser
+	}
+	var request models.UpdateNotificationPreferencesRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return models.NotificationPreferences{}, fmt.Errorf("%w: %v", ErrInvalidPreferences, err)
+	}
+	preferences, err := i.Preferences(ctx, userID)
+	if err != nil {
+		return preferences, err
+	}
+	if request.Answers != nil {
+		preferences.Answers = *request.Answers
+	}
+	if request.Accepted != nil {
+		preferences.Accepted = *request.Accepted
+	}
+	if request.Followed != nil {
+		preferences.Followed = *request.Followed
+	}
+	if request.Comments != nil {
+		preferences.Comments = *request.Comments
+	}
+	// Setting an unverified email again mails a new link.
+	var token string
+	if request.Email != nil && (*request.Email != preferences.Email || preferences.EmailVerifiedAt == nil) {
+		preferences.Email = *request.Email
+		preferences.EmailVerifiedAt = nil
+		preferences.EmailToken = ""
+		if preferences.Email != "" {
+			token, err = newToken()
+			if err != nil {
+				return models.NotificationPreferences{}, err
+			}
+			preferences.EmailToken = hashToken(token)
+		}
+	}
+	if request.Digest != nil {
+		preferences.Digest = *request.Digest
+	}
+	if err := validatePreferences(preferences); err != nil {
+		return models.NotificationPreferences{}, err
+	}
+	if err := i.storage.SaveNotificationPreferences(ctx, &preferences); err != nil {
+		i.logDBError("DB_WritingError", err)
+		return models.NotificationPreferences{}, errors.New("DB_WritingError")
+	}
+	if token != "" && i.verifier != nil {
+		if err := i.verifier.SendVerification(ctx, preferences.Email, token); err != nil {
+			i.log.Error(
+				"VerificationError",
+				slog.String("component", "notifications"),
+				slog.String("user_id", userID),
+				slog.Any("error", err),
+			)
+			return models.NotificationPreferences{}, errors.New("VerificationError")
+		}
+	}
+
+	return preferences, nil
+}
+
+// VerifyEmail confirms the email the token was mailed to. Digests are
+// mailed to verified addresses only, so the X-User-ID of a request cannot
+// send them to somebody else.
+func(i *Inbox) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return gorm.ErrRecordNotFound
+	}
+	rows, err := i.storage.VerifyNotificationEmail(ctx, hashToken(token), i.now())
+	if err != nil {
+		i.logDBError("DB_WritingError", err)
+		return errors.New("DB_WritingError")
+	}
+	if rows == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func(i *Inbox) Follow(ctx context.Context, questionID int, userID string) error {
+	if !userIDPattern.MatchString(userID) {
+		return ErrInvalidUser
+	}
+	err := i.storage.FollowQuestion(ctx, questionID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		i.logDBError("DB_WritingError", err)
+		return errors.New("DB_WritingError")
+	}
+
+	return err
+}
+
+func(i *Inbox) Unfollow(ctx context.Context, questionID int, userID string) error {
+	if !userIDPattern.MatchString(userID) {
+		return ErrInvalidUser
+	}
+	if err := i.storage.UnfollowQuestion(ctx, questionID, userID); err != nil {
+		i.logDBError("DB_WritingError", err)
+		return errors.New("DB_WritingError")
+	}
+
+	return nil
+}
+
+// FollowTag lists the unanswered questions with the tag in the digests of
+// the user. Tags need no question to be followed.
+func(i *Inbox) FollowTag(ctx context.Context, tag, userID string) error {
+	if !userIDPattern.MatchString(userID) {
+		return ErrInvalidUser
+	}
+	tags, err := service.NormalizeTags([]string{tag})
+	if err != nil {
+		return err
+	}
+	if err := i.storage.FollowTag(ctx, tags[0], userID); err != nil {
+		i.logDBError("DB_WritingError", err)
+		return errors.New("DB_WritingError")
+	}
+
+	return nil
+}
+
+func(i *Inbox) UnfollowTag(ctx context.Context, tag, userID string) error {
+	if !userIDPattern.MatchString(userID) {
+		return ErrInvalidUser
+	}
+	tags, err := service.NormalizeTags([]string{tag})
+	if err != nil {
+		return err
+	}
+	if err := i.storage.UnfollowTag(ctx, tags[0], userID); err != nil {
+		i.logDBError("DB_WritingError", err)
+		return errors.New("DB_WritingError")
+	}
+
+	return nil
+}
+
+func(i *Inbox) FollowedTags(ctx context.Context, userID string) (models.FollowedTagsResponse, error) {
+	if !userIDPattern.MatchString(userID) {
+		return models.FollowedTagsResponse{}, ErrInvalidUser
+	}
+	tags, err := i.storage.FollowedTags(ctx, userID)
+	if err != nil {
+		i.logDBError("DB_ReadingError", err)
+		return models.FollowedTagsResponse{}, errors.New("DB_ReadingError")
+	}
+
+	return models.FollowedTagsResponse{Tags: tags}, nil
+}
+
+// filter keeps the recipients whose preferences allow the reason.
+func(i *Inbox) filter(ctx context.Context, reasons map[string]string, notification models.Notification) ([]models.Notification, error) {
+	userIDs := make([]string, 0, len(reasons))
+	for userID := range reasons {
+		userIDs = append(userIDs, userID)
+	}
+	preferences, err := i.preferences(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]models.Notification, 0, len(reasons))
+	for userID, reason := range reasons {
+		if !allows(preferences[userID], notification.Type, reason) {
+			continue
+		}
+		notification.UserID = userID
+		notification.Reason = reason
+		res = append(res, notification)
+	}
+
+	return res, nil
+}
+
+// preferences returns the preferences of every user, the defaults for
+// users who never saved theirs.
+func(i *Inbox) preferences(ctx context.Context, userIDs []string) (map[string]models.NotificationPreferences, error) {
+	saved, err := i.storage.NotificationPreferences(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]models.NotificationPreferences, len(userIDs))
+	for _, userID := range userIDs {
+		res[userID] = models.DefaultNotificationPreferences(userID)
+	}
+	for _, preferences := range saved {
+		res[preferences.UserID] = preferences
+	}
+
+	return res, nil
+}
+
+func(i *Inbox) logDBError(msg string, err error) {
+	i.log.Error(
+		msg,
+		slog.String("component", "db"),
+		slog.Any("error", err),
+	)
+}
+
+func allows(preferences models.NotificationPreferences, eventType, reason string) bool {
+	if eventType == events.CommentCreated {
+		return preferences.Comments
+	}
+	switch reason {
+	case models.ReasonQuestionAuthor:
+		return preferences.Answers
+	case models.ReasonAnswerAuthor:
+		return preferences.Accepted
+	case models.ReasonFollower:
+		return preferences.Followed
+	}
+
+	return false
+}
+
+// validatePreferences rejects a malformed email and a digest that has no
+// email to go to.
+func validatePreferences(preferences models.NotificationPreferences) error {
+	switch preferences.Digest {
+	case models.DigestOff, models.DigestDaily, models.DigestWeekly:
+	default:
+		return fmt.Errorf("%w: unknown digest %q", ErrInvalidPreferences, preferences.Digest)
+	}
+	if preferences.Email != "" {
+		address, err := mail.ParseAddress(preferences.Email)
+		if err != nil || address.Address != preferences.Email {
+			return fmt.Errorf("%w: invalid email", ErrInvalidPreferences)
+		}
+	}
+	if preferences.Digest != models.DigestOff && preferences.Email == "" {
+		return fmt.Errorf("%w: digest requires an email", ErrInvalidPreferences)
+	}
+
+	return nil
+}
+
+func normalizePage(page models.Page) models.Page {
+	if page.Limit <= 0 {
+		page.Limit = defaultPageLimit
+	}
+	page.Limit = min(page.Limit, maxPageLimit)
+	page.Offset = max(page.Offset, 0)
+
+	return page
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// hashToken is what is stored, so a leaked table does not verify anything.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
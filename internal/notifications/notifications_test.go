@@ -0,0 +1,294 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+	"gorm.io/gorm"
+)
+
+const (
+	author = "11111111-1111-1111-1111-111111111111"
+	answerer = "22222222-2222-2222-2222-222222222222"
+	follower = "33333333-3333-3333-3333-333333333333"
+)
+
+func TestAnswerNotifiesAuthorAndFollowers(t *testing.T) {
+	ctx := context.Background()
+	inbox, questionID := newTestInbox(t)
+	if err := inbox.Follow(ctx, questionID, follower); err != nil {
+		t.Fatal(err)
+	}
+
+	event := answerEvent(1, models.Answer{ID: 1, QuestionID: questionID, UserID: answerer})
+	for i := 0; i < 2; i++ {
+		// A redelivered event writes nothing new.
+		if err := inbox.Handle(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := inbox.Handle(ctx, answerEvent(2, models.Answer{ID: 2, QuestionID: questionID, UserID: follower})); err != nil {
+		t.Fatal(err)
+	}
+
+	excpected := map[string][]string{
+		author: {models.ReasonQuestionAuthor, models.ReasonQuestionAuthor},
+		follower: {models.ReasonFollower},
+		answerer: {},
+	}
+	for userID, reasons := range excpected {
+		res, err := inbox.Notifications(ctx, userID, false, models.Page{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Notifications) != len(reasons) || res.Unread != len(reasons) {
+			t.Errorf("Excpected %d notifications for %s, got %+v", len(reasons), userID, res)
+			continue
+		}
+		for i, notification := range res.Notifications {
+			if notification.Reason != reasons[i] || notification.Type != events.AnswerCreated {
+				t.Errorf("Excpected %s notification for %s, got %+v", reasons[i], userID, notification)
+			}
+		}
+	}
+}
+
+func TestAcceptRespectsPreferences(t *testing.T) {
+	ctx := context.Background()
+	inbox, questionID := newTestInbox(t)
+	if err := inbox.Follow(ctx, questionID, follower); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inbox.UpdatePreferences(ctx, follower, []byte(`{"Followed":false}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	accepted := models.AnswerAccepted{Answer: models.Answer{ID: 1, QuestionID: questionID, UserID: answerer, Accepted: true}}
+	event := events.NewAnswerAccepted(accepted)
+	event.ID = 1
+	if err := inbox.Handle(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+
+	for userID, count := range map[string]int{answerer: 1, author: 0, follower: 0} {
+		res, err := inbox.Notifications(ctx, userID, false, models.Page{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Notifications) != count {
+			t.Errorf("Excpected %d notifications for %s, got %+v", count, userID, res.Notifications)
+		}
+	}
+	preferences, err := inbox.Preferences(ctx, follower)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !preferences.Answers || !preferences.Accepted || preferences.Followed {
+		t.Errorf("Excpected only Followed to be off, got %+v", preferences)
+	}
+}
+
+func TestCommentNotifiesParentAuthor(t *testing.T) {
+	ctx := context.Background()
+	inbox, questionID := newTestInbox(t)
+	if err := inbox.Follow(ctx, questionID, follower); err != nil {
+		t.Fatal(err)
+	}
+	answerID := 1
+	comments := []models.CommentCreated{
+		{Comment: models.Comment{ID: 1, QuestionID: &questionID, UserID: answerer}, QuestionID: questionID, ParentAuthorID: author},
+		{Comment: models.Comment{ID: 2, AnswerID: &answerID, UserID: author}, QuestionID: questionID, ParentAuthorID: answerer},
+		// Own comments and comments of users who turned them off are not
+		// notified.
+		{Comment: models.Comment{ID: 3, AnswerID: &answerID, UserID: answerer}, QuestionID: questionID, ParentAuthorID: answerer},
+		{Comment: models.Comment{ID: 4, QuestionID: &questionID, UserID: answerer}, QuestionID: questionID, ParentAuthorID: follower},
+	}
+	if _, err := inbox.UpdatePreferences(ctx, follower, []byte(`{"Comments":false}`)); err != nil {
+		t.Fatal(err)
+	}
+	for i, created := range comments {
+		event := events.NewCommentCreated(created)
+		event.ID = uint64(i + 1)
+		if err := inbox.Handle(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	excpected := map[string]string{author: models.ReasonQuestionAuthor, answerer: models.ReasonAnswerAuthor, follower: ""}
+	for userID, reason := range excpected {
+		res, err := inbox.Notifications(ctx, userID, false, models.Page{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reason == "" {
+			if len(res.Notifications) != 0 {
+				t.Errorf("Excpected no notifications for %s, got %+v", userID, res.Notifications)
+			}
+			continue
+		}
+		if len(res.Notifications) != 1 || res.Notifications[0].Reason != reason || res.Notifications[0].Type != events.CommentCreated {
+			t.Errorf("Excpected a %s comment notification for %s, got %+v", reason, userID, res.Notifications)
+		}
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	ctx := context.Background()
+	inbox, questionID := newTestInbox(t)
+	for id := 1; id <= 3; id++ {
+		if err := inbox.Handle(ctx, answerEvent(uint64(id), models.Answer{ID: id, QuestionID: questionID, UserID: answerer})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := inbox.Notifications(ctx, author, false, models.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marked, err := inbox.MarkRead(ctx, author, res.Notifications[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marked.Marked != 1 || marked.Unread != 2 {
+		t.Errorf("Excpected 1 marked and 2 unread, got %+v", marked)
+	}
+	if _, err := inbox.MarkRead(ctx, answerer, res.Notifications[1].ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Excpected ErrRecordNotFound for a notification of another user, got %v", err)
+	}
+	unread, err := inbox.Notifications(ctx, author, true, models.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unread.Notifications) != 2 {
+		t.Errorf("Excpected 2 unread notifications, got %d", len(unread.Notifications))
+	}
+	marked, err = inbox.MarkRead(ctx, author, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marked.Marked != 2 || marked.Unread != 0 {
+		t.Errorf("Excpected 2 marked and none unread, got %+v", marked)
+	}
+}
+
+func newTestInbox(t *testing.T) (*Inbox, int) {
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	userID := author
+	question := &models.Question{Text: "test", UserID: &userID}
+	if err := questions.CreateQuestion(context.Background(), question); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewInbox(slog.Default(), mock.NewMockStorageNotifications(questions), &verifier{}), question.ID
+}
+
+type verifier struct {
+	email string
+	token string
+}
+
+func(v *verifier) SendVerification(ctx context.Context, email, token string) error {
+	v.email, v.token = email, token
+	return nil
+}
+
+func answerEvent(id uint64, answer models.Answer) events.Event {
+	event := events.NewAnswerCreated(answer)
+	event.ID = id
+	return event
+}
+
+func TestDigestPreferences(t *testing.T) {
+	ctx := context.Background()
+	inbox, _ := newTestInbox(t)
+	for _, body := range []string{
+		`{"Digest":"daily"}`,
+		`{"Digest":"hourly","Email":"alice@example.com"}`,
+		`{"Digest":"weekly","Email":"Alice <alice@example.com>"}`,
+	} {
+		if _, err := inbox.UpdatePreferences(ctx, follower, []byte(body)); !errors.Is(err, ErrInvalidPreferences) {
+			t.Errorf("Excpected ErrInvalidPreferences for %s, got %v", body, err)
+		}
+	}
+	preferences, err := inbox.UpdatePreferences(ctx, follower, []byte(`{"Digest":"weekly","Email":"alice@example.com"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preferences.Digest != models.DigestWeekly || preferences.Email != "alice@example.com" || !preferences.Answers {
+		t.Errorf("Excpected a weekly digest to alice@example.com, got %+v", preferences)
+	}
+}
+
+func TestEmailIsVerified(t *testing.T) {
+	ctx := context.Background()
+	inbox, _ := newTestInbox(t)
+	sent := inbox.verifier.(*verifier)
+
+	preferences, err := inbox.UpdatePreferences(ctx, follower, []byte(`{"Email":"alice@example.com"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preferences.EmailVerifiedAt != nil || sent.email != "alice@example.com" || sent.token == "" {
+		t.Fatalf("Excpected an unverified email and a mailed token, got %+v", preferences)
+	}
+	if err := inbox.VerifyEmail(ctx, "wrong"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Excpected ErrRecordNotFound for a wrong token, got %v", err)
+	}
+	if err := inbox.VerifyEmail(ctx, sent.token); err != nil {
+		t.Fatal(err)
+	}
+	if err := inbox.VerifyEmail(ctx, sent.token); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Excpected a used token to fail, got %v", err)
+	}
+	preferences, _ = inbox.Preferences(ctx, follower)
+	if preferences.EmailVerifiedAt == nil {
+		t.Error("Excpected the email to be verified")
+	}
+
+	token := sent.token
+	preferences, err = inbox.UpdatePreferences(ctx, follower, []byte(`{"Email":"alice@example.com","Digest":"daily"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preferences.EmailVerifiedAt == nil || sent.token != token {
+		t.Errorf("Excpected the verified email to stay verified, got %+v", preferences)
+	}
+	preferences, err = inbox.UpdatePreferences(ctx, follower, []byte(`{"Email":"mallory@example.com"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preferences.EmailVerifiedAt != nil || sent.email != "mallory@example.com" {
+		t.Errorf("Excpected a changed email to need verification, got %+v", preferences)
+	}
+}
+
+func TestFollowTags(t *testing.T) {
+	ctx := context.Background()
+	inbox, _ := newTestInbox(t)
+
+	for _, tag := range []string{"Go", "sql", "go"} {
+		if err := inbox.FollowTag(ctx, tag, follower); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := inbox.FollowTag(ctx, "two words", follower); !errors.Is(err, service.ErrInvalidTags) {
+		t.Errorf("Excpected ErrInvalidTags, got %v", err)
+	}
+	if err := inbox.UnfollowTag(ctx, "SQL", follower); err != nil {
+		t.Fatal(err)
+	}
+	res, err := inbox.FollowedTags(ctx, follower)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Tags) != 1 || res.Tags[0] != "go" {
+		t.Errorf("Excpected to follow go, got %v", res.Tags)
+	}
+}
@@ -0,0 +1,129 @@
+package reputation
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// Reasons recorded in the ledger.
+const (
+	ReasonUpvoted = "upvoted"
+	ReasonUpvoteRemoved = "upvote_removed"
+	ReasonDownvoted = "downvoted"
+	ReasonDownvoteRemoved = "downvote_removed"
+	ReasonDownvoteCast = "downvote_cast"
+	ReasonDownvoteCastRemoved = "downvote_cast_removed"
+	ReasonAccepted = "accepted"
+	ReasonAcceptRemoved = "accept_removed"
+)
+
+type Storage interface {
+	RecordReputation(ctx context.Context, entries []models.ReputationEvent) error
+	RecomputeReputation(ctx context.Context) error
+}
+
+// Ledger turns vote and acceptance events into reputation entries using the
+// rules from config. It is subscribed to the events dispatcher.
+type Ledger struct {
+	log *slog.Logger
+	cfg config.ReputationConfig
+	storage Storage
+}
+
+func NewLedger(log *slog.Logger, cfg config.ReputationConfig, storage Storage) *Ledger {
+	return &Ledger{
+		log: log,
+		cfg: cfg,
+		storage: storage,
+	}
+}
+
+func(l *Ledger) Handle(ctx context.Context, event events.Event) error {
+	entries, err := l.Entries(event)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+
+	return l.storage.RecordReputation(ctx, entries)
+}
+
+// Recompute rebuilds every user total from the ledger.
+func(l *Ledger) Recompute(ctx context.Context) error {
+	err := l.storage.RecomputeReputation(ctx)
+	if err != nil {
+		l.log.Error(
+			"DB_WritingError",
+			slog.String("component", "reputation"),
+			slog.Any("error", err),
+		)
+	}
+
+	return err
+}
+
+// Entries applies the rules to the event. Changing a vote reverts the points
+// of the previous one. Authors accepting their own answer earn nothing, so
+// nothing is taken back when it is unaccepted.
+func(l *Ledger) Entries(event events.Event) ([]models.ReputationEvent, error) {
+	var res []models.ReputationEvent
+	add := func(userID, reason string, points, answerID int) {
+		if points == 0 || userID == "" {
+			return
+		}
+		res = append(res, models.ReputationEvent{
+			UserID: userID,
+			EventID: int64(event.ID),
+			Reason: reason,
+			Points: points,
+			AnswerID: answerID,
+		})
+	}
+
+	switch event.Type {
+	case events.AnswerVoted:
+		voted, err := events.Decode[models.AnswerVoted](event)
+		if err != nil {
+			return nil, err
+		}
+		author, answerID := voted.Answer.UserID, voted.Answer.ID
+		switch voted.Previous {
+		case 1:
+			add(author, ReasonUpvoteRemoved, -l.cfg.AnswerUpvoted, answerID)
+		case -1:
+			add(author, ReasonDownvoteRemoved, -l.cfg.AnswerDownvoted, answerID)
+			add(voted.VoterID, ReasonDownvoteCastRemoved, -l.cfg.DownvoteCast, answerID)
+		}
+		switch voted.Value {
+		case 1:
+			add(author, ReasonUpvoted, l.cfg.AnswerUpvoted, answerID)
+		case -1:
+			add(author, ReasonDownvoted, l.cfg.AnswerDownvoted, answerID)
+			add(voted.VoterID, ReasonDownvoteCast, l.cfg.DownvoteCast, answerID)
+		}
+	case events.AnswerAccepted:
+		accepted, err := events.Decode[models.AnswerAccepted](event)
+		if err != nil {
+			return nil, err
+		}
+		if accepted.Answer.UserID != accepted.QuestionUserID {
+			add(accepted.Answer.UserID, ReasonAccepted, l.cfg.AnswerAccepted, accepted.Answer.ID)
+		}
+		if accepted.Previous != nil && accepted.Previous.UserID != accepted.QuestionUserID {
+			add(accepted.Previous.UserID, ReasonAcceptRemoved, -l.cfg.AnswerAccepted, accepted.Previous.ID)
+		}
+	case events.AnswerUnaccepted:
+		unaccepted, err := events.Decode[models.AnswerUnaccepted](event)
+		if err != nil {
+			return nil, err
+		}
+		if unaccepted.UserID != unaccepted.QuestionUserID {
+			add(unaccepted.UserID, ReasonAcceptRemoved, -l.cfg.AnswerAccepted, unaccepted.ID)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,121 @@
+package reputation
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+const (
+	author = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	voter = "123e4567-e89b-12d3-a456-426614174000"
+)
+
+func TestEntriesRevertPreviousVote(t *testing.T) {
+	ledger := NewLedger(slog.Default(), testConfig(), nil)
+	event := events.NewAnswerVoted(models.AnswerVoted{
+		Answer: models.Answer{ID: 1, UserID: author},
+		VoterID: voter,
+		Value: -1,
+		Previous: 1,
+	})
+	event.ID = 7
+
+	entries, err := ledger.Entries(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	excpected := []models.ReputationEvent{
+		{UserID: author, EventID: 7, Reason: ReasonUpvoteRemoved, Points: -10, AnswerID: 1},
+		{UserID: author, EventID: 7, Reason: ReasonDownvoted, Points: -2, AnswerID: 1},
+		{UserID: voter, EventID: 7, Reason: ReasonDownvoteCast, Points: -1, AnswerID: 1},
+	}
+	if len(entries) != len(excpected) {
+		t.Fatalf("Excpected %d entries, got %+v", len(excpected), entries)
+	}
+	for i := range excpected {
+		if entries[i] != excpected[i] {
+			t.Errorf("Excpected entry %+v, got %+v", excpected[i], entries[i])
+		}
+	}
+}
+
+func TestEntriesTakeBackUnacceptedAnswer(t *testing.T) {
+	ledger := NewLedger(slog.Default(), testConfig(), nil)
+	event := events.NewAnswerUnaccepted(models.AnswerUnaccepted{Answer: models.Answer{ID: 2, UserID: author}})
+	event.ID = 9
+
+	entries, err := ledger.Entries(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	excpected := models.ReputationEvent{UserID: author, EventID: 9, Reason: ReasonAcceptRemoved, Points: -15, AnswerID: 2}
+	if len(entries) != 1 || entries[0] != excpected {
+		t.Errorf("Excpected entry %+v, got %+v", excpected, entries)
+	}
+}
+
+func TestEntriesSkipOwnAcceptedAnswer(t *testing.T) {
+	ledger := NewLedger(slog.Default(), testConfig(), nil)
+	own := models.Answer{ID: 2, UserID: author}
+	for _, event := range []events.Event{
+		events.NewAnswerAccepted(models.AnswerAccepted{Answer: own, QuestionUserID: author}),
+		events.NewAnswerAccepted(models.AnswerAccepted{Answer: models.Answer{ID: 3, UserID: voter}, Previous: &own, QuestionUserID: author}),
+		events.NewAnswerUnaccepted(models.AnswerUnaccepted{Answer: own, QuestionUserID: author}),
+	} {
+		entries, err := ledger.Entries(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			if entry.UserID == author {
+				t.Errorf("Excpected no points for accepting an own answer, got %+v", entry)
+			}
+		}
+	}
+}
+
+func TestHandleIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	users := mock.NewMockStorageUsers(questions)
+	questions.CreateQuestion(ctx, &models.Question{Text: "test"})
+	answers.CreateAnswer(ctx, []*models.Answer{{QuestionID: 1, UserID: author, Text: "test"}})
+	ledger := NewLedger(slog.Default(), testConfig(), users)
+
+	accepted, _ := answers.AcceptAnswer(ctx, 1)
+	event := events.NewAnswerAccepted(accepted)
+	event.ID = 3
+	for i := 0; i < 2; i++ {
+		if err := ledger.Handle(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	user, _ := users.User(ctx, author)
+	if user.Reputation != 15 {
+		t.Errorf("Excpected reputation 15 after a redelivered event, got %d", user.Reputation)
+	}
+	if err := ledger.Recompute(ctx); err != nil {
+		t.Fatal(err)
+	}
+	user, _ = users.User(ctx, author)
+	if user.Reputation != 15 {
+		t.Errorf("Excpected recomputed reputation 15, got %d", user.Reputation)
+	}
+}
+
+func testConfig() config.ReputationConfig {
+	return config.ReputationConfig{
+		AnswerUpvoted: 10,
+		AnswerDownvoted: -2,
+		AnswerAccepted: 15,
+		DownvoteCast: -1,
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// QuestionsByID returns the questions found, by ID.
+func(s *Service) QuestionsByID(ctx context.Context, ids []int) (map[int]models.Question, error) {
+	questions, err := s.questionStorage.QuestionsByID(ctx, ids)
+	if err != nil {
+		return nil, s.batchError(err)
+	}
+	res := make(map[int]models.Question, len(questions))
+	for _, question := range questions {
+		res[question.ID] = question
+	}
+
+	return res, nil
+}
+
+// AnswersByQuestion returns the answers of each question, oldest first as
+// in GET /questions/{id}.
+func(s *Service) AnswersByQuestion(ctx context.Context, questionIDs []int) (map[int][]models.Answer, error) {
+	answers, err := s.answerStorage.AnswersByQuestion(ctx, questionIDs)
+	if err != nil {
+		return nil, s.batchError(err)
+	}
+	res := make(map[int][]models.Answer, len(questionIDs))
+	for _, answer := range answers {
+		res[answer.QuestionID] = append(res[answer.QuestionID], answer)
+	}
+
+	return res, nil
+}
+
+// UsersByID returns the users found, by ID. Malformed IDs are not looked
+// up.
+func(s *Service) UsersByID(ctx context.Context, ids []string) (map[string]models.User, error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if validUserID(id) {
+			valid = append(valid, id)
+		}
+	}
+	res := make(map[string]models.User, len(valid))
+	if len(valid) == 0 {
+		return res, nil
+	}
+	users, err := s.userStorage.UsersByID(ctx, valid)
+	if err != nil {
+		return nil, s.batchError(err)
+	}
+	for _, user := range users {
+		res[user.ID] = user
+	}
+
+	return res, nil
+}
+
+// AnswersByUser returns the page of answers of each user, newest first as
+// in GET /users/{id}/answers.
+func(s *Service) AnswersByUser(ctx context.Context, ids []string, page models.Page) (map[string][]models.Answer, error) {
+	page = normalizePage(page)
+	answers, err := s.userStorage.AnswersByUser(ctx, ids, page)
+	if err != nil {
+		return nil, s.batchError(err)
+	}
+	res := make(map[string][]models.Answer, len(ids))
+	for _, answer := range answers {
+		res[answer.UserID] = append(res[answer.UserID], answer)
+	}
+
+	return res, nil
+}
+
+// QuestionsPage returns a page of the questions, newest first or by views,
+// only those tagged tag when it is set.
+func(s *Service) QuestionsPage(ctx context.Context, sort, tag string, page models.Page) (models.GetQuestionsResponse, error) {
+	order := models.QuestionSort(sort)
+	if order != models.SortDefault && order != models.SortViews {
+		return models.GetQuestionsResponse{}, ErrInvalidSort
+	}
+	if tag != "" {
+		tags, err := NormalizeTags([]string{tag})
+		if err != nil {
+			return models.GetQuestionsResponse{}, err
+		}
+		tag = tags[0]
+	}
+	questions, err := s.questionStorage.QuestionsPage(ctx, order, tag, normalizePage(page))
+	if err != nil {
+		return models.GetQuestionsResponse{}, s.batchError(err)
+	}
+
+	return models.GetQuestionsResponse{Questions: questions}, nil
+}
+
+// QuestionsByTag returns the page of questions of each tag, newest first.
+// The tags must be normalized.
+func(s *Service) QuestionsByTag(ctx context.Context, tags []string, page models.Page) (map[string][]models.Question, error) {
+	page = normalizePage(page)
+	questions, err := s.questionStorage.QuestionsByTag(ctx, tags, page)
+	if err != nil {
+		return nil, s.batchError(err)
+	}
+	res := make(map[string][]models.Question, len(tags))
+	for _, question := range questions {
+		res[question.Tag] = append(res[question.Tag], question.Question)
+	}
+
+	return res, nil
+}
+
+func(s *Service) batchError(err error) error {
+	s.log.Error(
+		"DB_ReadingError",
+		slog.String("component", "db"),
+		slog.Any("error", err),
+	)
+	return errors.New("DB_ReadingError")
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidComment = errors.New("InvalidComment")
+
+// maxCommentLength bounds a comment, in characters; longer remarks are
+// answers.
+const maxCommentLength = 600
+
+type StorageComment interface {
+	// CreateComment returns gorm.ErrRecordNotFound when the question or
+	// answer commented on does not exist.
+	CreateComment(ctx context.Context, data *models.Comment) error
+	Comment(ctx context.Context, id int) (models.Comment, error)
+	// QuestionComments and AnswerComments list oldest first and return
+	// gorm.ErrRecordNotFound for a missing parent.
+	QuestionComments(ctx context.Context, questionID int, page models.Page) ([]models.Comment, error)
+	AnswerComments(ctx context.Context, answerID int, page models.Page) ([]models.Comment, error)
+	DeleteComment(ctx context.Context, id int) (int, error)
+	// CommentsByQuestion and CommentsByAnswer return the page of comments
+	// of each parent, for the GraphQL loaders.
+	CommentsByQuestion(ctx context.Context, questionIDs []int, page models.Page) ([]models.Comment, error)
+	CommentsByAnswer(ctx context.Context, answerIDs []int, page models.Page) ([]models.Comment, error)
+}
+
+// CommentQuestion adds a comment to the question.
+func(s *Service) CommentQuestion(ctx context.Context, id int, data []byte) (models.CreateCommentResponse, error) {
+	return s.newComment(ctx, models.Comment{QuestionID: &id}, data)
+}
+
+// CommentAnswer adds a comment to the answer.
+func(s *Service) CommentAnswer(ctx context.Context, id int, data []byte) (models.CreateCommentResponse, error) {
+	return s.newComment(ctx, models.Comment{AnswerID: &id}, data)
+}
+
+func(s *Service) newComment(ctx context.Context, comment models.Comment, data []byte) (models.CreateCommentResponse, error) {
+	var request models.CreateCommentRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return models.CreateCommentResponse{}, fmt.Errorf("%w: %v", ErrInvalidComment, err)
+	}
+	if !validUserID(request.UserID) {
+		return models.CreateCommentResponse{}, ErrInvalidUser
+	}
+	text := strings.TrimSpace(request.Text)
+	if text == "" || utf8.RuneCountInString(text) > maxCommentLength {
+		return models.CreateCommentResponse{}, fmt.Errorf("%w: text must have 1 to %d characters", ErrInvalidComment, maxCommentLength)
+	}
+	comment.UserID = request.UserID
+	comment.Text = text
+
+	err := s.commentStorage.CreateComment(ctx, &comment)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.CreateCommentResponse{}, err
+	}
+	if err != nil {
+		s.log.Error(
+			"DB_WritingError",
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.CreateCommentResponse{}, errors.New("DB_WritingError")
+	}
+	s.log.Info(fmt.Sprintf("Create comment with id: %d", comment.ID))
+	s.notify()
+
+	return models.CreateCommentResponse{Comment: comment}, nil
+}
+
+// QuestionComments returns a page of the comments of the question, oldest
+// first.
+func(s *Service) QuestionComments(ctx context.Context, id int, page models.Page) (models.GetCommentsResponse, error) {
+	page = normalizePage(page)
+	comments, err := s.commentStorage.QuestionComments(ctx, id, page)
+	return s.commentsResponse(comments, page, err)
+}
+
+// AnswerComments returns a page of the comments of the answer, oldest
+// first.
+func(s *Service) AnswerComments(ctx context.Context, id int, page models.Page) (models.GetCommentsResponse, error) {
+	page = normalizePage(page)
+	comments, err := s.commentStorage.AnswerComments(ctx, id, page)
+	return s.commentsResponse(comments, page, err)
+}
+
+func(s *Service) commentsResponse(comments []models.Comment, page models.Page, err error) (models.GetCommentsResponse, error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.GetCommentsResponse{}, err
+	}
+	if err != nil {
+		s.log.Error(
+			"DB_ReadingError",
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetCommentsResponse{}, errors.New("DB_ReadingError")
+	}
+
+	return models.GetCommentsResponse{Comments: comments, Limit: page.Limit, Offset: page.Offset}, nil
+}
+
+// DeleteComment removes the comment. Only its author may, or a user with
+// the edit_others privilege.
+func(s *Service) DeleteComment(ctx context.Context, id int, userID string) error {
+	if !validUserID(userID) {
+		return ErrInvalidUser
+	}
+	comment, err := s.commentStorage.Comment(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err != nil {
+		s.log.Error(
+			"DB_ReadingError",
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return errors.New("DB_ReadingError")
+	}
+	if comment.UserID != userID {
+		if err := s.requirePrivilege(ctx, userID, PrivilegeEditOthers); err != nil {
+			return err
+		}
+	}
+	rowsAffected, err := s.commentStorage.DeleteComment(ctx, id)
+	if err != nil {
+		s.log.Error(
+			"DB_DeletingError",
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return errors.New("DB_DeletingError")
+	}
+	if rowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	s.log.Info(fmt.Sprintf("Delete comment with id: %d", id))
+
+	return nil
+}
+
+// CommentsByQuestion returns the page of comments of each question, oldest
+// first.
+func(s *Service) CommentsByQuestion(ctx context.Context, questionIDs []int, page models.Page) (map[int][]models.Comment, error) {
+	comments, err := s.commentStorage.CommentsByQuestion(ctx, questionIDs, normalizePage(page))
+	if err != nil {
+		return nil, s.batchError(err)
+	}
+	res := make(map[int][]models.Comment, len(questionIDs))
+	for _, comment := range comments {
+		res[*comment.QuestionID] = append(res[*comment.QuestionID], comment)
+	}
+
+	return res, nil
+}
+
+// CommentsByAnswer returns the page of comments of each answer, oldest
+// first.
+func(s *Service) CommentsByAnswer(ctx context.Context, answerIDs []int, page models.Page) (map[int][]models.Comment, error) {
+	comments, err := s.commentStorage.CommentsByAnswer(ctx, answerIDs, normalizePage(page))
+	if err != nil {
+		return nil, s.batchError(err)
+	}
+	res := make(map[int][]models.Comment, len(answerIDs))
+	for _, comment := range comments {
+		res[*comment.AnswerID] = append(res[*comment.AnswerID], comment)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDuplicateQuestion = errors.New("DuplicateQuestion")
+	ErrInvalidDuplicate = errors.New("InvalidDuplicate")
+)
+
+// MarkDuplicate marks the question, at version unless it is 0, as a
+// duplicate of the target. The link goes to the question the target chain
+// ends at, so marking never makes a cycle. Only users with the edit_others
+// privilege can mark duplicates.
+func(s *Service) MarkDuplicate(ctx context.Context, id, target, version int, data []byte) (models.MarkDuplicateResponse, error) {
+	var request models.MarkDuplicateRequest
+	err := json.Unmarshal(data, &request)
+	if err != nil {
+		s.log.Error(
+			"ParsingJSONError", 
+			slog.String("component", "json/unmarshaling"),
+			slog.Any("error", err),
+		)
+		return models.MarkDuplicateResponse{}, fmt.Errorf("%w: %v", ErrInvalidDuplicate, err)
+	}
+	if err := s.requirePrivilege(ctx, request.UserID, PrivilegeEditOthers); err != nil {
+		return models.MarkDuplicateResponse{}, err
+	}
+
+	question, err := s.questionStorage.MarkDuplicate(ctx, id, target, version)
+	if errors.Is(err, models.ErrDuplicateChain) {
+		return models.MarkDuplicateResponse{}, fmt.Errorf("%w: question would be its own duplicate", ErrInvalidDuplicate)
+	}
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.log.Error(
+			"DB_WritingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.MarkDuplicateResponse{}, errors.New("DB_WritingError")
+	}
+	if err != nil {
+		return models.MarkDuplicateResponse{}, s.missedQuestion(ctx, id, version)
+	}
+	s.log.Info(fmt.Sprintf("Mark question with id: %d as duplicate of %d", id, *question.DuplicateOf))
+	s.notify()
+
+	return models.MarkDuplicateResponse{Question: question}, nil
+}
+
+// duplicates finds the existing questions similar to the text.
+func(s *Service) duplicates(ctx context.Context, text string) ([]models.SimilarQuestion, error) {
+	if s.duplicatesConfig.Limit <= 0 {
+		return nil, nil
+	}
+	res, err := s.questionStorage.SimilarQuestions(ctx, text, s.duplicatesConfig.Threshold, s.duplicatesConfig.Limit)
+	if err != nil {
+		s.log.Error(
+			"DB_ReadingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return nil, errors.New("DB_ReadingError")
+	}
+
+	return res, nil
+}
+
+// missedQuestion tells why a change of the question at version found no
+// row: the question, or the question it refers to, is gone, or the question
+// is at another version.
+func(s *Service) missedQuestion(ctx context.Context, id, version int) error {
+	if version != 0 {
+		if question, err := s.questionStorage.Exist(ctx, id); err == nil && question.Version != version {
+			return ErrVersionMismatch
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func(s *Service) existingQuestion(ctx context.Context, id int) (models.Question, error) {
+	question, err := s.questionStorage.Exist(ctx, id)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.log.Error(
+			"DB_ReadingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return question, errors.New("DB_ReadingError")
+	}
+
+	return question, err
+}
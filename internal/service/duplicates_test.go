@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+func TestNewQuestionReportsDuplicates(t *testing.T) {
+	service := newTestService(2, 1)
+	service.duplicatesConfig = config.DuplicatesConfig{Threshold: 0.5, Limit: 5}
+	first := newQuestion(service, "How do I reverse a slice in Go?", false, t)
+	newQuestion(service, "What is a goroutine?", false, t)
+
+	res := newQuestion(service, "How to reverse a slice in Go", false, t)
+	if len(res.Duplicates) != 1 || res.Duplicates[0].ID != first.Question.ID {
+		t.Errorf("Excpected question %d as duplicate, got %+v", first.Question.ID, res.Duplicates)
+	}
+	if res.Question.ID == 0 {
+		t.Error("Excpected question to be created")
+	}
+}
+
+func TestNewQuestionStrictRejectsDuplicates(t *testing.T) {
+	service := newTestService(2, 1)
+	service.duplicatesConfig = config.DuplicatesConfig{Threshold: 0.5, Limit: 5}
+	newQuestion(service, "How do I reverse a slice in Go?", false, t)
+
+	raw, _ := json.Marshal(models.CreateQuestionRequest{Text: "How do I reverse a slice in Go"})
+	res, err := service.NewQuestion(context.Background(), raw, true)
+	if !errors.Is(err, ErrDuplicateQuestion) || len(res.Duplicates) != 1 {
+		t.Fatalf("Excpected ErrDuplicateQuestion with duplicates, got %v %+v", err, res)
+	}
+	all, _ := service.AllQuestions(context.Background(), "")
+	if len(all.Questions) != 1 {
+		t.Errorf("Excpected 1 question, got %d", len(all.Questions))
+	}
+}
+
+func TestMarkDuplicateLinksToCanonical(t *testing.T) {
+	service := newTestService(3, 1)
+	first, _ := CreateQuestion(service, t)
+	second, _ := CreateQuestion(service, t)
+	third, _ := CreateQuestion(service, t)
+	body := []byte(`{"UserID":"` + testVoterID + `"}`)
+
+	res, err := service.MarkDuplicate(context.Background(), second.Question.ID, first.Question.ID, 0, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Question.DuplicateOf == nil || *res.Question.DuplicateOf != first.Question.ID || res.Question.Version != 2 {
+		t.Errorf("Excpected duplicate of %d at version 2, got %+v", first.Question.ID, res.Question)
+	}
+
+	res, err = service.MarkDuplicate(context.Background(), third.Question.ID, second.Question.ID, 0, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *res.Question.DuplicateOf != first.Question.ID {
+		t.Errorf("Excpected link to canonical question %d, got %d", first.Question.ID, *res.Question.DuplicateOf)
+	}
+
+	_, err = service.MarkDuplicate(context.Background(), first.Question.ID, third.Question.ID, 0, body)
+	if !errors.Is(err, ErrInvalidDuplicate) {
+		t.Errorf("Excpected ErrInvalidDuplicate for a cycle, got %v", err)
+	}
+	_, err = service.MarkDuplicate(context.Background(), third.Question.ID, first.Question.ID, 1, body)
+	if !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("Excpected ErrVersionMismatch for a stale version, got %v", err)
+	}
+}
+
+func TestMarkDuplicateRequiresPrivilege(t *testing.T) {
+	service := newTestService(2, 1)
+	service.reputation.Privileges.EditOthers = 2000
+	first, _ := CreateQuestion(service, t)
+	second, _ := CreateQuestion(service, t)
+
+	_, err := service.MarkDuplicate(context.Background(), second.Question.ID, first.Question.ID, 0, []byte(`{"UserID":"`+testVoterID+`"}`))
+	if !errors.Is(err, ErrPrivilegeRequired) {
+		t.Errorf("Excpected ErrPrivilegeRequired, got %v", err)
+	}
+}
+
+func newQuestion(service *Service, text string, strict bool, t *testing.T) models.CreateQuestionResponse {
+	raw, _ := json.Marshal(models.CreateQuestionRequest{Text: text})
+	res, err := service.NewQuestion(context.Background(), raw, strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidMove = errors.New("InvalidMove")
+
+// MergeQuestions merges the question, at version unless it is 0, into the
+// target: its answers move with their votes and it stays as a stub
+// redirecting to the target. Like MarkDuplicate, it needs the edit_others
+// privilege and merges into the question the target chain of duplicates
+// ends at.
+func(s *Service) MergeQuestions(ctx context.Context, id, target, version int, data []byte) (models.MergeQuestionResponse, error) {
+	var request models.MergeQuestionRequest
+	err := json.Unmarshal(data, &request)
+	if err != nil {
+		s.log.Error(
+			"ParsingJSONError", 
+			slog.String("component", "json/unmarshaling"),
+			slog.Any("error", err),
+		)
+		return models.MergeQuestionResponse{}, fmt.Errorf("%w: %v", ErrInvalidMove, err)
+	}
+	if err := s.requirePrivilege(ctx, request.UserID, PrivilegeEditOthers); err != nil {
+		return models.MergeQuestionResponse{}, err
+	}
+
+	merged, err := s.questionStorage.MergeQuestions(ctx, id, target, version, request.UserID)
+	if errors.Is(err, models.ErrDuplicateChain) {
+		return models.MergeQuestionResponse{}, fmt.Errorf("%w: question would be merged into itself", ErrInvalidMove)
+	}
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.log.Error(
+			"DB_WritingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.MergeQuestionResponse{}, errors.New("DB_WritingError")
+	}
+	if err != nil {
+		return models.MergeQuestionResponse{}, s.missedQuestion(ctx, id, version)
+	}
+	s.log.Info(fmt.Sprintf("Merge question with id: %d into %d, moved %d answers", id, merged.Question.ID, len(merged.Answers)))
+	s.notify()
+
+	return models.MergeQuestionResponse{Question: merged.Question, Merged: merged.Merged, Answers: merged.Answers}, nil
+}
+
+// PatchAnswer applies the change to the answer, at version unless it is 0.
+// Moving an answer to another question needs the edit_others privilege.
+func(s *Service) PatchAnswer(ctx context.Context, id, version int, data []byte) (models.GetAnswerResponse, error) {
+	var request models.PatchAnswerRequest
+	err := json.Unmarshal(data, &request)
+	if err != nil {
+		s.log.Error(
+			"ParsingJSONError", 
+			slog.String("component", "json/unmarshaling"),
+			slog.Any("error", err),
+		)
+		return models.GetAnswerResponse{}, fmt.Errorf("%w: %v", ErrInvalidMove, err)
+	}
+	if request.QuestionID == nil {
+		return models.GetAnswerResponse{}, fmt.Errorf("%w: nothing to change", ErrInvalidMove)
+	}
+	if err := s.requirePrivilege(ctx, request.UserID, PrivilegeEditOthers); err != nil {
+		return models.GetAnswerResponse{}, err
+	}
+
+	answer, err := s.Answer(ctx, id)
+	if err != nil {
+		return models.GetAnswerResponse{}, err
+	}
+	if version != 0 && answer.Answer.Version != version {
+		return models.GetAnswerResponse{}, ErrVersionMismatch
+	}
+	target := *request.QuestionID
+	if answer.Answer.QuestionID == target {
+		return answer, nil
+	}
+	if _, err := s.existingQuestion(ctx, target); err != nil {
+		return models.GetAnswerResponse{}, err
+	}
+
+	moved, err := s.answerStorage.MoveAnswer(ctx, id, target, version, request.UserID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.log.Error(
+			"DB_WritingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetAnswerResponse{}, errors.New("DB_WritingError")
+	}
+	if err != nil {
+		if version != 0 {
+			if answer, err := s.answerStorage.GetAnswer(ctx, id); err == nil && answer.Version != version {
+				return models.GetAnswerResponse{}, ErrVersionMismatch
+			}
+		}
+		return models.GetAnswerResponse{}, err
+	}
+	s.log.Info(fmt.Sprintf("Move answer with id: %d from question %d to %d", id, moved.From, target))
+	s.notify()
+
+	return models.GetAnswerResponse{Answer: moved.Answer}, nil
+}
+
+// requirePrivilege checks that the user is valid and has the privilege.
+func(s *Service) requirePrivilege(ctx context.Context, userID, privilege string) error {
+	if !validUserID(userID) {
+		return ErrInvalidUser
+	}
+	allowed, err := s.hasPrivilege(ctx, userID, privilege)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s", ErrPrivilegeRequired, privilege)
+	}
+
+	return nil
+}
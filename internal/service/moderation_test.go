@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+func TestMergeQuestionsMovesAnswers(t *testing.T) {
+	service := newTestService(2, 2)
+	target, _ := CreateQuestion(service, t)
+	merged, _ := CreateQuestion(service, t)
+	created, _ := CreateAnswer(service, merged.Question.ID, t)
+	id := created.Answers[0].ID
+	if _, err := service.VoteAnswer(context.Background(), id, []byte(`{"UserID":"`+testVoterID+`","Value":1}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := service.MergeQuestions(context.Background(), merged.Question.ID, target.Question.ID, 0, []byte(`{"UserID":"`+testVoterID+`"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Answers) != 1 || res.Answers[0].QuestionID != target.Question.ID || res.Answers[0].Score != 1 {
+		t.Errorf("Excpected the voted answer on question %d, got %+v", target.Question.ID, res.Answers)
+	}
+	if res.Merged.DuplicateOf == nil || *res.Merged.DuplicateOf != target.Question.ID {
+		t.Errorf("Excpected a stub redirecting to %d, got %+v", target.Question.ID, res.Merged)
+	}
+
+	question, err := service.Question(context.Background(), target.Question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(question.Answers) != 1 {
+		t.Errorf("Excpected 1 answer on the target, got %d", len(question.Answers))
+	}
+	audit := service.answerStorage.(*mock.MockStorageAnswers).Audit()
+	if len(audit) != 1 || audit[0].Action != models.AuditQuestionMerged || audit[0].UserID != testVoterID {
+		t.Errorf("Excpected the merge in the audit log, got %+v", audit)
+	}
+}
+
+func TestMergeQuestionsKeepsAcceptedOfTarget(t *testing.T) {
+	service := newTestService(2, 2)
+	target := askQuestion(service, testVoterID, t)
+	merged := askQuestion(service, testVoterID, t)
+	kept, _ := CreateAnswer(service, target.Question.ID, t)
+	moved, _ := CreateAnswer(service, merged.Question.ID, t)
+	for _, created := range []models.CreateAnswerResponse{kept, moved} {
+		if _, err := service.AcceptAnswer(context.Background(), created.Answers[0].ID, testVoterID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := service.MergeQuestions(context.Background(), merged.Question.ID, target.Question.ID, 0, []byte(`{"UserID":"`+testVoterID+`"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Answers[0].Accepted {
+		t.Error("Excpected the moved answer to lose acceptance")
+	}
+	answer, _ := service.Answer(context.Background(), kept.Answers[0].ID)
+	if !answer.Answer.Accepted {
+		t.Error("Excpected the answer of the target to stay accepted")
+	}
+	pending, _ := service.answerStorage.(*mock.MockStorageAnswers).Outbox().PendingOutbox(context.Background(), 100)
+	unaccepted := 0
+	for _, row := range pending {
+		if row.Type == events.AnswerUnaccepted && row.AnswerID == moved.Answers[0].ID {
+			unaccepted++
+		}
+	}
+	if unaccepted != 1 {
+		t.Errorf("Excpected an answer.unaccepted event for the moved answer, got %d", unaccepted)
+	}
+}
+
+func TestMergeQuestionIntoItself(t *testing.T) {
+	service := newTestService(2, 1)
+	first, _ := CreateQuestion(service, t)
+	second, _ := CreateQuestion(service, t)
+	body := []byte(`{"UserID":"` + testVoterID + `"}`)
+	if _, err := service.MarkDuplicate(context.Background(), second.Question.ID, first.Question.ID, 0, body); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := service.MergeQuestions(context.Background(), first.Question.ID, second.Question.ID, 0, body)
+	if !errors.Is(err, ErrInvalidMove) {
+		t.Errorf("Excpected ErrInvalidMove, got %v", err)
+	}
+}
+
+func TestMergeQuestionsAtStaleVersion(t *testing.T) {
+	service := newTestService(2, 1)
+	target, _ := CreateQuestion(service, t)
+	merged, _ := CreateQuestion(service, t)
+	body := []byte(`{"UserID":"` + testVoterID + `"}`)
+
+	_, err := service.MergeQuestions(context.Background(), merged.Question.ID, target.Question.ID, merged.Question.Version + 1, body)
+	if !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("Excpected ErrVersionMismatch, got %v", err)
+	}
+	if _, err := service.MergeQuestions(context.Background(), merged.Question.ID, target.Question.ID, merged.Question.Version, body); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPatchAnswerMovesAnswer(t *testing.T) {
+	service := newTestService(2, 1)
+	from, _ := CreateQuestion(service, t)
+	to, _ := CreateQuestion(service, t)
+	created, _ := CreateAnswer(service, from.Question.ID, t)
+	id := created.Answers[0].ID
+
+	res, err := service.PatchAnswer(context.Background(), id, 0, []byte(fmt.Sprintf(`{"UserID":"%s","QuestionID":%d}`, testVoterID, to.Question.ID)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Answer.QuestionID != to.Question.ID || res.Answer.Version != 2 {
+		t.Errorf("Excpected answer on question %d at version 2, got %+v", to.Question.ID, res.Answer)
+	}
+	audit := service.answerStorage.(*mock.MockStorageAnswers).Audit()
+	if len(audit) != 1 || audit[0].Action != models.AuditAnswerMoved || audit[0].AnswerID != id {
+		t.Errorf("Excpected the move in the audit log, got %+v", audit)
+	}
+}
+
+func TestPatchAnswerInvalid(t *testing.T) {
+	service := newTestService(1, 1)
+	question, _ := CreateQuestion(service, t)
+	created, _ := CreateAnswer(service, question.Question.ID, t)
+	id := created.Answers[0].ID
+
+	_, err := service.PatchAnswer(context.Background(), id, 0, []byte(`{"UserID":"`+testVoterID+`"}`))
+	if !errors.Is(err, ErrInvalidMove) {
+		t.Errorf("Excpected ErrInvalidMove, got %v", err)
+	}
+	_, err = service.PatchAnswer(context.Background(), id, 0, []byte(`{"UserID":"`+testVoterID+`","QuestionID":42}`))
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Excpected ErrRecordNotFound, got %v", err)
+	}
+	_, err = service.PatchAnswer(context.Background(), id, 2, []byte(`{"UserID":"`+testVoterID+`","QuestionID":42}`))
+	if !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("Excpected ErrVersionMismatch, got %v", err)
+	}
+}
@@ -7,37 +7,84 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/markdown"
 	"github.com/behummble/Questions-answers/internal/models"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrVersionMismatch = errors.New("VersionMismatch")
+	ErrInvalidSort = errors.New("InvalidSort")
+)
+
 type Service struct {
 	log *slog.Logger
 	questionStorage StorageQuestion
 	answerStorage StorageAnswer
+	userStorage StorageUser
+	commentStorage StorageComment
+	reputation config.ReputationConfig
+	duplicatesConfig config.DuplicatesConfig
+	notifier Notifier
+}
+
+// Notifier is woken after every successful write, so the events the storage
+// wrote to the outbox are relayed without waiting for the next poll.
+type Notifier interface {
+	Notify()
 }
 
 type StorageQuestion interface {
 	CreateQuestion(ctx context.Context, data *models.Question) error
 	Question(ctx context.Context, id int) (models.QuestionWithAnswers, error)
-	AllQuestions(ctx context.Context) ([]models.Question, error)
-	DeleteQuestion(ctx context.Context, id int) (int, error)
+	AllQuestions(ctx context.Context, order models.QuestionSort) ([]models.Question, error)
+	StreamQuestions(ctx context.Context, order models.QuestionSort, yield func(models.Question) error) error
+	// DeleteQuestion removes nothing, without an error, when the question
+	// is not at version.
+	DeleteQuestion(ctx context.Context, id, version int) (models.QuestionDelete, error)
+	CreateQuestions(ctx context.Context, data []*models.Question) error
+	WriteQuestions(ctx context.Context, batch *models.QuestionBatch) error
 	Exist(ctx context.Context, id int) (models.Question, error)
+	SimilarQuestions(ctx context.Context, text string, threshold float64, limit int) ([]models.SimilarQuestion, error)
+	MarkDuplicate(ctx context.Context, id, target, version int) (models.Question, error)
+	MergeQuestions(ctx context.Context, id, target, version int, userID string) (models.QuestionsMerged, error)
+	// QuestionsByID loads many questions in one query for the GraphQL
+	// loaders. Missing IDs are left out.
+	QuestionsByID(ctx context.Context, ids []int) ([]models.Question, error)
+	// QuestionsPage returns a page of the questions, newest first unless
+	// order is SortViews, only those tagged tag when it is set.
+	QuestionsPage(ctx context.Context, order models.QuestionSort, tag string, page models.Page) ([]models.Question, error)
+	// QuestionsByTag returns the page of questions of each tag, newest
+	// first.
+	QuestionsByTag(ctx context.Context, tags []string, page models.Page) ([]models.TaggedQuestion, error)
 	Shutdown(ctx context.Context)
 }
 
 type StorageAnswer interface {
 	CreateAnswer(ctx context.Context, data []*models.Answer) error
 	GetAnswer(ctx context.Context, id int) (models.Answer, error)
-	DeleteAnswer(ctx context.Context, id int) (int, error)
+	StreamAnswers(ctx context.Context, questionID int, yield func(models.Answer) error) error
+	DeleteAnswer(ctx context.Context, id, version int) (int, error)
+	VoteAnswer(ctx context.Context, vote *models.Vote) (models.AnswerVoted, error)
+	AcceptAnswer(ctx context.Context, id int) (models.AnswerAccepted, error)
+	MoveAnswer(ctx context.Context, id, target, version int, userID string) (models.AnswerMoved, error)
+	// AnswersByQuestion loads the answers of many questions in one query
+	// for the GraphQL loaders.
+	AnswersByQuestion(ctx context.Context, questionIDs []int) ([]models.Answer, error)
 	Shutdown(ctx context.Context)
 }
 
-func NewService(log *slog.Logger, questionStorage StorageQuestion, answerStorage StorageAnswer) *Service {
+func NewService(log *slog.Logger, questionStorage StorageQuestion, answerStorage StorageAnswer, userStorage StorageUser, commentStorage StorageComment, reputation config.ReputationConfig, duplicates config.DuplicatesConfig, notifier Notifier) *Service {
 	return &Service{
 		log: log,
 		questionStorage: questionStorage,
 		answerStorage: answerStorage,
+		userStorage: userStorage,
+		commentStorage: commentStorage,
+		reputation: reputation,
+		duplicatesConfig: duplicates,
+		notifier: notifier,
 	}
 }
 
@@ -46,7 +93,10 @@ func(s *Service) Shutdown(ctx context.Context) {
 	s.questionStorage.Shutdown(ctx)
 }
 
-func(s *Service) NewQuestion(ctx context.Context, question []byte) (models.CreateQuestionResponse, error) {
+// NewQuestion creates the question and reports the existing questions with
+// a similar text. In strict mode a question with duplicates is not created
+// and ErrDuplicateQuestion is returned with them.
+func(s *Service) NewQuestion(ctx context.Context, question []byte, strict bool) (models.CreateQuestionResponse, error) {
 	var questionRequest models.CreateQuestionRequest
 	err := json.Unmarshal(question, &questionRequest)
 	if err != nil {
@@ -61,9 +111,35 @@ func(s *Service) NewQuestion(ctx context.Context, question []byte) (models.Creat
 	if questionRequest.Text == "" {
 		return models.CreateQuestionResponse{}, errors.New("BodyExecutionError")
 	}
+	if questionRequest.UserID != "" && !validUserID(questionRequest.UserID) {
+		return models.CreateQuestionResponse{}, ErrInvalidUser
+	}
+	tags, err := NormalizeTags(questionRequest.Tags)
+	if err != nil {
+		return models.CreateQuestionResponse{}, err
+	}
+
+	duplicates, err := s.duplicates(ctx, questionRequest.Text)
+	if err != nil {
+		return models.CreateQuestionResponse{}, err
+	}
+	if strict && len(duplicates) != 0 {
+		return models.CreateQuestionResponse{Duplicates: duplicates}, ErrDuplicateQuestion
+	}
+
+	html, err := s.render(questionRequest.Text)
+	if err != nil {
+		return models.CreateQuestionResponse{}, err
+	}
 
 	questionData := models.Question{
 		Text: questionRequest.Text,
+		TextHTML: html,
+		Tags: tags,
+		Version: 1,
+	}
+	if questionRequest.UserID != "" {
+		questionData.UserID = &questionRequest.UserID
 	}
 
 	err = s.questionStorage.CreateQuestion(ctx, &questionData)
@@ -77,8 +153,9 @@ func(s *Service) NewQuestion(ctx context.Context, question []byte) (models.Creat
 	}
 
 	s.log.Info(fmt.Sprintf("Write new question with id: %d", questionData.ID))
+	s.notify()
 
-	return models.CreateQuestionResponse{Question: questionData}, err
+	return models.CreateQuestionResponse{Question: questionData, Duplicates: duplicates}, err
 }
 
 func(s *Service) Question(ctx context.Context, id int) (models.GetQuestionResponse, error) {	
@@ -99,8 +176,12 @@ func(s *Service) Question(ctx context.Context, id int) (models.GetQuestionRespon
 	return models.GetQuestionResponse{Question: res.Question, Answers: res.Answers}, nil
 }
 
-func(s *Service) AllQuestions(ctx context.Context) (models.GetQuestionsResponse, error) {
-	allQuestions, err := s.questionStorage.AllQuestions(ctx)
+func(s *Service) AllQuestions(ctx context.Context, sort string) (models.GetQuestionsResponse, error) {
+	order := models.QuestionSort(sort)
+	if order != models.SortDefault && order != models.SortViews {
+		return models.GetQuestionsResponse{}, ErrInvalidSort
+	}
+	allQuestions, err := s.questionStorage.AllQuestions(ctx, order)
 	if err != nil {
 		s.log.Error(
 			"DB_ReadingError", 
@@ -113,8 +194,10 @@ func(s *Service) AllQuestions(ctx context.Context) (models.GetQuestionsResponse,
 	return models.GetQuestionsResponse{Questions: allQuestions}, err
 }
 
-func(s *Service) DeleteQuestion(ctx context.Context, id int) error {
-	rowsAffected, err := s.questionStorage.DeleteQuestion(ctx, id)
+// DeleteQuestion removes the question. A non-zero version makes the delete
+// conditional: ErrVersionMismatch is returned if the stored version differs.
+func(s *Service) DeleteQuestion(ctx context.Context, id, version int) error {
+	res, err := s.questionStorage.DeleteQuestion(ctx, id, version)
 	if err != nil {
 		s.log.Error(
 			"DB_DeletingError", 
@@ -123,10 +206,16 @@ func(s *Service) DeleteQuestion(ctx context.Context, id int) error {
 		)
 		return errors.New("DB_DeletingError")
 	}
-	if rowsAffected == 0 {
+	if !res.Deleted {
+		if version != 0 {
+			if _, err := s.questionStorage.Exist(ctx, id); err == nil {
+				return ErrVersionMismatch
+			}
+		}
 		return gorm.ErrRecordNotFound
 	}
 	s.log.Info(fmt.Sprintf("Delete question with id: %d", id))
+	s.notify()
 	return nil
 }
 
@@ -155,16 +244,22 @@ func(s *Service) NewAnswer(ctx context.Context, answer []byte, questionID int) (
 		return models.CreateAnswerResponse{}, errors.New("ParsingJSONError")
 	}
 
-	if len(answerRequest.Texts) == 0 {
+	if len(answerRequest.Texts) == 0 || !validUserID(answerRequest.UserID) {
 		return models.CreateAnswerResponse{}, errors.New("BodyExecutionError")
 	}
 
 	answerData := make([]*models.Answer, 0, len(answerRequest.Texts))
 	for _, text := range answerRequest.Texts {
+		html, err := s.render(text)
+		if err != nil {
+			return models.CreateAnswerResponse{}, err
+		}
 		answerData = append(answerData, &models.Answer{
 			Text: text,
+			TextHTML: html,
 			UserID: answerRequest.UserID,
 			QuestionID: questionID,
+			Version: 1,
 		})
 	}
 
@@ -181,6 +276,7 @@ func(s *Service) NewAnswer(ctx context.Context, answer []byte, questionID int) (
 	for _, answer:= range answerData {
 		s.log.Info(fmt.Sprintf("Create answer: %d for question with id: %d", answer.ID, questionID))
 	}
+	s.notify()
 
 	return models.CreateAnswerResponse{Answers: answerData}, err
 }
@@ -201,8 +297,9 @@ func(s *Service) Answer(ctx context.Context, id int) (models.GetAnswerResponse,
 	return models.GetAnswerResponse{Answer: answer}, err
 }
 
-func(s *Service) DeleteAnswer(ctx context.Context, id int) error {
-	rowsAffected, err := s.answerStorage.DeleteAnswer(ctx, id)
+// DeleteAnswer removes the answer, conditionally on version like DeleteQuestion.
+func(s *Service) DeleteAnswer(ctx context.Context, id, version int) error {
+	rowsAffected, err := s.answerStorage.DeleteAnswer(ctx, id, version)
 	if err != nil {
 		s.log.Error(
 			"DB_DeletingError", 
@@ -212,8 +309,36 @@ func(s *Service) DeleteAnswer(ctx context.Context, id int) error {
 		return errors.New("DB_DeletingError")
 	}
 	if rowsAffected == 0 {
+		if version != 0 {
+			if _, err := s.answerStorage.GetAnswer(ctx, id); err == nil {
+				return ErrVersionMismatch
+			}
+		}
 		return gorm.ErrRecordNotFound
 	}
 	s.log.Info(fmt.Sprintf("Delete answer with id: %d", id))
+	s.notify()
 	return nil
+}
+
+// render converts the Markdown text to the sanitized HTML stored with it.
+func(s *Service) render(text string) (string, error) {
+	html, err := markdown.Render(text)
+	if err != nil {
+		s.log.Error(
+			"RenderingError", 
+			slog.String("component", "markdown"),
+			slog.Any("error", err),
+		)
+		return "", errors.New("RenderingError")
+	}
+
+	return html, nil
+}
+
+func(s *Service) notify() {
+	if s.notifier == nil {
+		return
+	}
+	s.notifier.Notify()
 }
\ No newline at end of file
@@ -3,10 +3,13 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/behummble/Questions-answers/internal/config"
 	"github.com/behummble/Questions-answers/internal/models"
 	"github.com/behummble/Questions-answers/internal/mock"
 )
@@ -17,6 +20,8 @@ func TestNewQuestionCorrect(t *testing.T) {
 		Question: models.Question{
 			ID: 1,
 			Text: "test",
+			TextHTML: "<p>test</p>\n",
+			Version: 1,
 			CreatedAt: defaultTime(),
 		},
 	}
@@ -26,22 +31,92 @@ func TestNewQuestionCorrect(t *testing.T) {
         t.Fatal(err)
     }
 
-	if res != excpected {
+	if !reflect.DeepEqual(res.Question, excpected.Question) || len(res.Duplicates) != 0 {
 		t.Errorf("Excpected result %+v, got %+v", excpected, res)
 	}
 }
 
+func TestNewQuestionNormalizesTags(t *testing.T) {
+	service := newTestService(1, 1)
+
+	res, err := service.NewQuestion(context.Background(), []byte(`{"Text":"test","Tags":["SQL","go","sql"]}`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res.Question.Tags, []string{"go", "sql"}) {
+		t.Errorf("Excpected tags [go sql], got %v", res.Question.Tags)
+	}
+
+	invalid := []string{
+		`{"Text":"test","Tags":["two words"]}`,
+		`{"Text":"test","Tags":["a","b","c","d","e","f"]}`,
+	}
+	for _, raw := range invalid {
+		_, err = service.NewQuestion(context.Background(), []byte(raw), false)
+		if !errors.Is(err, ErrInvalidTags) {
+			t.Errorf("Excpected ErrInvalidTags for %s, got %v", raw, err)
+		}
+	}
+}
+
+func TestQuestionsPageFiltersByTag(t *testing.T) {
+	ctx := context.Background()
+	service := newTestService(3, 1)
+	for _, raw := range []string{`{"Text":"a","Tags":["go"]}`, `{"Text":"b","Tags":["sql"]}`, `{"Text":"c","Tags":["go","sql"]}`} {
+		if _, err := service.NewQuestion(ctx, []byte(raw), false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := service.QuestionsPage(ctx, "", "GO", models.Page{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Questions) != 1 || res.Questions[0].Text != "c" {
+		t.Errorf("Excpected the newest question tagged go, got %+v", res.Questions)
+	}
+	if _, err := service.QuestionsPage(ctx, "", "two words", models.Page{}); !errors.Is(err, ErrInvalidTags) {
+		t.Errorf("Excpected ErrInvalidTags, got %v", err)
+	}
+
+	byTag, err := service.QuestionsByTag(ctx, []string{"go", "sql"}, models.Page{Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byTag["go"]) != 2 || len(byTag["sql"]) != 2 || byTag["sql"][0].Text != "c" {
+		t.Errorf("Excpected 2 questions per tag, newest first, got %+v", byTag)
+	}
+}
+
 func TestNewQuestionWithInvalidData(t *testing.T) {
 	service := newTestService(1, 1)
 
 	raw := "{\"struct\":\"\"}"
 
-	_, err := service.NewQuestion(context.Background(), []byte(raw))
+	_, err := service.NewQuestion(context.Background(), []byte(raw), false)
 	if err == nil {
         t.Error("Excpected error")
     }
 }
 
+func TestNewQuestionWithAuthor(t *testing.T) {
+	service := newTestService(1, 1)
+
+	raw := `{"Text":"test","UserID":"3fa85f64-5717-4562-b3fc-2c963f66afa6"}`
+	res, err := service.NewQuestion(context.Background(), []byte(raw), false)
+	if err != nil {
+        t.Fatal(err)
+    }
+	if res.Question.UserID == nil || *res.Question.UserID != "3fa85f64-5717-4562-b3fc-2c963f66afa6" {
+		t.Errorf("Excpected the author to be recorded, got %+v", res.Question)
+	}
+
+	_, err = service.NewQuestion(context.Background(), []byte(`{"Text":"test","UserID":"bob"}`), false)
+	if !errors.Is(err, ErrInvalidUser) {
+		t.Errorf("Excpected ErrInvalidUser, got %v", err)
+	}
+}
+
 func TestQuestionWithCorrectID(t *testing.T) {
 	service := newTestService(1, 1)
 
@@ -75,7 +150,7 @@ func TestAllQuestionsExists(t *testing.T) {
 		}
 	}
 
-	all, err := service.AllQuestions(context.Background())
+	all, err := service.AllQuestions(context.Background(), "")
 	if err != nil {
         t.Fatal("Unexcpected error")
     }
@@ -85,10 +160,19 @@ func TestAllQuestionsExists(t *testing.T) {
 	}
 }
 
+func TestAllQuestionsInvalidSort(t *testing.T) {
+	service := newTestService(3, 1)
+
+	_, err := service.AllQuestions(context.Background(), "votes")
+	if !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("Excpected %v, got %v", ErrInvalidSort, err)
+	}
+}
+
 func TestAllQuestionsEmpty(t *testing.T) {
 	service := newTestService(3, 1)
 
-	all, err := service.AllQuestions(context.Background())
+	all, err := service.AllQuestions(context.Background(), "")
 	if err != nil {
         t.Fatal("Unexcpected error")
     }
@@ -106,7 +190,7 @@ func TestDeleteQuestionCorrect(t *testing.T) {
         t.Fatal("Unexcpected error")
     }
 
-	err = service.DeleteQuestion(context.Background(), created.Question.ID)
+	err = service.DeleteQuestion(context.Background(), created.Question.ID, 0)
 	if err != nil {
         t.Fatal("Unexcpected error")
     }
@@ -115,12 +199,57 @@ func TestDeleteQuestionCorrect(t *testing.T) {
 func TestDeleteQuestionWithInvalidID(t *testing.T) {
 	service := newTestService(1, 1)
 
-	err := service.DeleteQuestion(context.Background(), 1)
+	err := service.DeleteQuestion(context.Background(), 1, 0)
 	if err == nil {
         t.Error("Unexcpected error")
     }
 }
 
+func TestDeleteQuestionWithStaleVersion(t *testing.T) {
+	service := newTestService(1, 1)
+
+	created, err := CreateQuestion(service, t)
+	if err != nil {
+        t.Fatal("Unexcpected error")
+    }
+
+	err = service.DeleteQuestion(context.Background(), created.Question.ID, created.Question.Version+1)
+	if !errors.Is(err, ErrVersionMismatch) {
+        t.Errorf("Excpected ErrVersionMismatch, got %v", err)
+    }
+
+	err = service.DeleteQuestion(context.Background(), created.Question.ID, created.Question.Version)
+	if err != nil {
+        t.Errorf("Unexcpected error %v", err)
+    }
+}
+
+func TestDeleteQuestionAfterAnswersChanged(t *testing.T) {
+	service := newTestService(1, 1)
+
+	created, err := CreateQuestion(service, t)
+	if err != nil {
+        t.Fatal("Unexcpected error")
+    }
+	if _, err := CreateAnswer(service, created.Question.ID, t); err != nil {
+        t.Fatal("Unexcpected error")
+    }
+
+	err = service.DeleteQuestion(context.Background(), created.Question.ID, created.Question.Version)
+	if !errors.Is(err, ErrVersionMismatch) {
+        t.Errorf("Excpected ErrVersionMismatch for the version before the answer, got %v", err)
+    }
+
+	current, err := service.questionStorage.Question(context.Background(), created.Question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = service.DeleteQuestion(context.Background(), created.Question.ID, current.Question.Version)
+	if err != nil {
+        t.Errorf("Unexcpected error %v", err)
+    }
+}
+
 func TestNewAnswerCorrect(t *testing.T) {
 	service := newTestService(1, 1)
 	question, err := CreateQuestion(service, t)
@@ -214,7 +343,7 @@ func TestDeleteAnswer(t *testing.T) {
         t.Fatal("Unexcpected Error")
     }
 
-	err = service.DeleteAnswer(context.Background(), 1)
+	err = service.DeleteAnswer(context.Background(), 1, 0)
 
 	if err != nil {
         t.Error("Unexcpected Error")
@@ -224,7 +353,7 @@ func TestDeleteAnswer(t *testing.T) {
 func TestDeleteAnswerWithInvalidID(t *testing.T) {
 	service := newTestService(1, 1)
 
-	err := service.DeleteAnswer(context.Background(), 1)
+	err := service.DeleteAnswer(context.Background(), 1, 0)
 
 	if err == nil {
         t.Error("Excpected Error")
@@ -239,7 +368,7 @@ func CreateQuestion(service *Service, t *testing.T) (models.CreateQuestionRespon
 	if err != nil {
         t.Fatal(err)
     }
-	res, err := service.NewQuestion(context.Background(), raw)
+	res, err := service.NewQuestion(context.Background(), raw, false)
 	if err != nil {
         t.Fatal(err)
     }
@@ -270,6 +399,11 @@ func newTestService(questionLen, answerLen int) *Service {
 		slog.Default(),
 		mockStorageQuestions,
 		mockStorageAnswers,
+		mock.NewMockStorageUsers(mockStorageQuestions),
+		mock.NewMockStorageComments(mockStorageQuestions),
+		config.ReputationConfig{},
+		config.DuplicatesConfig{},
+		nil,
 	)
 }
 
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidBatch = errors.New("InvalidBatch")
+	ErrBatchTooLarge = errors.New("BatchTooLarge")
+	// ErrBatchNotApplied is the error of an atomic batch with a failed
+	// operation; its results tell which one.
+	ErrBatchNotApplied = errors.New("BatchNotApplied")
+	// ErrBatchRolledBack is the result of the operations of an atomic
+	// batch that did not fail but were not applied.
+	ErrBatchRolledBack = errors.New("BatchRolledBack")
+)
+
+// BatchQuestions creates and deletes questions for migrations, with at
+// most limit operations. An atomic batch is written in one transaction and
+// fails with ErrBatchNotApplied if any operation fails; otherwise every
+// operation is applied on its own and has its result. The creates are not
+// checked for duplicates.
+func(s *Service) BatchQuestions(ctx context.Context, data []byte, atomic bool, limit int) (models.BatchQuestionsResponse, error) {
+	var batchRequest models.BatchQuestionsRequest
+	err := json.Unmarshal(data, &batchRequest)
+	if err != nil {
+		s.log.Error(
+			"ParsingJSONError",
+			slog.String("component", "json/unmarshalling"),
+			slog.Any("error", err),
+		)
+		return models.BatchQuestionsResponse{}, ErrInvalidBatch
+	}
+	if len(batchRequest.Operations) == 0 {
+		return models.BatchQuestionsResponse{}, ErrInvalidBatch
+	}
+	if len(batchRequest.Operations) > limit {
+		return models.BatchQuestionsResponse{}, ErrBatchTooLarge
+	}
+
+	res := models.BatchQuestionsResponse{
+		Atomic: atomic,
+		Results: make([]models.BatchQuestionResult, len(batchRequest.Operations)),
+	}
+	var batch models.QuestionBatch
+	// The indexes in the results of the creates and deletes of the batch.
+	var created, deleted []int
+	for i, operation := range batchRequest.Operations {
+		result := &res.Results[i]
+		result.Op = operation.Op
+		switch operation.Op {
+		case models.BatchCreate:
+			question, err := s.batchQuestion(operation)
+			if err != nil {
+				result.Err = err
+				continue
+			}
+			batch.Create = append(batch.Create, question)
+			created = append(created, i)
+		case models.BatchDelete:
+			if operation.ID <= 0 || operation.Version < 0 {
+				result.Err = ErrInvalidBatch
+				continue
+			}
+			batch.Delete = append(batch.Delete, models.QuestionDelete{ID: operation.ID, Version: operation.Version})
+			deleted = append(deleted, i)
+		default:
+			result.Err = ErrInvalidBatch
+		}
+	}
+
+	if atomic {
+		err = s.writeBatch(ctx, &batch, res.Results, deleted)
+	} else {
+		err = s.applyBatch(ctx, &batch, res.Results, created, deleted)
+	}
+	if errors.Is(err, ErrBatchNotApplied) {
+		return res, err
+	}
+	if err != nil {
+		return models.BatchQuestionsResponse{}, err
+	}
+	for n, i := range created {
+		if res.Results[i].Err == nil {
+			res.Results[i].Question = batch.Create[n]
+		}
+	}
+
+	return res, nil
+}
+
+func(s *Service) batchQuestion(operation models.BatchQuestionOperation) (*models.Question, error) {
+	if operation.Text == "" {
+		return nil, ErrInvalidBatch
+	}
+	if operation.UserID != "" && !validUserID(operation.UserID) {
+		return nil, ErrInvalidUser
+	}
+	tags, err := NormalizeTags(operation.Tags)
+	if err != nil {
+		return nil, err
+	}
+	html, err := s.render(operation.Text)
+	if err != nil {
+		return nil, err
+	}
+	question := &models.Question{
+		Text: operation.Text,
+		TextHTML: html,
+		Tags: tags,
+		Version: 1,
+	}
+	if operation.UserID != "" {
+		question.UserID = &operation.UserID
+	}
+
+	return question, nil
+}
+
+// writeBatch writes the batch in one transaction. The results of the
+// operations are ErrBatchRolledBack after one fails.
+func(s *Service) writeBatch(ctx context.Context, batch *models.QuestionBatch, results []models.BatchQuestionResult, deleted []int) error {
+	failed := false
+	for _, result := range results {
+		failed = failed || result.Err != nil
+	}
+	if !failed {
+		err := s.questionStorage.WriteQuestions(ctx, batch)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.Error(
+				"DB_WritingError",
+				slog.String("component", "db"),
+				slog.Any("error", err),
+			)
+			return errors.New("DB_WritingError")
+		}
+		if err != nil {
+			failed = true
+			for n, item := range batch.Delete {
+				if !item.Deleted {
+					results[deleted[n]].Err = s.missedDelete(ctx, item)
+					break
+				}
+			}
+		}
+	}
+	if failed {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = ErrBatchRolledBack
+			}
+		}
+		return ErrBatchNotApplied
+	}
+
+	s.log.Info(fmt.Sprintf("Write a batch of %d new and %d deleted questions", len(batch.Create), len(batch.Delete)))
+	s.notify()
+	return nil
+}
+
+// applyBatch creates the questions together and deletes them one at a
+// time, so a delete fails on its own. When the creates fail together they
+// are retried one at a time, so a bad row fails only its own operation.
+func(s *Service) applyBatch(ctx context.Context, batch *models.QuestionBatch, results []models.BatchQuestionResult, created, deleted []int) error {
+	if len(batch.Create) != 0 {
+		err := s.questionStorage.CreateQuestions(ctx, batch.Create)
+		if err != nil {
+			s.log.Warn(
+				"DB_WritingError",
+				slog.String("component", "db"),
+				slog.String("fallback", "one question at a time"),
+				slog.Any("error", err),
+			)
+			s.createEach(ctx, batch.Create, results, created)
+		} else {
+			s.log.Info(fmt.Sprintf("Write a batch of %d new questions", len(batch.Create)))
+		}
+		s.notify()
+	}
+	for n, item := range batch.Delete {
+		res, err := s.questionStorage.DeleteQuestion(ctx, item.ID, item.Version)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound), err == nil && !res.Deleted:
+			results[deleted[n]].Err = s.missedDelete(ctx, item)
+		case err != nil:
+			s.log.Error(
+				"DB_DeletingError",
+				slog.String("component", "db"),
+				slog.Any("error", err),
+			)
+			results[deleted[n]].Err = errors.New("DB_DeletingError")
+		default:
+			s.log.Info(fmt.Sprintf("Delete question with id: %d", item.ID))
+			s.notify()
+		}
+	}
+
+	return nil
+}
+
+// createEach creates the questions of a batch one at a time and records
+// the error of each that fails in its result.
+func(s *Service) createEach(ctx context.Context, questions []*models.Question, results []models.BatchQuestionResult, created []int) {
+	for n, question := range questions {
+		// The failed transaction may have numbered the question already.
+		question.ID = 0
+		question.CreatedAt = time.Time{}
+		if err := s.questionStorage.CreateQuestions(ctx, []*models.Question{question}); err != nil {
+			s.log.Error(
+				"DB_WritingError",
+				slog.String("component", "db"),
+				slog.Any("error", err),
+			)
+			results[created[n]].Err = errors.New("DB_WritingError")
+			continue
+		}
+		s.log.Info(fmt.Sprintf("Create question with id: %d", question.ID))
+	}
+}
+
+// missedDelete tells why the delete of a batch removed nothing.
+func(s *Service) missedDelete(ctx context.Context, item models.QuestionDelete) error {
+	if item.Version != 0 {
+		if _, err := s.questionStorage.Exist(ctx, item.ID); err == nil {
+			return ErrVersionMismatch
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
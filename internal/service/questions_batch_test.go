@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+func batchQuestions(service *Service, atomic bool, t *testing.T, operations ...models.BatchQuestionOperation) (models.BatchQuestionsResponse, error) {
+	raw, err := json.Marshal(models.BatchQuestionsRequest{Operations: operations})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service.BatchQuestions(context.Background(), raw, atomic, 10)
+}
+
+func TestBatchQuestionsAtomic(t *testing.T) {
+	service := newTestService(3, 1)
+	first, _ := CreateQuestion(service, t)
+
+	res, err := batchQuestions(service, true, t,
+		models.BatchQuestionOperation{Op: models.BatchCreate, Text: "What is Go?", UserID: testUserID},
+		models.BatchQuestionOperation{Op: models.BatchDelete, ID: first.Question.ID, Version: 1},
+		models.BatchQuestionOperation{Op: models.BatchCreate, Text: "What is a goroutine?"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Results) != 3 || res.Results[0].Question == nil || res.Results[2].Question == nil || res.Results[1].Err != nil {
+		t.Fatalf("Excpected 2 questions and a delete, got %+v", res.Results)
+	}
+	if *res.Results[0].Question.UserID != testUserID || res.Results[0].Question.TextHTML == "" {
+		t.Errorf("Excpected the question of the user, got %+v", res.Results[0].Question)
+	}
+	all, _ := service.AllQuestions(context.Background(), "")
+	if len(all.Questions) != 2 {
+		t.Errorf("Excpected 2 questions, got %d", len(all.Questions))
+	}
+}
+
+func TestBatchQuestionsAtomicRollsBack(t *testing.T) {
+	service := newTestService(3, 1)
+	first, _ := CreateQuestion(service, t)
+
+	res, err := batchQuestions(service, true, t,
+		models.BatchQuestionOperation{Op: models.BatchCreate, Text: "What is Go?"},
+		models.BatchQuestionOperation{Op: models.BatchDelete, ID: first.Question.ID, Version: 2},
+	)
+	if !errors.Is(err, ErrBatchNotApplied) {
+		t.Fatalf("Excpected ErrBatchNotApplied, got %v", err)
+	}
+	if !errors.Is(res.Results[0].Err, ErrBatchRolledBack) || !errors.Is(res.Results[1].Err, ErrVersionMismatch) {
+		t.Errorf("Excpected the delete to fail on its version, got %+v", res.Results)
+	}
+
+	res, err = batchQuestions(service, true, t,
+		models.BatchQuestionOperation{Op: models.BatchDelete, ID: first.Question.ID},
+		models.BatchQuestionOperation{Op: models.BatchCreate, Text: ""},
+	)
+	if !errors.Is(err, ErrBatchNotApplied) || !errors.Is(res.Results[1].Err, ErrInvalidBatch) {
+		t.Fatalf("Excpected the invalid create to fail the batch, got %v %+v", err, res.Results)
+	}
+	all, _ := service.AllQuestions(context.Background(), "")
+	if len(all.Questions) != 1 {
+		t.Errorf("Excpected the batches not to be applied, got %d questions", len(all.Questions))
+	}
+}
+
+func TestBatchQuestionsBestEffort(t *testing.T) {
+	service := newTestService(3, 1)
+	first, _ := CreateQuestion(service, t)
+
+	res, err := batchQuestions(service, false, t,
+		models.BatchQuestionOperation{Op: models.BatchDelete, ID: first.Question.ID},
+		models.BatchQuestionOperation{Op: models.BatchDelete, ID: first.Question.ID},
+		models.BatchQuestionOperation{Op: models.BatchCreate, Text: "What is Go?", UserID: "not-a-uuid"},
+		models.BatchQuestionOperation{Op: "update"},
+		models.BatchQuestionOperation{Op: models.BatchCreate, Text: "What is Go?"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Results[0].Err != nil || !errors.Is(res.Results[1].Err, gorm.ErrRecordNotFound) {
+		t.Errorf("Excpected the second delete to miss, got %+v", res.Results[:2])
+	}
+	if !errors.Is(res.Results[2].Err, ErrInvalidUser) || !errors.Is(res.Results[3].Err, ErrInvalidBatch) {
+		t.Errorf("Excpected the invalid operations to fail, got %+v", res.Results[2:4])
+	}
+	if res.Results[4].Question == nil || res.Results[4].Question.ID == 0 {
+		t.Errorf("Excpected the question to be created, got %+v", res.Results[4])
+	}
+}
+
+// failingQuestions fails every insert with a question of text "fail", as
+// Postgres fails a whole INSERT for one bad row.
+type failingQuestions struct {
+	*mock.MockStorageQuestions
+}
+
+func(s failingQuestions) CreateQuestions(ctx context.Context, data []*models.Question) error {
+	for _, question := range data {
+		if question.Text == "fail" {
+			return errors.New("check constraint violated")
+		}
+	}
+	return s.MockStorageQuestions.CreateQuestions(ctx, data)
+}
+
+func TestBatchQuestionsBestEffortCreatesEach(t *testing.T) {
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(3, answers)
+	service := NewService(slog.Default(), failingQuestions{questions}, answers, mock.NewMockStorageUsers(questions), mock.NewMockStorageComments(questions), config.ReputationConfig{}, config.DuplicatesConfig{}, nil)
+
+	res, err := batchQuestions(service, false, t,
+		models.BatchQuestionOperation{Op: models.BatchCreate, Text: "What is Go?"},
+		models.BatchQuestionOperation{Op: models.BatchCreate, Text: "fail"},
+		models.BatchQuestionOperation{Op: models.BatchCreate, Text: "What is a goroutine?"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Results[0].Question == nil || res.Results[2].Question == nil {
+		t.Fatalf("Excpected the valid questions to be created, got %+v", res.Results)
+	}
+	if res.Results[1].Err == nil || res.Results[1].Question != nil {
+		t.Errorf("Excpected only the bad question to fail, got %+v", res.Results[1])
+	}
+	all, _ := service.AllQuestions(context.Background(), "")
+	if len(all.Questions) != 2 {
+		t.Errorf("Excpected 2 questions, got %d", len(all.Questions))
+	}
+}
+
+func TestBatchQuestionsLimit(t *testing.T) {
+	service := newTestService(1, 1)
+	operations := make([]models.BatchQuestionOperation, 11)
+	for i := range operations {
+		operations[i] = models.BatchQuestionOperation{Op: models.BatchCreate, Text: "test"}
+	}
+	if _, err := batchQuestions(service, true, t, operations...); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("Excpected ErrBatchTooLarge, got %v", err)
+	}
+	if _, err := batchQuestions(service, true, t); !errors.Is(err, ErrInvalidBatch) {
+		t.Errorf("Excpected ErrInvalidBatch for an empty batch, got %v", err)
+	}
+}
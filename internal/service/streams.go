@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// StreamQuestions passes every question to yield in the order of sort,
+// without holding them all in memory. An error of yield, such as a client
+// that went away, stops the stream and is returned as it is.
+func(s *Service) StreamQuestions(ctx context.Context, sort string, yield func(models.Question) error) error {
+	order := models.QuestionSort(sort)
+	if order != models.SortDefault && order != models.SortViews {
+		return ErrInvalidSort
+	}
+	var yieldErr error
+	err := s.questionStorage.StreamQuestions(ctx, order, func(question models.Question) error {
+		yieldErr = yield(question)
+		return yieldErr
+	})
+
+	return s.streamError(ctx, err, yieldErr)
+}
+
+// StreamAnswers passes the answers of the question to yield as
+// StreamQuestions does.
+func(s *Service) StreamAnswers(ctx context.Context, questionID int, yield func(models.Answer) error) error {
+	_, err := s.questionStorage.Exist(ctx, questionID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Error(
+			"DB_ReadingError",
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return errors.New("DB_ReadingError")
+	}
+	if err != nil {
+		return err
+	}
+	var yieldErr error
+	err = s.answerStorage.StreamAnswers(ctx, questionID, func(answer models.Answer) error {
+		yieldErr = yield(answer)
+		return yieldErr
+	})
+
+	return s.streamError(ctx, err, yieldErr)
+}
+
+func(s *Service) Answers(ctx context.Context, questionID int) (models.GetAnswersResponse, error) {
+	answers := make([]models.Answer, 0)
+	err := s.StreamAnswers(ctx, questionID, func(answer models.Answer) error {
+		answers = append(answers, answer)
+		return nil
+	})
+	if err != nil {
+		return models.GetAnswersResponse{}, err
+	}
+
+	return models.GetAnswersResponse{Answers: answers}, nil
+}
+
+// streamError logs the errors of the storage, but not those of yield or
+// of a cancelled request.
+func(s *Service) streamError(ctx context.Context, err, yieldErr error) error {
+	if err == nil || yieldErr != nil && errors.Is(err, yieldErr) {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	s.log.Error(
+		"DB_ReadingError",
+		slog.String("component", "db"),
+		slog.Any("error", err),
+	)
+	return errors.New("DB_ReadingError")
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+func TestStreamQuestionsStops(t *testing.T) {
+	service := newTestService(3, 1)
+	for range 3 {
+		CreateQuestion(service, t)
+	}
+
+	gone := errors.New("client went away")
+	var streamed int
+	err := service.StreamQuestions(context.Background(), "", func(question models.Question) error {
+		streamed++
+		if streamed == 2 {
+			return gone
+		}
+		return nil
+	})
+	if !errors.Is(err, gone) || streamed != 2 {
+		t.Errorf("Excpected the stream to stop at the error, got %v after %d", err, streamed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	streamed = 0
+	err = service.StreamQuestions(ctx, "views", func(question models.Question) error {
+		streamed++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || streamed != 1 {
+		t.Errorf("Excpected the stream to stop when cancelled, got %v after %d", err, streamed)
+	}
+
+	if err := service.StreamQuestions(context.Background(), "votes", nil); !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("Excpected ErrInvalidSort, got %v", err)
+	}
+}
+
+func TestStreamAnswers(t *testing.T) {
+	service := newTestService(1, 3)
+	question, _ := CreateQuestion(service, t)
+	CreateAnswer(service, question.Question.ID, t)
+	CreateAnswer(service, question.Question.ID, t)
+
+	var ids []int
+	err := service.StreamAnswers(context.Background(), question.Question.ID, func(answer models.Answer) error {
+		ids = append(ids, answer.ID)
+		return nil
+	})
+	if err != nil || len(ids) != 2 || ids[0] > ids[1] {
+		t.Errorf("Excpected the 2 answers in order, got %v %v", err, ids)
+	}
+
+	res, err := service.Answers(context.Background(), question.Question.ID)
+	if err != nil || len(res.Answers) != 2 {
+		t.Errorf("Excpected the 2 answers, got %v %+v", err, res)
+	}
+	if _, err := service.Answers(context.Background(), 404); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Excpected ErrRecordNotFound, got %v", err)
+	}
+}
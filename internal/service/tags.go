@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidTags = errors.New("InvalidTags")
+
+// MaxTags is the number of tags a question may have.
+const MaxTags = 5
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+#.-]{0,31}$`)
+
+// NormalizeTags lowercases the tags, drops the repeated ones and sorts
+// them, so that a question is stored with the tags its readers filter on.
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTags, tag)
+		}
+		res = append(res, tag)
+	}
+	slices.Sort(res)
+	res = slices.Compact(res)
+	if len(res) > MaxTags {
+		return nil, fmt.Errorf("%w: more than %d tags", ErrInvalidTags, MaxTags)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidUser = errors.New("InvalidUser")
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit = 100
+	maxDisplayNameLength = 64
+	maxBioLength = 1000
+)
+
+var userIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type StorageUser interface {
+	User(ctx context.Context, id string) (models.User, error)
+	SaveUser(ctx context.Context, data *models.User) error
+	UserAnswers(ctx context.Context, id string, page models.Page) ([]models.Answer, error)
+	// AuthoredAnswers returns every answer of the user with only its ID
+	// and QuestionID set.
+	AuthoredAnswers(ctx context.Context, id string) ([]models.Answer, error)
+	UserQuestions(ctx context.Context, id string, page models.Page) ([]models.Question, error)
+	Leaderboard(ctx context.Context, page models.Page) ([]models.User, error)
+	ReputationEvents(ctx context.Context, id string, page models.Page) ([]models.ReputationEvent, error)
+	// UsersByID loads many users in one query for the GraphQL loaders.
+	// Missing IDs are left out.
+	UsersByID(ctx context.Context, ids []string) ([]models.User, error)
+	// AnswersByUser returns the page of answers of each user.
+	AnswersByUser(ctx context.Context, ids []string, page models.Page) ([]models.Answer, error)
+}
+
+func(s *Service) User(ctx context.Context, id string) (models.GetUserResponse, error) {
+	if !validUserID(id) {
+		return models.GetUserResponse{}, ErrInvalidUser
+	}
+	user, err := s.userStorage.User(ctx, id)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.log.Error(
+			"DB_ReadingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetUserResponse{}, errors.New("DB_ReadingError")
+	}
+	if err != nil {
+		return models.GetUserResponse{}, err
+	}
+
+	return models.GetUserResponse{User: user, Privileges: s.privileges(user.Reputation)}, nil
+}
+
+// UpdateUser replaces the profile, creating the user if it has not
+// answered yet.
+func(s *Service) UpdateUser(ctx context.Context, id string, data []byte) (models.GetUserResponse, error) {
+	if !validUserID(id) {
+		return models.GetUserResponse{}, ErrInvalidUser
+	}
+	var userRequest models.UpdateUserRequest
+	err := json.Unmarshal(data, &userRequest)
+	if err != nil {
+		s.log.Error(
+			"ParsingJSONError", 
+			slog.String("component", "json/unmarshaling"),
+			slog.Any("error", err),
+		)
+		return models.GetUserResponse{}, fmt.Errorf("%w: %v", ErrInvalidUser, err)
+	}
+	if err := validateProfile(userRequest); err != nil {
+		return models.GetUserResponse{}, err
+	}
+
+	user := models.User{
+		ID: id,
+		DisplayName: userRequest.DisplayName,
+		Bio: userRequest.Bio,
+		AvatarURL: userRequest.AvatarURL,
+	}
+	err = s.userStorage.SaveUser(ctx, &user)
+	if err != nil {
+		s.log.Error(
+			"DB_WritingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetUserResponse{}, errors.New("DB_WritingError")
+	}
+	s.log.Info(fmt.Sprintf("Update user with id: %s", id))
+
+	return models.GetUserResponse{User: user, Privileges: s.privileges(user.Reputation)}, nil
+}
+
+func(s *Service) UserAnswers(ctx context.Context, id string, page models.Page) (models.GetUserAnswersResponse, error) {
+	page, err := s.userPage(ctx, id, page)
+	if err != nil {
+		return models.GetUserAnswersResponse{}, err
+	}
+	answers, err := s.userStorage.UserAnswers(ctx, id, page)
+	if err != nil {
+		s.log.Error(
+			"DB_ReadingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetUserAnswersResponse{}, errors.New("DB_ReadingError")
+	}
+
+	return models.GetUserAnswersResponse{Answers: answers, Limit: page.Limit, Offset: page.Offset}, nil
+}
+
+// UserQuestions lists the questions the user asked, newest first.
+func(s *Service) UserQuestions(ctx context.Context, id string, page models.Page) (models.GetUserQuestionsResponse, error) {
+	page, err := s.userPage(ctx, id, page)
+	if err != nil {
+		return models.GetUserQuestionsResponse{}, err
+	}
+	questions, err := s.userStorage.UserQuestions(ctx, id, page)
+	if err != nil {
+		s.log.Error(
+			"DB_ReadingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetUserQuestionsResponse{}, errors.New("DB_ReadingError")
+	}
+
+	return models.GetUserQuestionsResponse{Questions: questions, Limit: page.Limit, Offset: page.Offset}, nil
+}
+
+// Leaderboard lists users by reputation, highest first.
+func(s *Service) Leaderboard(ctx context.Context, page models.Page) (models.GetLeaderboardResponse, error) {
+	page = normalizePage(page)
+	users, err := s.userStorage.Leaderboard(ctx, page)
+	if err != nil {
+		s.log.Error(
+			"DB_ReadingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetLeaderboardResponse{}, errors.New("DB_ReadingError")
+	}
+
+	return models.GetLeaderboardResponse{Users: users, Limit: page.Limit, Offset: page.Offset}, nil
+}
+
+// Reputation returns the total of the user with its ledger, newest first.
+func(s *Service) Reputation(ctx context.Context, id string, page models.Page) (models.GetReputationResponse, error) {
+	user, err := s.User(ctx, id)
+	if err != nil {
+		return models.GetReputationResponse{}, err
+	}
+	page = normalizePage(page)
+	entries, err := s.userStorage.ReputationEvents(ctx, id, page)
+	if err != nil {
+		s.log.Error(
+			"DB_ReadingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetReputationResponse{}, errors.New("DB_ReadingError")
+	}
+
+	return models.GetReputationResponse{
+		Reputation: user.User.Reputation,
+		Events: entries,
+		Limit: page.Limit,
+		Offset: page.Offset,
+	}, nil
+}
+
+// userPage checks that the user exists and applies the page defaults.
+func(s *Service) userPage(ctx context.Context, id string, page models.Page) (models.Page, error) {
+	if _, err := s.User(ctx, id); err != nil {
+		return page, err
+	}
+
+	return normalizePage(page), nil
+}
+
+func normalizePage(page models.Page) models.Page {
+	if page.Limit <= 0 {
+		page.Limit = defaultPageLimit
+	}
+	page.Limit = min(page.Limit, maxPageLimit)
+	page.Offset = max(page.Offset, 0)
+
+	return page
+}
+
+func validUserID(id string) bool {
+	return userIDPattern.MatchString(id)
+}
+
+func validateProfile(request models.UpdateUserRequest) error {
+	if utf8.RuneCountInString(request.DisplayName) > maxDisplayNameLength {
+		return fmt.Errorf("%w: display name is longer than %d", ErrInvalidUser, maxDisplayNameLength)
+	}
+	if utf8.RuneCountInString(request.Bio) > maxBioLength {
+		return fmt.Errorf("%w: bio is longer than %d", ErrInvalidUser, maxBioLength)
+	}
+	if request.AvatarURL == "" {
+		return nil
+	}
+	avatar, err := url.Parse(request.AvatarURL)
+	if err != nil || (avatar.Scheme != "http" && avatar.Scheme != "https") || avatar.Host == "" {
+		return fmt.Errorf("%w: avatar must be an http(s) URL", ErrInvalidUser)
+	}
+
+	return nil
+}
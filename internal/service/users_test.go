@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+const testUserID = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+func TestUpdateUserEmbedsAuthor(t *testing.T) {
+	service := newTestService(1, 1)
+	question, _ := CreateQuestion(service, t)
+	created, _ := CreateAnswer(service, question.Question.ID, t)
+
+	_, err := service.UpdateUser(context.Background(), testUserID, []byte(`{"DisplayName":"Gopher","AvatarURL":"https://example.com/a.png"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := service.Answer(context.Background(), created.Answers[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	excpected := models.Author{ID: testUserID, DisplayName: "Gopher", AvatarURL: "https://example.com/a.png", UpdatedAt: created.Answers[0].Author.UpdatedAt.Add(time.Second)}
+	if res.Answer.Author == nil || *res.Answer.Author != excpected {
+		t.Errorf("Excpected author %+v, got %+v", excpected, res.Answer.Author)
+	}
+}
+
+func TestUpdateUserWithInvalidProfile(t *testing.T) {
+	service := newTestService(1, 1)
+
+	_, err := service.UpdateUser(context.Background(), testUserID, []byte(`{"AvatarURL":"javascript:alert(1)"}`))
+	if !errors.Is(err, ErrInvalidUser) {
+		t.Errorf("Excpected ErrInvalidUser for avatar, got %v", err)
+	}
+	_, err = service.UpdateUser(context.Background(), "not-a-uuid", []byte(`{}`))
+	if !errors.Is(err, ErrInvalidUser) {
+		t.Errorf("Excpected ErrInvalidUser for id, got %v", err)
+	}
+}
+
+func TestUserAnswersPagination(t *testing.T) {
+	service := newTestService(1, 3)
+	question, _ := CreateQuestion(service, t)
+	for i := 0; i < 3; i++ {
+		CreateAnswer(service, question.Question.ID, t)
+	}
+
+	res, err := service.UserAnswers(context.Background(), testUserID, models.Page{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Answers) != 2 || res.Answers[0].ID != 2 || res.Answers[1].ID != 1 {
+		t.Errorf("Excpected answers 2 and 1, got %+v", res.Answers)
+	}
+
+	// Only the questions the user asked are listed, not the answered ones.
+	asked, err := service.NewQuestion(context.Background(), []byte(`{"Text":"asked","UserID":"`+testUserID+`"}`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	questions, err := service.UserQuestions(context.Background(), testUserID, models.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(questions.Questions) != 1 || questions.Questions[0].ID != asked.Question.ID || questions.Limit != defaultPageLimit {
+		t.Errorf("Excpected the asked question with default limit, got %+v", questions)
+	}
+}
+
+func TestUserWithoutAnswers(t *testing.T) {
+	service := newTestService(1, 1)
+
+	_, err := service.UserAnswers(context.Background(), testUserID, models.Page{})
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Excpected not found for unknown user, got %v", err)
+	}
+}
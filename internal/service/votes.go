@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidVote = errors.New("InvalidVote")
+	ErrPrivilegeRequired = errors.New("PrivilegeRequired")
+)
+
+const (
+	PrivilegeDownvote = "downvote"
+	PrivilegeEditOthers = "edit_others"
+)
+
+// VoteAnswer sets the vote of a user on the answer. Users cannot vote on
+// their own answers, and downvoting needs the downvote privilege.
+func(s *Service) VoteAnswer(ctx context.Context, id int, data []byte) (models.VoteResponse, error) {
+	var voteRequest models.VoteRequest
+	err := json.Unmarshal(data, &voteRequest)
+	if err != nil {
+		s.log.Error(
+			"ParsingJSONError", 
+			slog.String("component", "json/unmarshaling"),
+			slog.Any("error", err),
+		)
+		return models.VoteResponse{}, fmt.Errorf("%w: %v", ErrInvalidVote, err)
+	}
+	if !validUserID(voteRequest.UserID) || voteRequest.Value < -1 || voteRequest.Value > 1 {
+		return models.VoteResponse{}, ErrInvalidVote
+	}
+
+	answer, err := s.Answer(ctx, id)
+	if err != nil {
+		return models.VoteResponse{}, err
+	}
+	if answer.Answer.UserID == voteRequest.UserID {
+		return models.VoteResponse{}, fmt.Errorf("%w: own answer", ErrInvalidVote)
+	}
+	if voteRequest.Value == -1 {
+		allowed, err := s.hasPrivilege(ctx, voteRequest.UserID, PrivilegeDownvote)
+		if err != nil {
+			return models.VoteResponse{}, err
+		}
+		if !allowed {
+			return models.VoteResponse{}, fmt.Errorf("%w: %s", ErrPrivilegeRequired, PrivilegeDownvote)
+		}
+	}
+
+	voted, err := s.answerStorage.VoteAnswer(ctx, &models.Vote{
+		AnswerID: id,
+		UserID: voteRequest.UserID,
+		Value: voteRequest.Value,
+	})
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.log.Error(
+			"DB_WritingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.VoteResponse{}, errors.New("DB_WritingError")
+	}
+	if err != nil {
+		return models.VoteResponse{}, err
+	}
+	if voted.Value != voted.Previous {
+		s.log.Info(fmt.Sprintf("Vote %d on answer with id: %d", voted.Value, id))
+		s.notify()
+	}
+
+	return models.VoteResponse{Answer: voted.Answer, Value: voted.Value}, nil
+}
+
+// AcceptAnswer marks the answer as the accepted one of its question. Only
+// the author of the question may accept, so questions without one have no
+// accepted answer.
+func(s *Service) AcceptAnswer(ctx context.Context, id int, userID string) (models.GetAnswerResponse, error) {
+	if !validUserID(userID) {
+		return models.GetAnswerResponse{}, ErrInvalidUser
+	}
+	answer, err := s.Answer(ctx, id)
+	if err != nil {
+		return models.GetAnswerResponse{}, err
+	}
+	question, err := s.questionStorage.Exist(ctx, answer.Answer.QuestionID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.log.Error(
+			"DB_ReadingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetAnswerResponse{}, errors.New("DB_ReadingError")
+	}
+	if err != nil {
+		return models.GetAnswerResponse{}, err
+	}
+	if question.UserID == nil || *question.UserID != userID {
+		return models.GetAnswerResponse{}, fmt.Errorf("%w: question author", ErrPrivilegeRequired)
+	}
+
+	accepted, err := s.answerStorage.AcceptAnswer(ctx, id)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.log.Error(
+			"DB_WritingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return models.GetAnswerResponse{}, errors.New("DB_WritingError")
+	}
+	if err != nil {
+		return models.GetAnswerResponse{}, err
+	}
+	s.log.Info(fmt.Sprintf("Accept answer with id: %d", id))
+	s.notify()
+
+	return models.GetAnswerResponse{Answer: accepted.Answer}, nil
+}
+
+// privileges lists the privileges a user with the reputation has.
+func(s *Service) privileges(reputation int) []string {
+	res := make([]string, 0, 2)
+	if reputation >= s.reputation.Privileges.Downvote {
+		res = append(res, PrivilegeDownvote)
+	}
+	if reputation >= s.reputation.Privileges.EditOthers {
+		res = append(res, PrivilegeEditOthers)
+	}
+
+	return res
+}
+
+// hasPrivilege checks the reputation of the user. Users without a profile
+// have no reputation.
+func(s *Service) hasPrivilege(ctx context.Context, userID, privilege string) (bool, error) {
+	var reputation int
+	user, err := s.userStorage.User(ctx, userID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.log.Error(
+			"DB_ReadingError", 
+			slog.String("component", "db"),
+			slog.Any("error", err),
+		)
+		return false, errors.New("DB_ReadingError")
+	}
+	if err == nil {
+		reputation = user.Reputation
+	}
+
+	return slices.Contains(s.privileges(reputation), privilege), nil
+}
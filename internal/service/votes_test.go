@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+const testVoterID = "123e4567-e89b-12d3-a456-426614174000"
+
+func TestVoteAnswerChangesScore(t *testing.T) {
+	service := newTestService(1, 1)
+	question, _ := CreateQuestion(service, t)
+	created, _ := CreateAnswer(service, question.Question.ID, t)
+	id := created.Answers[0].ID
+
+	res, err := service.VoteAnswer(context.Background(), id, []byte(`{"UserID":"`+testVoterID+`","Value":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Answer.Score != 1 || res.Answer.Version != 2 {
+		t.Errorf("Excpected score 1 at version 2, got %+v", res.Answer)
+	}
+
+	res, err = service.VoteAnswer(context.Background(), id, []byte(`{"UserID":"`+testVoterID+`","Value":-1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Answer.Score != -1 {
+		t.Errorf("Excpected score -1 after changing the vote, got %d", res.Answer.Score)
+	}
+}
+
+func TestVoteOwnAnswer(t *testing.T) {
+	service := newTestService(1, 1)
+	question, _ := CreateQuestion(service, t)
+	created, _ := CreateAnswer(service, question.Question.ID, t)
+
+	_, err := service.VoteAnswer(context.Background(), created.Answers[0].ID, []byte(`{"UserID":"`+testUserID+`","Value":1}`))
+	if !errors.Is(err, ErrInvalidVote) {
+		t.Errorf("Excpected ErrInvalidVote, got %v", err)
+	}
+}
+
+func TestDownvoteRequiresPrivilege(t *testing.T) {
+	service := newTestService(1, 1)
+	service.reputation.Privileges.Downvote = 125
+	question, _ := CreateQuestion(service, t)
+	created, _ := CreateAnswer(service, question.Question.ID, t)
+
+	_, err := service.VoteAnswer(context.Background(), created.Answers[0].ID, []byte(`{"UserID":"`+testVoterID+`","Value":-1}`))
+	if !errors.Is(err, ErrPrivilegeRequired) {
+		t.Errorf("Excpected ErrPrivilegeRequired, got %v", err)
+	}
+}
+
+func TestAcceptAnswerReplacesPrevious(t *testing.T) {
+	service := newTestService(1, 2)
+	question := askQuestion(service, testVoterID, t)
+	first, _ := CreateAnswer(service, question.Question.ID, t)
+	second, _ := CreateAnswer(service, question.Question.ID, t)
+
+	service.AcceptAnswer(context.Background(), first.Answers[0].ID, testVoterID)
+	res, err := service.AcceptAnswer(context.Background(), second.Answers[0].ID, testVoterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Answer.Accepted {
+		t.Error("Excpected answer to be accepted")
+	}
+	previous, _ := service.Answer(context.Background(), first.Answers[0].ID)
+	if previous.Answer.Accepted {
+		t.Error("Excpected previous answer to lose acceptance")
+	}
+
+	user, _ := service.User(context.Background(), testUserID)
+	excpected := []string{"downvote", "edit_others"}
+	if len(user.Privileges) != 2 || user.Privileges[0] != excpected[0] {
+		t.Errorf("Excpected privileges %v with zero thresholds, got %v", excpected, user.Privileges)
+	}
+}
+
+func TestAcceptAnswerNeedsQuestionAuthor(t *testing.T) {
+	service := newTestService(2, 2)
+	asked := askQuestion(service, testVoterID, t)
+	answer, _ := CreateAnswer(service, asked.Question.ID, t)
+	anonymous, _ := CreateQuestion(service, t)
+	orphan, _ := CreateAnswer(service, anonymous.Question.ID, t)
+
+	if _, err := service.AcceptAnswer(context.Background(), answer.Answers[0].ID, ""); !errors.Is(err, ErrInvalidUser) {
+		t.Errorf("Excpected ErrInvalidUser without a user, got %v", err)
+	}
+	if _, err := service.AcceptAnswer(context.Background(), answer.Answers[0].ID, testUserID); !errors.Is(err, ErrPrivilegeRequired) {
+		t.Errorf("Excpected ErrPrivilegeRequired for another user, got %v", err)
+	}
+	if _, err := service.AcceptAnswer(context.Background(), orphan.Answers[0].ID, testUserID); !errors.Is(err, ErrPrivilegeRequired) {
+		t.Errorf("Excpected ErrPrivilegeRequired for a question without author, got %v", err)
+	}
+	previous, _ := service.Answer(context.Background(), answer.Answers[0].ID)
+	if previous.Answer.Accepted {
+		t.Error("Excpected the answer to stay unaccepted")
+	}
+}
+
+func TestAcceptOwnAnswerRecordsAuthor(t *testing.T) {
+	service := newTestService(1, 1)
+	question := askQuestion(service, testUserID, t)
+	answer, _ := CreateAnswer(service, question.Question.ID, t)
+
+	if _, err := service.AcceptAnswer(context.Background(), answer.Answers[0].ID, testUserID); err != nil {
+		t.Fatal(err)
+	}
+	pending, _ := service.answerStorage.(*mock.MockStorageAnswers).Outbox().PendingOutbox(context.Background(), 100)
+	for _, row := range pending {
+		if row.Type != events.AnswerAccepted {
+			continue
+		}
+		accepted, err := events.Decode[models.AnswerAccepted](events.Event{Payload: row.Payload})
+		if err != nil || accepted.QuestionUserID != testUserID {
+			t.Errorf("Excpected the question author in the event, got %+v %v", accepted, err)
+		}
+		return
+	}
+	t.Error("Excpected an answer.accepted event")
+}
+
+// askQuestion creates a question of the user.
+func askQuestion(service *Service, userID string, t *testing.T) models.CreateQuestionResponse {
+	res, err := service.NewQuestion(context.Background(), []byte(`{"Text":"test","UserID":"`+userID+`"}`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
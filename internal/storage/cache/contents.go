@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// ContentStorage is the storage of the Markdown backfill.
+type ContentStorage interface {
+	UnrenderedContents(ctx context.Context, table string, afterID, limit int) ([]models.Content, error)
+	SaveRenderedContents(ctx context.Context, table string, contents []models.Content) error
+}
+
+// Contents passes the Markdown backfill through the cache: saving the HTML
+// bumps the versions, so the cached questions and answers it renders are
+// dropped instead of being served without HTML until the TTL.
+type Contents struct {
+	ContentStorage
+	cache *Storage
+}
+
+func NewContents(cache *Storage, contents ContentStorage) *Contents {
+	return &Contents{
+		ContentStorage: contents,
+		cache: cache,
+	}
+}
+
+func(c *Contents) SaveRenderedContents(ctx context.Context, table string, contents []models.Content) error {
+	if err := c.ContentStorage.SaveRenderedContents(ctx, table, contents); err != nil {
+		return err
+	}
+	keys := make([]string, 0, 2 * len(contents))
+	for _, content := range contents {
+		if table == "answers" {
+			keys = append(keys, answerKey(content.ID), questionKey(content.QuestionID))
+		} else {
+			keys = append(keys, questionKey(content.ID))
+		}
+	}
+	c.cache.invalidate(ctx, keys...)
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRU is an in-process Backend bounded by entry count. Expired entries are
+// dropped lazily when they are read or pushed out by newer ones.
+type LRU struct {
+	mu sync.Mutex
+	size int
+	items map[string]*list.Element
+	order *list.List
+	now func() time.Time
+}
+
+type lruEntry struct {
+	key string
+	value []byte
+	expiresAt time.Time
+}
+
+func NewLRU(size int) *LRU {
+	if size <= 0 {
+		size = 1
+	}
+	return &LRU{
+		size: size,
+		items: make(map[string]*list.Element, size),
+		order: list.New(),
+		now: time.Now,
+	}
+}
+
+func(c *LRU) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.removeElement(elem)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(elem)
+
+	return entry.value, true, nil
+}
+
+func(c *LRU) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return nil
+	}
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+	}
+
+	return nil
+}
+
+func(c *LRU) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if elem, ok := c.items[key]; ok {
+			c.removeElement(elem)
+		}
+	}
+
+	return nil
+}
+
+func(c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func(c *LRU) Close() error {
+	return nil
+}
+
+func(c *LRU) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*lruEntry).key)
+}
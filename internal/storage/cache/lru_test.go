@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+
+	c.Set(ctx, "a", []byte("1"), 0)
+	c.Set(ctx, "b", []byte("2"), 0)
+	if _, ok, _ := c.Get(ctx, "a"); !ok {
+		t.Fatal("Excpected key a")
+	}
+	c.Set(ctx, "c", []byte("3"), 0)
+
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Error("Excpected key b to be evicted")
+	}
+	if _, ok, _ := c.Get(ctx, "a"); !ok {
+		t.Error("Excpected key a to stay")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Excpected len 2, got %d", c.Len())
+	}
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+	now := time.Date(2000, time.January, 1, 8, 8, 8, 8, time.UTC)
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	now = now.Add(59 * time.Second)
+	if _, ok, _ := c.Get(ctx, "a"); !ok {
+		t.Fatal("Excpected key a before ttl")
+	}
+	now = now.Add(time.Second)
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Error("Excpected key a to expire")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Excpected expired entry to be dropped, len %d", c.Len())
+	}
+}
+
+func TestLRUDelete(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+
+	c.Set(ctx, "a", []byte("1"), 0)
+	c.Delete(ctx, "a", "missing")
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Error("Excpected key a to be deleted")
+	}
+}
@@ -0,0 +1,285 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Redis is a Backend speaking the Redis protocol (RESP2) over a small pool
+// of connections. It only needs GET, SET PX and DEL, so any compatible server
+// (Redis, Valkey, KeyDB, Dragonfly) works.
+type Redis struct {
+	addr string
+	password string
+	db int
+	timeout time.Duration
+	// slots bounds the open connections; idle holds those not in use.
+	slots chan struct{}
+	idle chan *redisConn
+	mu sync.Mutex
+	closed bool
+}
+
+type redisConn struct {
+	conn net.Conn
+	reader *bufio.Reader
+}
+
+func NewRedis(addr, password string, db, poolSize int, timeout time.Duration) *Redis {
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	if poolSize <= 0 {
+		poolSize = 8
+	}
+	return &Redis{
+		addr: addr,
+		password: password,
+		db: db,
+		timeout: timeout,
+		slots: make(chan struct{}, poolSize),
+		idle: make(chan *redisConn, poolSize),
+	}
+}
+
+func(r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+
+	return value, true, nil
+}
+
+func(r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := r.do(ctx, args...)
+	return err
+}
+
+func(r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := r.do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// Close closes the idle connections; those in use are closed when they are
+// released.
+func(r *Redis) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+	var err error
+	for {
+		select {
+		case c := <-r.idle:
+			err = errors.Join(err, c.conn.Close())
+		default:
+			return err
+		}
+	}
+}
+
+func(r *Redis) do(ctx context.Context, args ...string) (any, error) {
+	c, err := r.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.roundTrip(ctx, r.timeout, args)
+	if err != nil {
+		var redisErr redisError
+		r.release(c, errors.As(err, &redisErr))
+		return nil, err
+	}
+	r.release(c, true)
+
+	return reply, nil
+}
+
+// acquire takes an idle connection, or dials a new one while the pool has a
+// free slot, and otherwise waits for one to be released.
+func(r *Redis) acquire(ctx context.Context) (*redisConn, error) {
+	select {
+	case r.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		<-r.slots
+		return nil, errors.New("redis: client is closed")
+	}
+	select {
+	case c := <-r.idle:
+		return c, nil
+	default:
+	}
+	c, err := r.dial(ctx)
+	if err != nil {
+		<-r.slots
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// release puts a healthy connection back and closes one whose stream may be
+// out of sync after a failed round trip.
+func(r *Redis) release(c *redisConn, healthy bool) {
+	defer func() { <-r.slots }()
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if !healthy || closed {
+		c.conn.Close()
+		return
+	}
+	select {
+	case r.idle <- c:
+	default:
+		c.conn.Close()
+	}
+}
+
+func(r *Redis) dial(ctx context.Context) (*redisConn, error) {
+	dialer := net.Dialer{Timeout: r.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", r.addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+	if r.password != "" {
+		if _, err := c.roundTrip(ctx, r.timeout, []string{"AUTH", r.password}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if r.db != 0 {
+		if _, err := c.roundTrip(ctx, r.timeout, []string{"SELECT", strconv.Itoa(r.db)}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func(c *redisConn) roundTrip(ctx context.Context, timeout time.Duration, args []string) (any, error) {
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	c.conn.SetDeadline(deadline)
+	if _, err := c.conn.Write(encodeCommand(args)); err != nil {
+		return nil, err
+	}
+
+	return readReply(c.reader)
+}
+
+type redisError string
+
+func(e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+func encodeCommand(args []string) []byte {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+
+	return buf
+}
+
+// readReply decodes one RESP2 value. Bulk strings come back as []byte, nil
+// bulk strings as nil, integers as int64 and simple strings as string.
+func readReply(reader *bufio.Reader) (any, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return string(line[1:]), nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(string(line[1:]), 10, 64)
+	case '$':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]any, 0, n)
+		for i := 0; i < n; i++ {
+			item, err := readReply(reader)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+}
+
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, errors.New("redis: malformed line")
+	}
+
+	return line[:len(line)-2], nil
+}
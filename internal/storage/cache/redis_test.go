@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRedisGetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	addr, _ := startFakeRedis(t)
+	r := NewRedis(addr, "secret", 1, 2, time.Second)
+	defer r.Close()
+
+	if _, ok, err := r.Get(ctx, "a"); err != nil || ok {
+		t.Fatalf("Excpected miss, got ok=%v err=%v", ok, err)
+	}
+	if err := r.Set(ctx, "a", []byte("value\r\nwith crlf"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	value, ok, err := r.Get(ctx, "a")
+	if err != nil || !ok {
+		t.Fatalf("Excpected hit, got ok=%v err=%v", ok, err)
+	}
+	if string(value) != "value\r\nwith crlf" {
+		t.Errorf("Excpected stored value, got %q", value)
+	}
+	if err := r.Delete(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := r.Get(ctx, "a"); ok {
+		t.Error("Excpected key to be deleted")
+	}
+}
+
+func TestRedisReportsServerErrors(t *testing.T) {
+	addr, _ := startFakeRedis(t)
+	r := NewRedis(addr, "wrong", 0, 2, time.Second)
+	defer r.Close()
+
+	if _, _, err := r.Get(context.Background(), "a"); err == nil {
+		t.Error("Excpected auth error")
+	}
+}
+
+func TestRedisPoolsConnections(t *testing.T) {
+	ctx := context.Background()
+	addr, conns := startFakeRedis(t)
+	r := NewRedis(addr, "", 0, 2, time.Second)
+	defer r.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := strconv.Itoa(i)
+			if err := r.Set(ctx, key, []byte(key), time.Minute); err != nil {
+				t.Error(err)
+				return
+			}
+			if value, ok, err := r.Get(ctx, key); err != nil || !ok || string(value) != key {
+				t.Errorf("Excpected %q, got %q ok=%v err=%v", key, value, ok, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := conns.Load(); n > 2 {
+		t.Errorf("Excpected at most 2 connections, got %d", n)
+	}
+}
+
+// startFakeRedis serves the handful of commands the backend sends, with
+// "secret" as the only accepted password, and counts the accepted
+// connections.
+func startFakeRedis(t *testing.T) (string, *atomic.Int32) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	var mu sync.Mutex
+	data := make(map[string]string)
+	conns := new(atomic.Int32)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns.Add(1)
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					reply, err := readReply(reader)
+					if err != nil {
+						return
+					}
+					items, _ := reply.([]any)
+					args := make([]string, 0, len(items))
+					for _, item := range items {
+						b, _ := item.([]byte)
+						args = append(args, string(b))
+					}
+					mu.Lock()
+					conn.Write([]byte(fakeRedisReply(data, args)))
+					mu.Unlock()
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), conns
+}
+
+func fakeRedisReply(data map[string]string, args []string) string {
+	switch strings.ToUpper(args[0]) {
+	case "AUTH":
+		if args[1] != "secret" {
+			return "-WRONGPASS invalid password\r\n"
+		}
+		return "+OK\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		value, ok := data[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+	case "SET":
+		data[args[1]] = args[2]
+		return "+OK\r\n"
+	case "DEL":
+		n := 0
+		for _, key := range args[1:] {
+			if _, ok := data[key]; ok {
+				delete(data, key)
+				n++
+			}
+		}
+		return ":" + strconv.Itoa(n) + "\r\n"
+	}
+
+	return "-ERR unknown command\r\n"
+}
@@ -0,0 +1,417 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+)
+
+// generationSlots is the number of invalidation counters the keys are
+// spread over.
+const generationSlots = 256
+
+// Backend keeps serialized entries. LRU and Redis implement it.
+type Backend interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Close() error
+}
+
+type Stats struct {
+	Hits uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	Errors uint64 `json:"errors"`
+}
+
+// Storage is a read-through decorator over the question and answer storages.
+// Single questions (with their answers) and single answers are cached;
+// writes pass through and drop the entries they make stale. It wraps the
+// user storage too, as a profile update changes the authors embedded in
+// the cached answers. Users themselves are not cached, so the reputation
+// ledger writes to the storage directly: no cached entry holds a
+// reputation, and GET /users/{id} and the leaderboard read the same rows.
+type Storage struct {
+	service.StorageUser
+	log *slog.Logger
+	backend Backend
+	ttl time.Duration
+	questions service.StorageQuestion
+	answers service.StorageAnswer
+	hits atomic.Uint64
+	misses atomic.Uint64
+	errors atomic.Uint64
+	// generations count the invalidations of the keys hashed to each slot.
+	// A read-through stores what it loaded only if no write of this process
+	// invalidated the key meanwhile, as it may have loaded the row before
+	// the write and would keep it until the TTL.
+	generations [generationSlots]atomic.Uint64
+	closeOnce sync.Once
+}
+
+func NewStorage(log *slog.Logger, backend Backend, ttl time.Duration, questions service.StorageQuestion, answers service.StorageAnswer, users service.StorageUser) *Storage {
+	return &Storage{
+		StorageUser: users,
+		log: log,
+		backend: backend,
+		ttl: ttl,
+		questions: questions,
+		answers: answers,
+	}
+}
+
+// NewBackend builds the backend selected in config.
+func NewBackend(cfg config.CacheConfig) (Backend, error) {
+	switch cfg.Backend {
+	case "", "memory":
+		return NewLRU(cfg.Size), nil
+	case "redis":
+		return NewRedis(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB, cfg.Redis.PoolSize, cfg.Redis.Timeout), nil
+	}
+
+	return nil, fmt.Errorf("unknown cache backend: %s", cfg.Backend)
+}
+
+func(s *Storage) Stats() Stats {
+	return Stats{
+		Hits: s.hits.Load(),
+		Misses: s.misses.Load(),
+		Errors: s.errors.Load(),
+	}
+}
+
+func(s *Storage) CreateQuestion(ctx context.Context, data *models.Question) error {
+	return s.questions.CreateQuestion(ctx, data)
+}
+
+func(s *Storage) Question(ctx context.Context, id int) (models.QuestionWithAnswers, error) {
+	var res models.QuestionWithAnswers
+	key := questionKey(id)
+	if s.load(ctx, key, &res) {
+		return res, nil
+	}
+	generation := s.generation(key)
+	res, err := s.questions.Question(ctx, id)
+	if err != nil {
+		return res, err
+	}
+	s.store(ctx, key, generation, res)
+
+	return res, nil
+}
+
+func(s *Storage) AllQuestions(ctx context.Context, order models.QuestionSort) ([]models.Question, error) {
+	return s.questions.AllQuestions(ctx, order)
+}
+
+// StreamQuestions is not cached: a stream is read once.
+func(s *Storage) StreamQuestions(ctx context.Context, order models.QuestionSort, yield func(models.Question) error) error {
+	return s.questions.StreamQuestions(ctx, order, yield)
+}
+
+func(s *Storage) DeleteQuestion(ctx context.Context, id, version int) (models.QuestionDelete, error) {
+	res, err := s.questions.DeleteQuestion(ctx, id, version)
+	if err == nil && res.Deleted {
+		s.invalidate(ctx, deletedKeys(res)...)
+	}
+
+	return res, err
+}
+
+func(s *Storage) CreateQuestions(ctx context.Context, data []*models.Question) error {
+	return s.questions.CreateQuestions(ctx, data)
+}
+
+func(s *Storage) WriteQuestions(ctx context.Context, batch *models.QuestionBatch) error {
+	err := s.questions.WriteQuestions(ctx, batch)
+	if err != nil || len(batch.Delete) == 0 {
+		return err
+	}
+	keys := make([]string, 0, len(batch.Delete))
+	for _, item := range batch.Delete {
+		keys = append(keys, deletedKeys(item)...)
+	}
+	s.invalidate(ctx, keys...)
+
+	return nil
+}
+
+func(s *Storage) SimilarQuestions(ctx context.Context, text string, threshold float64, limit int) ([]models.SimilarQuestion, error) {
+	return s.questions.SimilarQuestions(ctx, text, threshold, limit)
+}
+
+func(s *Storage) MarkDuplicate(ctx context.Context, id, target, version int) (models.Question, error) {
+	res, err := s.questions.MarkDuplicate(ctx, id, target, version)
+	if err == nil {
+		s.invalidate(ctx, questionKey(id))
+	}
+
+	return res, err
+}
+
+func(s *Storage) MergeQuestions(ctx context.Context, id, target, version int, userID string) (models.QuestionsMerged, error) {
+	res, err := s.questions.MergeQuestions(ctx, id, target, version, userID)
+	if err != nil {
+		return res, err
+	}
+	keys := []string{questionKey(id), questionKey(res.Question.ID)}
+	for _, answer := range res.Answers {
+		keys = append(keys, answerKey(answer.ID))
+	}
+	for _, repointed := range res.Repointed {
+		keys = append(keys, questionKey(repointed))
+	}
+	s.invalidate(ctx, keys...)
+
+	return res, nil
+}
+
+// The batch reads of the GraphQL loaders are not cached: the loaders
+// already read each row once per request.
+func(s *Storage) QuestionsByID(ctx context.Context, ids []int) ([]models.Question, error) {
+	return s.questions.QuestionsByID(ctx, ids)
+}
+
+func(s *Storage) QuestionsPage(ctx context.Context, order models.QuestionSort, tag string, page models.Page) ([]models.Question, error) {
+	return s.questions.QuestionsPage(ctx, order, tag, page)
+}
+
+func(s *Storage) QuestionsByTag(ctx context.Context, tags []string, page models.Page) ([]models.TaggedQuestion, error) {
+	return s.questions.QuestionsByTag(ctx, tags, page)
+}
+
+// Exist is not cached: it tells whether the question is there now, as
+// after a conditional delete that removed nothing.
+func(s *Storage) Exist(ctx context.Context, id int) (models.Question, error) {
+	return s.questions.Exist(ctx, id)
+}
+
+func(s *Storage) CreateAnswer(ctx context.Context, data []*models.Answer) error {
+	err := s.answers.CreateAnswer(ctx, data)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(data))
+	for _, answer := range data {
+		keys = append(keys, questionKey(answer.QuestionID))
+	}
+	s.invalidate(ctx, keys...)
+
+	return nil
+}
+
+func(s *Storage) GetAnswer(ctx context.Context, id int) (models.Answer, error) {
+	var res models.Answer
+	key := answerKey(id)
+	if s.load(ctx, key, &res) {
+		return res, nil
+	}
+	generation := s.generation(key)
+	res, err := s.answers.GetAnswer(ctx, id)
+	if err != nil {
+		return res, err
+	}
+	s.store(ctx, key, generation, res)
+
+	return res, nil
+}
+
+func(s *Storage) StreamAnswers(ctx context.Context, questionID int, yield func(models.Answer) error) error {
+	return s.answers.StreamAnswers(ctx, questionID, yield)
+}
+
+func(s *Storage) AnswersByQuestion(ctx context.Context, questionIDs []int) ([]models.Answer, error) {
+	return s.answers.AnswersByQuestion(ctx, questionIDs)
+}
+
+func(s *Storage) DeleteAnswer(ctx context.Context, id, version int) (int, error) {
+	answer, err := s.GetAnswer(ctx, id)
+	if err != nil {
+		return s.answers.DeleteAnswer(ctx, id, version)
+	}
+	rows, err := s.answers.DeleteAnswer(ctx, id, version)
+	if err == nil && rows > 0 {
+		s.invalidate(ctx, answerKey(id), questionKey(answer.QuestionID))
+	}
+
+	return rows, err
+}
+
+func(s *Storage) VoteAnswer(ctx context.Context, vote *models.Vote) (models.AnswerVoted, error) {
+	res, err := s.answers.VoteAnswer(ctx, vote)
+	if err == nil && res.Value != res.Previous {
+		s.invalidate(ctx, answerKey(res.Answer.ID), questionKey(res.Answer.QuestionID))
+	}
+
+	return res, err
+}
+
+func(s *Storage) MoveAnswer(ctx context.Context, id, target, version int, userID string) (models.AnswerMoved, error) {
+	res, err := s.answers.MoveAnswer(ctx, id, target, version, userID)
+	if err == nil && res.From != res.Answer.QuestionID {
+		s.invalidate(ctx, answerKey(id), questionKey(res.From), questionKey(res.Answer.QuestionID))
+	}
+
+	return res, err
+}
+
+func(s *Storage) AcceptAnswer(ctx context.Context, id int) (models.AnswerAccepted, error) {
+	res, err := s.answers.AcceptAnswer(ctx, id)
+	if err != nil {
+		return res, err
+	}
+	keys := []string{answerKey(res.Answer.ID), questionKey(res.Answer.QuestionID)}
+	if res.Previous != nil {
+		keys = append(keys, answerKey(res.Previous.ID))
+	}
+	s.invalidate(ctx, keys...)
+
+	return res, nil
+}
+
+// SaveUser drops the answers of the user and the questions they are in, to
+// serve them with the new profile.
+func(s *Storage) SaveUser(ctx context.Context, data *models.User) error {
+	if err := s.StorageUser.SaveUser(ctx, data); err != nil {
+		return err
+	}
+	answers, err := s.StorageUser.AuthoredAnswers(ctx, data.ID)
+	if err != nil {
+		s.logError("CacheInvalidatingError", err)
+		return nil
+	}
+	keys := make([]string, 0, 2 * len(answers))
+	for _, answer := range answers {
+		keys = append(keys, answerKey(answer.ID), questionKey(answer.QuestionID))
+	}
+	if len(keys) > 0 {
+		s.invalidate(ctx, keys...)
+	}
+
+	return nil
+}
+
+// Shutdown closes the backend once and then the wrapped storages, which the
+// service calls through both of its storage interfaces.
+func(s *Storage) Shutdown(ctx context.Context) {
+	s.closeOnce.Do(func() {
+		s.log.Info(
+			"Cache stats",
+			slog.Uint64("hits", s.hits.Load()),
+			slog.Uint64("misses", s.misses.Load()),
+		)
+		if err := s.backend.Close(); err != nil {
+			s.log.Error(
+				"CacheClosingError",
+				slog.String("component", "cache"),
+				slog.Any("error", err),
+			)
+		}
+		s.questions.Shutdown(ctx)
+		s.answers.Shutdown(ctx)
+	})
+}
+
+func(s *Storage) load(ctx context.Context, key string, dst any) bool {
+	if !s.peek(ctx, key, dst) {
+		s.misses.Add(1)
+		return false
+	}
+	s.hits.Add(1)
+
+	return true
+}
+
+func(s *Storage) peek(ctx context.Context, key string, dst any) bool {
+	data, ok, err := s.backend.Get(ctx, key)
+	if err != nil {
+		s.logError("CacheReadingError", err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		s.logError("CacheDecodingError", err)
+		return false
+	}
+
+	return true
+}
+
+// store caches the value loaded at generation of the key. The generation is
+// checked again after the write, in case an invalidation ran between the
+// check and the write.
+func(s *Storage) store(ctx context.Context, key string, generation uint64, value any) {
+	if s.generation(key) != generation {
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		s.logError("CacheEncodingError", err)
+		return
+	}
+	if err := s.backend.Set(ctx, key, data, s.ttl); err != nil {
+		s.logError("CacheWritingError", err)
+		return
+	}
+	if s.generation(key) != generation {
+		s.invalidate(ctx, key)
+	}
+}
+
+func(s *Storage) invalidate(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		s.generations[slot(key)].Add(1)
+	}
+	if err := s.backend.Delete(ctx, keys...); err != nil {
+		s.logError("CacheDeletingError", err)
+	}
+}
+
+func(s *Storage) generation(key string) uint64 {
+	return s.generations[slot(key)].Load()
+}
+
+func slot(key string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % generationSlots)
+}
+
+func(s *Storage) logError(msg string, err error) {
+	s.errors.Add(1)
+	s.log.Error(
+		msg,
+		slog.String("component", "cache"),
+		slog.Any("error", err),
+	)
+}
+
+// deletedKeys are the entries of a deleted question and of its answers,
+// cached or not.
+func deletedKeys(item models.QuestionDelete) []string {
+	keys := make([]string, 0, len(item.AnswerIDs) + 1)
+	keys = append(keys, questionKey(item.ID))
+	for _, id := range item.AnswerIDs {
+		keys = append(keys, answerKey(id))
+	}
+	return keys
+}
+
+func questionKey(id int) string {
+	return fmt.Sprintf("qa:question:%d", id)
+}
+
+func answerKey(id int) string {
+	return fmt.Sprintf("qa:answer:%d", id)
+}
@@ -0,0 +1,351 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+func TestQuestionIsReadThrough(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage()
+	question := createQuestion(t, s)
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.Question(ctx, question.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats := s.Stats()
+	if stats.Misses != 1 || stats.Hits != 2 {
+		t.Errorf("Excpected 1 miss and 2 hits, got %+v", stats)
+	}
+}
+
+func TestCreateAnswerInvalidatesQuestion(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage()
+	question := createQuestion(t, s)
+
+	if _, err := s.Question(ctx, question.ID); err != nil {
+		t.Fatal(err)
+	}
+	createAnswer(t, s, question.ID)
+
+	res, err := s.Question(ctx, question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Answers) != 1 {
+		t.Errorf("Excpected 1 answer after invalidation, got %d", len(res.Answers))
+	}
+}
+
+func TestDeleteAnswerInvalidatesQuestionAndAnswer(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage()
+	question := createQuestion(t, s)
+	answer := createAnswer(t, s, question.ID)
+
+	if _, err := s.Question(ctx, question.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteAnswer(ctx, answer.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.Question(ctx, question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Answers) != 0 {
+		t.Errorf("Excpected no answers after delete, got %d", len(res.Answers))
+	}
+	if _, err := s.GetAnswer(ctx, answer.ID); err == nil {
+		t.Error("Excpected deleted answer to be gone from cache")
+	}
+}
+
+func TestDeleteQuestionInvalidatesQuestion(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage()
+	question := createQuestion(t, s)
+	answer := createAnswer(t, s, question.ID)
+
+	if _, err := s.Question(ctx, question.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetAnswer(ctx, answer.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteQuestion(ctx, question.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Question(ctx, question.ID); err == nil {
+		t.Error("Excpected deleted question to be gone from cache")
+	}
+	if _, err := s.GetAnswer(ctx, answer.ID); err == nil {
+		t.Error("Excpected answers of deleted question to be gone from cache")
+	}
+}
+
+func TestDeleteQuestionInvalidatesUncachedQuestionAnswers(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage()
+	question := createQuestion(t, s)
+	answer := createAnswer(t, s, question.ID)
+
+	// Only the answer is cached, so the question entry cannot list it.
+	if _, err := s.GetAnswer(ctx, answer.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteQuestion(ctx, question.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetAnswer(ctx, answer.ID); err == nil {
+		t.Error("Excpected answers of deleted question to be gone from cache")
+	}
+}
+
+func TestMergeInvalidatesRepointedDuplicates(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage()
+	target := createQuestion(t, s)
+	merged := createQuestion(t, s)
+	duplicate := createQuestion(t, s)
+	if _, err := s.MarkDuplicate(ctx, duplicate.ID, merged.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Question(ctx, duplicate.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.MergeQuestions(ctx, merged.ID, target.ID, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Question(ctx, duplicate.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Question.DuplicateOf == nil || *res.Question.DuplicateOf != target.ID {
+		t.Errorf("Excpected the duplicate to redirect to %d, got %v", target.ID, res.Question.DuplicateOf)
+	}
+}
+
+func TestSaveUserInvalidatesAuthoredAnswers(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage()
+	question := createQuestion(t, s)
+	answer := createAnswer(t, s, question.ID)
+
+	if _, err := s.Question(ctx, question.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetAnswer(ctx, answer.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SaveUser(ctx, &models.User{ID: answer.UserID, DisplayName: "Ann"}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.Question(ctx, question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Answers[0].Author == nil || res.Answers[0].Author.DisplayName != "Ann" {
+		t.Errorf("Excpected the question with the new author, got %+v", res.Answers[0].Author)
+	}
+	got, err := s.GetAnswer(ctx, answer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Author == nil || got.Author.DisplayName != "Ann" {
+		t.Errorf("Excpected the answer with the new author, got %+v", got.Author)
+	}
+}
+
+// racingQuestions runs write after reading a question and before returning
+// it, as a write landing during a read-through.
+type racingQuestions struct {
+	*mock.MockStorageQuestions
+	write func()
+}
+
+func(r *racingQuestions) Question(ctx context.Context, id int) (models.QuestionWithAnswers, error) {
+	res, err := r.MockStorageQuestions.Question(ctx, id)
+	if r.write != nil {
+		write := r.write
+		r.write = nil
+		write()
+	}
+	return res, err
+}
+
+func TestReadThroughSkipsEntryInvalidatedDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := &racingQuestions{MockStorageQuestions: mock.NewMockStorageQuestions(1, answers)}
+	s := NewStorage(slog.Default(), NewLRU(10), time.Minute, questions, answers, mock.NewMockStorageUsers(questions.MockStorageQuestions))
+	question := createQuestion(t, s)
+
+	questions.write = func() { createAnswer(t, s, question.ID) }
+	if _, err := s.Question(ctx, question.ID); err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Question(ctx, question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Answers) != 1 {
+		t.Errorf("Excpected the answer created during the load, got %d answers", len(res.Answers))
+	}
+}
+
+func TestExistIsNotCached(t *testing.T) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	s := NewStorage(slog.Default(), NewLRU(10), time.Minute, questions, answers, mock.NewMockStorageUsers(questions))
+	question := createQuestion(t, s)
+	if _, err := s.Question(ctx, question.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleted behind the cache, as by another replica.
+	if _, err := questions.DeleteQuestion(ctx, question.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Exist(ctx, question.ID); err == nil {
+		t.Error("Excpected a deleted question not to exist")
+	}
+}
+
+func TestBackfillDropsRenderedEntries(t *testing.T) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	s := NewStorage(slog.Default(), NewLRU(10), time.Minute, questions, answers, mock.NewMockStorageUsers(questions))
+	question := createQuestion(t, s)
+	answer := createAnswer(t, s, question.ID)
+	before, err := s.Question(ctx, question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetAnswer(ctx, answer.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	contents := NewContents(s, questions)
+	for _, table := range []string{"questions", "answers"} {
+		unrendered, err := contents.UnrenderedContents(ctx, table, 0, 10)
+		if err != nil || len(unrendered) != 1 {
+			t.Fatalf("Excpected 1 unrendered row in %s, got %v %v", table, unrendered, err)
+		}
+		unrendered[0].TextHTML = "<p>test</p>"
+		if err := contents.SaveRenderedContents(ctx, table, unrendered); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	after, err := s.Question(ctx, question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Question.TextHTML == "" || after.Question.Version == before.Question.Version || after.Answers[0].TextHTML == "" {
+		t.Errorf("Excpected the rendered question and answer, got %+v", after)
+	}
+	cached, err := s.GetAnswer(ctx, answer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.TextHTML == "" {
+		t.Errorf("Excpected the rendered answer, got %+v", cached)
+	}
+}
+
+func TestViewFlushDropsCountedQuestions(t *testing.T) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	s := NewStorage(slog.Default(), NewLRU(10), time.Minute, questions, answers, mock.NewMockStorageUsers(questions))
+	question := createQuestion(t, s)
+	if _, err := s.Question(ctx, question.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	views := NewViews(s, mock.NewMockStorageViews(questions))
+	if err := views.AddViews(ctx, map[int]int{question.ID: 3}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.Question(ctx, question.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Question.ViewCount != 3 {
+		t.Errorf("Excpected 3 views, got %d", res.Question.ViewCount)
+	}
+}
+
+// Users are not cached, so a reputation write is seen by the next read.
+func TestReputationIsNotCached(t *testing.T) {
+	ctx := context.Background()
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+	users := mock.NewMockStorageUsers(questions)
+	s := NewStorage(slog.Default(), NewLRU(10), time.Minute, questions, answers, users)
+	question := createQuestion(t, s)
+	answer := createAnswer(t, s, question.ID)
+	if _, err := s.Question(ctx, question.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.User(ctx, answer.UserID); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := models.ReputationEvent{EventID: 1, UserID: answer.UserID, Points: 10}
+	if err := users.RecordReputation(ctx, []models.ReputationEvent{entry}); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := s.User(ctx, answer.UserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaders, err := s.Leaderboard(ctx, models.Page{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Reputation != 10 || len(leaders) != 1 || leaders[0].Reputation != user.Reputation {
+		t.Errorf("Excpected the reputation of 10 everywhere, got %+v and %+v", user, leaders)
+	}
+}
+
+func newTestStorage() *Storage {
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(1, answers)
+
+	return NewStorage(slog.Default(), NewLRU(10), time.Minute, questions, answers, mock.NewMockStorageUsers(questions))
+}
+
+func createQuestion(t *testing.T, s *Storage) models.Question {
+	question := models.Question{Text: "test", Version: 1}
+	if err := s.CreateQuestion(context.Background(), &question); err != nil {
+		t.Fatal(err)
+	}
+	return question
+}
+
+func createAnswer(t *testing.T, s *Storage, questionID int) models.Answer {
+	answer := &models.Answer{Text: "test", QuestionID: questionID, UserID: "3fa85f64-5717-4562-b3fc-2c963f66afa6", Version: 1}
+	if err := s.CreateAnswer(context.Background(), []*models.Answer{answer}); err != nil {
+		t.Fatal(err)
+	}
+	return *answer
+}
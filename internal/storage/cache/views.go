@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// ViewStorage is the storage of the view counter.
+type ViewStorage interface {
+	AddViews(ctx context.Context, views map[int]int, weight float64) error
+	TrendingQuestions(ctx context.Context, page models.Page) ([]models.Question, error)
+}
+
+// Views passes the flushes of the view counter through the cache, so the
+// cached questions they count are dropped instead of being served with the
+// old ViewCount until the TTL.
+type Views struct {
+	ViewStorage
+	cache *Storage
+}
+
+func NewViews(cache *Storage, views ViewStorage) *Views {
+	return &Views{
+		ViewStorage: views,
+		cache: cache,
+	}
+}
+
+func(v *Views) AddViews(ctx context.Context, views map[int]int, weight float64) error {
+	if err := v.ViewStorage.AddViews(ctx, views, weight); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(views))
+	for id := range views {
+		keys = append(keys, questionKey(id))
+	}
+	v.cache.invalidate(ctx, keys...)
+
+	return nil
+}
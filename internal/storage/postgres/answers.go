@@ -3,17 +3,60 @@ package postgres
 import (
 	"context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"github.com/behummble/Questions-answers/internal/events"
 	"github.com/behummble/Questions-answers/internal/models"
 )
 
 func(s *Storage) CreateAnswer(ctx context.Context, data []*models.Answer) error {
-	return s.conn.WithContext(ctx).Create(data).Error
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureUsers(ctx, tx, data); err != nil {
+			return err
+		}
+		ids := make([]int, 0, len(data))
+		for _, answer := range data {
+			ids = append(ids, answer.QuestionID)
+		}
+		if err := bumpQuestions(tx, ids...); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(data).Error; err != nil {
+			return err
+		}
+		if err := loadAuthors(ctx, tx, data); err != nil {
+			return err
+		}
+		for _, answer := range data {
+			if err := writeOutbox(ctx, tx, events.NewAnswerCreated(*answer)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func(s *Storage) GetAnswer(ctx context.Context, id int) (models.Answer, error) {
-	return gorm.G[models.Answer](s.conn).Where("id = ?", id).First(ctx)
+	return gorm.G[models.Answer](s.conn).Preload("Author", nil).Where("id = ?", id).First(ctx)
 }
 
-func(s *Storage) DeleteAnswer(ctx context.Context, id int) (int, error) {
-	return gorm.G[models.Answer](s.conn).Where("id = ?", id).Delete(ctx)
+func(s *Storage) DeleteAnswer(ctx context.Context, id, version int) (int, error) {
+	var rows int
+	err := s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Returning{}).Where("id = ?", id)
+		if version != 0 {
+			query = query.Where("version = ?", version)
+		}
+		var deleted []models.Answer
+		res := query.Delete(&deleted)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		rows = int(res.RowsAffected)
+		if err := bumpQuestions(tx, deleted[0].QuestionID); err != nil {
+			return err
+		}
+		return writeOutbox(ctx, tx, events.NewAnswerDeleted(deleted[0]))
+	})
+
+	return rows, err
 }
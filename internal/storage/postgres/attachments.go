@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateAttachment locks the parent, so it cannot be deleted before the
+// row exists and the attachment is never orphaned unnoticed.
+func(s *Storage) CreateAttachment(ctx context.Context, data *models.Attachment) error {
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var parent any = &models.Question{}
+		id := data.QuestionID
+		if data.AnswerID != nil {
+			parent = &models.Answer{}
+			id = data.AnswerID
+		}
+		err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+			Where("id = ?", *id).
+			First(parent).Error
+		if err != nil {
+			return err
+		}
+		if err := ensureUser(tx, data.UserID); err != nil {
+			return err
+		}
+		return gorm.G[models.Attachment](tx).Create(ctx, data)
+	})
+}
+
+func(s *Storage) Attachment(ctx context.Context, id int) (models.Attachment, error) {
+	return gorm.G[models.Attachment](s.conn).Where("id = ?", id).First(ctx)
+}
+
+func(s *Storage) QuestionAttachments(ctx context.Context, questionID int) ([]models.Attachment, error) {
+	if _, err := s.Exist(ctx, questionID); err != nil {
+		return nil, err
+	}
+	return gorm.G[models.Attachment](s.conn).Where("question_id = ?", questionID).Order("id").Find(ctx)
+}
+
+func(s *Storage) AnswerAttachments(ctx context.Context, answerID int) ([]models.Attachment, error) {
+	if _, err := gorm.G[models.Answer](s.conn).Where("id = ?", answerID).First(ctx); err != nil {
+		return nil, err
+	}
+	return gorm.G[models.Attachment](s.conn).Where("answer_id = ?", answerID).Order("id").Find(ctx)
+}
+
+// OrphanedAttachments returns attachments the foreign keys detached from a
+// deleted question or answer.
+func(s *Storage) OrphanedAttachments(ctx context.Context, limit int) ([]models.Attachment, error) {
+	return gorm.G[models.Attachment](s.conn).
+		Where("question_id IS NULL AND answer_id IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(ctx)
+}
+
+func(s *Storage) DeleteAttachments(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := gorm.G[models.Attachment](s.conn).Where("id IN ?", ids).Delete(ctx)
+	return err
+}
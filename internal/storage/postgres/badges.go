@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaveBadges creates the badges or updates their title and description by
+// name, and returns them with their IDs.
+func(s *Storage) SaveBadges(ctx context.Context, badges []models.Badge) ([]models.Badge, error) {
+	if len(badges) == 0 {
+		return badges, nil
+	}
+	err := s.conn.WithContext(ctx).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "name"}},
+				DoUpdates: clause.AssignmentColumns([]string{"title", "description"}),
+			},
+			clause.Returning{},
+		).
+		Create(&badges).Error
+
+	return badges, err
+}
+
+func(s *Storage) AllBadges(ctx context.Context) ([]models.Badge, error) {
+	return gorm.G[models.Badge](s.conn).Order("id").Find(ctx)
+}
+
+func(s *Storage) UserBadges(ctx context.Context, userID string) ([]models.UserBadge, error) {
+	return gorm.G[models.UserBadge](s.conn).
+		Preload("Badge", nil).
+		Where("user_id = ?", userID).
+		Order("awarded_at").
+		Find(ctx)
+}
+
+// AwardBadge is a no-op for a badge the user already has.
+func(s *Storage) AwardBadge(ctx context.Context, award *models.UserBadge) error {
+	return s.conn.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(award).Error
+}
+
+func(s *Storage) UserStats(ctx context.Context, userID string) (models.UserStats, error) {
+	var res models.UserStats
+	err := s.conn.WithContext(ctx).Raw(`
+		SELECT
+			COUNT(answers.id) AS answers,
+			COUNT(answers.id) FILTER (WHERE answers.accepted) AS accepted_answers,
+			COALESCE(MAX(answers.score), 0) AS max_answer_score,
+			users.reputation AS reputation,
+			(SELECT COUNT(*) FROM questions WHERE questions.user_id = users.id) AS questions,
+			(SELECT COALESCE(MAX(view_count), 0) FROM questions WHERE questions.user_id = users.id) AS max_question_views
+		FROM users LEFT JOIN answers ON answers.user_id = users.id
+		WHERE users.id = ?
+		GROUP BY users.id`,
+		userID,
+	).Scan(&res).Error
+
+	return res, err
+}
+
+// QuestionAuthors returns the distinct authors of the questions.
+func(s *Storage) QuestionAuthors(ctx context.Context, ids []int) ([]string, error) {
+	var res []string
+	err := s.conn.WithContext(ctx).
+		Model(&models.Question{}).
+		Where("id IN ? AND user_id IS NOT NULL", ids).
+		Distinct().
+		Pluck("user_id", &res).Error
+
+	return res, err
+}
+
+// UserIDs pages through all users in ID order.
+func(s *Storage) UserIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	var res []string
+	query := s.conn.WithContext(ctx).Model(&models.User{}).Order("id").Limit(limit)
+	if afterID != "" {
+		query = query.Where("id > ?", afterID)
+	}
+	err := query.Pluck("id", &res).Error
+
+	return res, err
+}
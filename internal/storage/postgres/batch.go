@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+func(s *Storage) QuestionsByID(ctx context.Context, ids []int) ([]models.Question, error) {
+	return gorm.G[models.Question](s.conn).Where("id IN ?", ids).Find(ctx)
+}
+
+func(s *Storage) AnswersByQuestion(ctx context.Context, questionIDs []int) ([]models.Answer, error) {
+	return gorm.G[models.Answer](s.conn).
+		Where("question_id IN ?", questionIDs).
+		Order("question_id, id").
+		Find(ctx)
+}
+
+func(s *Storage) UsersByID(ctx context.Context, ids []string) ([]models.User, error) {
+	return gorm.G[models.User](s.conn).Where("id IN ?", ids).Find(ctx)
+}
+
+// AnswersByUser numbers the answers of each user from the newest, so one
+// query returns the same page for every user.
+func(s *Storage) AnswersByUser(ctx context.Context, ids []string, page models.Page) ([]models.Answer, error) {
+	var res []models.Answer
+	err := s.conn.WithContext(ctx).Raw(`
+		SELECT id, question_id, user_id, text, text_html, score, accepted, version, created_at
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY id DESC) AS position
+			FROM answers
+			WHERE user_id IN ?
+		) ranked
+		WHERE position > ? AND position <= ?
+		ORDER BY user_id, id DESC`,
+		ids, page.Offset, page.Offset + page.Limit,
+	).Scan(&res).Error
+
+	return res, err
+}
+
+func(s *Storage) QuestionsPage(ctx context.Context, order models.QuestionSort, tag string, page models.Page) ([]models.Question, error) {
+	query := gorm.G[models.Question](s.conn).Limit(page.Limit).Offset(page.Offset)
+	if tag != "" {
+		query = query.Where("tags @> jsonb_build_array(?::text)", tag)
+	}
+	if order == models.SortViews {
+		return query.Order("view_count DESC, id DESC").Find(ctx)
+	}
+	return query.Order("id DESC").Find(ctx)
+}
+
+// QuestionsByTag lists every question once per requested tag it has and
+// numbers them per tag from the newest, like AnswersByUser.
+func(s *Storage) QuestionsByTag(ctx context.Context, tags []string, page models.Page) ([]models.TaggedQuestion, error) {
+	var res []models.TaggedQuestion
+	err := s.conn.WithContext(ctx).Raw(`
+		SELECT tag, id, text, text_html, user_id, version, view_count, duplicate_of, tags, created_at
+		FROM (
+			SELECT tagged.tag, questions.*, ROW_NUMBER() OVER (PARTITION BY tagged.tag ORDER BY questions.id DESC) AS position
+			FROM questions
+			CROSS JOIN LATERAL jsonb_array_elements_text(questions.tags) AS tagged(tag)
+			WHERE tagged.tag IN ?
+		) ranked
+		WHERE position > ? AND position <= ?
+		ORDER BY tag, id DESC`,
+		tags, page.Offset, page.Offset + page.Limit,
+	).Scan(&res).Error
+
+	return res, err
+}
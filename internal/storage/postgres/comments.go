@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateComment locks the question or answer commented on, so it is not
+// deleted before the comment is written.
+func(s *Storage) CreateComment(ctx context.Context, data *models.Comment) error {
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		created := models.CommentCreated{}
+		locked := tx.Clauses(clause.Locking{Strength: "SHARE"})
+		if data.AnswerID != nil {
+			answer, err := gorm.G[models.Answer](locked).Where("id = ?", *data.AnswerID).First(ctx)
+			if err != nil {
+				return err
+			}
+			created.QuestionID = answer.QuestionID
+			created.ParentAuthorID = answer.UserID
+		} else {
+			question, err := gorm.G[models.Question](locked).Where("id = ?", *data.QuestionID).First(ctx)
+			if err != nil {
+				return err
+			}
+			created.QuestionID = question.ID
+			if question.UserID != nil {
+				created.ParentAuthorID = *question.UserID
+			}
+		}
+		if err := ensureUser(tx, data.UserID); err != nil {
+			return err
+		}
+		if err := gorm.G[models.Comment](tx).Create(ctx, data); err != nil {
+			return err
+		}
+		created.Comment = *data
+		return writeOutbox(ctx, tx, events.NewCommentCreated(created))
+	})
+}
+
+func(s *Storage) Comment(ctx context.Context, id int) (models.Comment, error) {
+	return gorm.G[models.Comment](s.conn).Where("id = ?", id).First(ctx)
+}
+
+func(s *Storage) QuestionComments(ctx context.Context, questionID int, page models.Page) ([]models.Comment, error) {
+	if _, err := s.Exist(ctx, questionID); err != nil {
+		return nil, err
+	}
+	return gorm.G[models.Comment](s.conn).
+		Where("question_id = ?", questionID).
+		Order("id").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(ctx)
+}
+
+func(s *Storage) AnswerComments(ctx context.Context, answerID int, page models.Page) ([]models.Comment, error) {
+	if _, err := gorm.G[models.Answer](s.conn).Where("id = ?", answerID).First(ctx); err != nil {
+		return nil, err
+	}
+	return gorm.G[models.Comment](s.conn).
+		Where("answer_id = ?", answerID).
+		Order("id").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(ctx)
+}
+
+func(s *Storage) DeleteComment(ctx context.Context, id int) (int, error) {
+	return gorm.G[models.Comment](s.conn).Where("id = ?", id).Delete(ctx)
+}
+
+// CommentsByQuestion numbers the comments of each question from the
+// oldest, so one query returns the same page for every question.
+func(s *Storage) CommentsByQuestion(ctx context.Context, questionIDs []int, page models.Page) ([]models.Comment, error) {
+	return s.commentsBy(ctx, "question_id", questionIDs, page)
+}
+
+func(s *Storage) CommentsByAnswer(ctx context.Context, answerIDs []int, page models.Page) ([]models.Comment, error) {
+	return s.commentsBy(ctx, "answer_id", answerIDs, page)
+}
+
+// commentsBy pages the comments by parent, a column name of this file.
+func(s *Storage) commentsBy(ctx context.Context, parent string, ids []int, page models.Page) ([]models.Comment, error) {
+	var res []models.Comment
+	err := s.conn.WithContext(ctx).Raw(`
+		SELECT id, question_id, answer_id, user_id, text, created_at
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY ` + parent + ` ORDER BY id) AS position
+			FROM comments
+			WHERE ` + parent + ` IN ?
+		) ranked
+		WHERE position > ? AND position <= ?
+		ORDER BY ` + parent + `, id`,
+		ids, page.Offset, page.Offset + page.Limit,
+	).Scan(&res).Error
+
+	return res, err
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+func(s *Storage) DueDigests(ctx context.Context, frequency string, sentBefore time.Time, after string, limit int) ([]models.NotificationPreferences, error) {
+	query := gorm.G[models.NotificationPreferences](s.conn).
+		Where("digest = ? AND email <> '' AND email_verified_at IS NOT NULL", frequency).
+		Where("digest_sent_at IS NULL OR digest_sent_at <= ?", sentBefore)
+	if after != "" {
+		query = query.Where("user_id > ?", after)
+	}
+	return query.Order("user_id").Limit(limit).Find(ctx)
+}
+
+func(s *Storage) FollowedAnswers(ctx context.Context, userID string, since, until time.Time) ([]models.DigestAnswer, error) {
+	var res []models.DigestAnswer
+	err := s.conn.WithContext(ctx).
+		Table("answers").
+		Select("answers.question_id, questions.text AS question_text, answers.id AS answer_id, answers.text AS answer_text, answers.user_id, answers.created_at").
+		Joins("JOIN question_followers ON question_followers.question_id = answers.question_id").
+		Joins("JOIN questions ON questions.id = answers.question_id").
+		Where("question_followers.user_id = ? AND answers.user_id <> ?", userID, userID).
+		Where("answers.created_at >= ? AND answers.created_at < ?", since, until).
+		Order("answers.question_id, answers.id").
+		Scan(&res).Error
+
+	return res, err
+}
+
+func(s *Storage) UnansweredQuestions(ctx context.Context, userID string, since, until time.Time, limit int) ([]models.DigestQuestion, error) {
+	questions, err := gorm.G[models.Question](s.conn).
+		Where("created_at >= ? AND created_at < ?", since, until).
+		Where("duplicate_of IS NULL AND (user_id IS NULL OR user_id <> ?)", userID).
+		Where("EXISTS (SELECT 1 FROM tag_followers WHERE tag_followers.user_id = ? AND questions.tags @> jsonb_build_array(tag_followers.tag))", userID).
+		Where("NOT EXISTS (SELECT 1 FROM answers WHERE answers.question_id = questions.id)").
+		Order("id").
+		Limit(limit).
+		Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]models.DigestQuestion, 0, len(questions))
+	for _, question := range questions {
+		res = append(res, models.DigestQuestion{ID: question.ID, Text: question.Text, Tags: question.Tags, CreatedAt: question.CreatedAt})
+	}
+
+	return res, nil
+}
+
+func(s *Storage) SwapDigestSent(ctx context.Context, userID string, previous, next *time.Time) (bool, error) {
+	query := s.conn.WithContext(ctx).Model(&models.NotificationPreferences{}).Where("user_id = ?", userID)
+	if previous == nil {
+		query = query.Where("digest_sent_at IS NULL")
+	} else {
+		query = query.Where("digest_sent_at = ?", *previous)
+	}
+	res := query.Update("digest_sent_at", next)
+
+	return res.RowsAffected == 1, res.Error
+}
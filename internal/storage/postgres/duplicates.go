@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SimilarQuestions finds the questions, not marked as duplicates, whose text
+// has a trigram similarity of at least threshold to the text.
+func(s *Storage) SimilarQuestions(ctx context.Context, text string, threshold float64, limit int) ([]models.SimilarQuestion, error) {
+	var res []models.SimilarQuestion
+	err := s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The % operator is the one served by the trigram index; it takes
+		// the threshold from a setting, which is local to the transaction.
+		err := tx.Exec(
+			"SELECT set_config('pg_trgm.similarity_threshold', ?, true)",
+			strconv.FormatFloat(threshold, 'f', -1, 64),
+		).Error
+		if err != nil {
+			return err
+		}
+		return tx.Raw(`
+			SELECT id, text, similarity(text, ?) AS similarity FROM questions
+			WHERE text % ? AND duplicate_of IS NULL
+			ORDER BY similarity DESC, id
+			LIMIT ?`,
+			text, text, limit,
+		).Scan(&res).Error
+	})
+
+	return res, err
+}
+
+// MarkDuplicate links the question, at version unless it is 0, to the
+// question the chain of duplicates of the target ends at, and bumps its
+// version.
+func(s *Storage) MarkDuplicate(ctx context.Context, id, target, version int) (models.Question, error) {
+	var question models.Question
+	err := s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id)
+		if version != 0 {
+			query = query.Where("version = ?", version)
+		}
+		err := query.First(&question).Error
+		if err != nil {
+			return err
+		}
+		canonical, err := canonicalQuestion(tx, target, id)
+		if err != nil {
+			return err
+		}
+		target = canonical.ID
+		question.DuplicateOf = &target
+		question.Version++
+		err = tx.Model(&models.Question{}).
+			Where("id = ?", id).
+			Updates(map[string]any{"duplicate_of": target, "version": question.Version}).Error
+		if err != nil {
+			return err
+		}
+		return writeOutbox(ctx, tx, events.NewQuestionMarkedDuplicate(question))
+	})
+
+	return question, err
+}
+
+// canonicalQuestion locks the questions of the chain of duplicates from
+// target up to the one it ends at, which it returns, so the chain cannot
+// change until the transaction ends. A chain through the question id, or
+// longer than MaxDuplicateHops, is an ErrDuplicateChain.
+func canonicalQuestion(tx *gorm.DB, target, id int) (models.Question, error) {
+	for i := 0; i < models.MaxDuplicateHops; i++ {
+		if target == id {
+			return models.Question{}, models.ErrDuplicateChain
+		}
+		var question models.Question
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", target).
+			First(&question).Error
+		if err != nil || question.DuplicateOf == nil {
+			return question, err
+		}
+		target = *question.DuplicateOf
+	}
+
+	return models.Question{}, models.ErrDuplicateChain
+}
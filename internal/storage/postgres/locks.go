@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/jobs"
+)
+
+// advisoryLock is a session-level advisory lock, held by keeping the
+// connection that took it out of the pool. The pool is sized for them
+// with Reserve.
+type advisoryLock struct {
+	conn *sql.Conn
+	name string
+}
+
+// TryLock takes the advisory lock keyed by the hash of name. Postgres
+// releases it if the connection dies, so a crashed replica gives its jobs
+// up.
+func(s *Storage) TryLock(ctx context.Context, name string) (jobs.Lock, error) {
+	db, err := s.conn.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var locked bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", name).Scan(&locked)
+	if err != nil || !locked {
+		conn.Close()
+		return nil, err
+	}
+
+	return &advisoryLock{conn: conn, name: name}, nil
+}
+
+func(l *advisoryLock) Held(ctx context.Context) bool {
+	var held bool
+	err := l.conn.QueryRowContext(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM pg_locks WHERE locktype = 'advisory' AND pid = pg_backend_pid() AND objid = hashtext($1)::oid AND objsubid = 1 AND granted)",
+		l.name,
+	).Scan(&held)
+
+	return err == nil && held
+}
+
+// Release unlocks and returns the connection to the pool, or closes it
+// when the unlock fails so the lock cannot stay behind on a pooled
+// connection.
+func(l *advisoryLock) Release() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
+	defer cancel()
+	if _, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1))", l.name); err != nil {
+		l.conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	l.conn.Close()
+}
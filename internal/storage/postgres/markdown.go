@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// UnrenderedContents pages in ID order through the rows of the table,
+// questions or answers, that have no rendered HTML yet.
+func(s *Storage) UnrenderedContents(ctx context.Context, table string, afterID, limit int) ([]models.Content, error) {
+	if err := checkContentTable(table); err != nil {
+		return nil, err
+	}
+	columns := "id, text, text_html"
+	if table == "answers" {
+		columns = "id, question_id, text, text_html"
+	}
+	var res []models.Content
+	err := s.conn.WithContext(ctx).
+		Table(table).
+		Select(columns).
+		Where("text_html = '' AND id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Scan(&res).Error
+
+	return res, err
+}
+
+// SaveRenderedContents stores the rendered HTML and bumps the versions,
+// since the representation changes.
+func(s *Storage) SaveRenderedContents(ctx context.Context, table string, contents []models.Content) error {
+	if err := checkContentTable(table); err != nil {
+		return err
+	}
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, content := range contents {
+			err := tx.Table(table).
+				Where("id = ? AND text_html = ''", content.ID).
+				Updates(map[string]any{"text_html": content.TextHTML, "version": gorm.Expr("version + 1")}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func checkContentTable(table string) error {
+	if table != "questions" && table != "answers" {
+		return fmt.Errorf("unknown content table: %s", table)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MergeQuestions moves the answers of the question, at version unless it
+// is 0, to the question the chain of duplicates of the target ends at, and
+// leaves the question as a stub that redirects there. Votes stay with the
+// answers. When both questions have an accepted answer, the one of the
+// target stays accepted.
+func(s *Storage) MergeQuestions(ctx context.Context, id, target, version int, userID string) (models.QuestionsMerged, error) {
+	var res models.QuestionsMerged
+	err := s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id)
+		if version != 0 {
+			query = query.Where("version = ?", version)
+		}
+		err := query.First(&res.Merged).Error
+		if err != nil {
+			return err
+		}
+		// Two merges of a pair in opposite directions deadlock here, and
+		// the one Postgres aborts would have made a cycle.
+		res.Question, err = canonicalQuestion(tx, target, id)
+		if err != nil {
+			return err
+		}
+		target = res.Question.ID
+
+		var ids []int
+		err = tx.Model(&models.Answer{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("question_id = ?", id).
+			Order("id").
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if err := keepOneAccepted(ctx, tx, id, target, res.Merged.UserID); err != nil {
+			return err
+		}
+		if len(ids) != 0 {
+			if err := bumpQuestions(tx, target); err != nil {
+				return err
+			}
+			res.Question.Version++
+			err = tx.Model(&models.Answer{}).
+				Where("id IN ?", ids).
+				Updates(map[string]any{"question_id": target, "version": gorm.Expr("version + 1")}).Error
+			if err != nil {
+				return err
+			}
+			if err := tx.Where("id IN ?", ids).Order("id").Find(&res.Answers).Error; err != nil {
+				return err
+			}
+			answers := make([]*models.Answer, 0, len(res.Answers))
+			for i := range res.Answers {
+				answers = append(answers, &res.Answers[i])
+			}
+			if err := loadAuthors(ctx, tx, answers); err != nil {
+				return err
+			}
+		}
+
+		res.Merged.DuplicateOf = &target
+		res.Merged.Version++
+		err = tx.Model(&models.Question{}).
+			Where("id = ?", id).
+			Updates(map[string]any{"duplicate_of": target, "version": res.Merged.Version}).Error
+		if err != nil {
+			return err
+		}
+		// Duplicates of the merged question redirect straight to the target.
+		err = tx.Model(&models.Question{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("duplicate_of = ?", id).
+			Order("id").
+			Pluck("id", &res.Repointed).Error
+		if err != nil {
+			return err
+		}
+		if len(res.Repointed) != 0 {
+			err = tx.Model(&models.Question{}).
+				Where("id IN ?", res.Repointed).
+				Updates(map[string]any{"duplicate_of": target, "version": gorm.Expr("version + 1")}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		details, _ := json.Marshal(map[string]any{"target": target, "answers": ids})
+		err = writeAudit(ctx, tx, models.AuditEntry{
+			Action: models.AuditQuestionMerged,
+			UserID: userID,
+			QuestionID: id,
+			Details: details,
+		})
+		if err != nil {
+			return err
+		}
+		return writeOutbox(ctx, tx, events.NewQuestionMerged(res))
+	})
+
+	return res, err
+}
+
+// MoveAnswer moves the answer, at version unless it is 0, to the target
+// question. An accepted answer stays accepted unless the target already has
+// one.
+func(s *Storage) MoveAnswer(ctx context.Context, id, target, version int, userID string) (models.AnswerMoved, error) {
+	var res models.AnswerMoved
+	err := s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		answer, err := lockAnswer(tx, id)
+		if err != nil {
+			return err
+		}
+		if version != 0 && answer.Version != version {
+			return gorm.ErrRecordNotFound
+		}
+		res = models.AnswerMoved{Answer: answer, From: answer.QuestionID}
+		if answer.QuestionID == target {
+			return loadAuthors(ctx, tx, []*models.Answer{&res.Answer})
+		}
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", target).
+			First(&models.Question{}).Error
+		if err != nil {
+			return err
+		}
+		if answer.Accepted {
+			accepted, err := hasAccepted(tx, target)
+			if err != nil {
+				return err
+			}
+			res.Answer.Accepted = !accepted
+		}
+
+		if err := bumpQuestions(tx, res.From, target); err != nil {
+			return err
+		}
+		res.Answer.QuestionID = target
+		res.Answer.Version++
+		err = tx.Model(&models.Answer{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"question_id": target,
+				"accepted": res.Answer.Accepted,
+				"version": res.Answer.Version,
+			}).Error
+		if err != nil {
+			return err
+		}
+		if err := loadAuthors(ctx, tx, []*models.Answer{&res.Answer}); err != nil {
+			return err
+		}
+		if answer.Accepted && !res.Answer.Accepted {
+			author, err := questionAuthor(tx, res.From)
+			if err != nil {
+				return err
+			}
+			unaccepted := models.AnswerUnaccepted{Answer: res.Answer, QuestionUserID: author}
+			if err := writeOutbox(ctx, tx, events.NewAnswerUnaccepted(unaccepted)); err != nil {
+				return err
+			}
+		}
+
+		details, _ := json.Marshal(map[string]any{"from": res.From, "to": target})
+		err = writeAudit(ctx, tx, models.AuditEntry{
+			Action: models.AuditAnswerMoved,
+			UserID: userID,
+			QuestionID: res.From,
+			AnswerID: id,
+			Details: details,
+		})
+		if err != nil {
+			return err
+		}
+		return writeOutbox(ctx, tx, events.NewAnswerMoved(res))
+	})
+
+	return res, err
+}
+
+// keepOneAccepted clears the accepted answer of the question when the
+// target already has one, so moving the answers keeps one per question.
+// Its answer.unaccepted event takes the points of the acceptance back from
+// the author, unless author, the asker of the question, accepted their own.
+func keepOneAccepted(ctx context.Context, tx *gorm.DB, id, target int, author *string) error {
+	accepted, err := hasAccepted(tx, target)
+	if err != nil || !accepted {
+		return err
+	}
+	var unaccepted []models.Answer
+	err = tx.Model(&unaccepted).
+		Clauses(clause.Returning{}).
+		Where("question_id = ? AND accepted", id).
+		Update("accepted", false).Error
+	if err != nil {
+		return err
+	}
+	for _, answer := range unaccepted {
+		event := models.AnswerUnaccepted{Answer: answer}
+		if author != nil {
+			event.QuestionUserID = *author
+		}
+		if err := writeOutbox(ctx, tx, events.NewAnswerUnaccepted(event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasAccepted(tx *gorm.DB, questionID int) (bool, error) {
+	var accepted int64
+	err := tx.Model(&models.Answer{}).
+		Where("question_id = ? AND accepted", questionID).
+		Count(&accepted).Error
+	return accepted != 0, err
+}
+
+func writeAudit(ctx context.Context, tx *gorm.DB, entry models.AuditEntry) error {
+	if err := ensureUser(tx, entry.UserID); err != nil {
+		return err
+	}
+	return gorm.G[models.AuditEntry](tx).Create(ctx, &entry)
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func(s *Storage) QuestionAudience(ctx context.Context, questionID int) (string, []string, error) {
+	question, err := s.Exist(ctx, questionID)
+	if err != nil {
+		return "", nil, err
+	}
+	var followers []string
+	err = s.conn.WithContext(ctx).
+		Model(&models.QuestionFollower{}).
+		Where("question_id = ?", questionID).
+		Order("user_id").
+		Pluck("user_id", &followers).Error
+	if err != nil {
+		return "", nil, err
+	}
+	var author string
+	if question.UserID != nil {
+		author = *question.UserID
+	}
+
+	return author, followers, nil
+}
+
+func(s *Storage) NotificationPreferences(ctx context.Context, userIDs []string) ([]models.NotificationPreferences, error) {
+	return gorm.G[models.NotificationPreferences](s.conn).Where("user_id IN ?", userIDs).Find(ctx)
+}
+
+func(s *Storage) SaveNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureUser(tx, preferences.UserID); err != nil {
+			return err
+		}
+		preferences.UpdatedAt = time.Now()
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"answers", "accepted", "followed", "comments", "email", "email_verified_at", "email_token", "digest", "updated_at"}),
+		}).Create(preferences).Error
+	})
+}
+
+func(s *Storage) VerifyNotificationEmail(ctx context.Context, tokenHash string, at time.Time) (int, error) {
+	res := s.conn.WithContext(ctx).
+		Model(&models.NotificationPreferences{}).
+		Where("email_token = ? AND email_token <> ''", tokenHash).
+		Updates(map[string]any{"email_verified_at": at, "email_token": ""})
+	return int(res.RowsAffected), res.Error
+}
+
+func(s *Storage) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+	return s.conn.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&notifications).Error
+}
+
+func(s *Storage) Notifications(ctx context.Context, userID string, unread bool, page models.Page) ([]models.Notification, error) {
+	query := gorm.G[models.Notification](s.conn).Where("user_id = ?", userID)
+	if unread {
+		query = query.Where("read_at IS NULL")
+	}
+	return query.Order("id DESC").Limit(page.Limit).Offset(page.Offset).Find(ctx)
+}
+
+func(s *Storage) UnreadNotifications(ctx context.Context, userID string) (int, error) {
+	count, err := gorm.G[models.Notification](s.conn).Where("user_id = ? AND read_at IS NULL", userID).Count(ctx, "id")
+	return int(count), err
+}
+
+func(s *Storage) MarkNotificationsRead(ctx context.Context, userID string, id int64, at time.Time) (int, error) {
+	query := s.conn.WithContext(ctx).Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if id != 0 {
+		query = query.Where("id = ?", id)
+	}
+	res := query.Update("read_at", at)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	if id != 0 && res.RowsAffected == 0 {
+		// Marking a read notification again is not an error.
+		_, err := gorm.G[models.Notification](s.conn).Where("id = ? AND user_id = ?", id, userID).First(ctx)
+		return 0, err
+	}
+
+	return int(res.RowsAffected), nil
+}
+
+func(s *Storage) FollowQuestion(ctx context.Context, questionID int, userID string) error {
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+			Where("id = ?", questionID).
+			First(&models.Question{}).Error
+		if err != nil {
+			return err
+		}
+		if err := ensureUser(tx, userID); err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.QuestionFollower{QuestionID: questionID, UserID: userID}).Error
+	})
+}
+
+func(s *Storage) UnfollowQuestion(ctx context.Context, questionID int, userID string) error {
+	_, err := gorm.G[models.QuestionFollower](s.conn).
+		Where("question_id = ? AND user_id = ?", questionID, userID).
+		Delete(ctx)
+	return err
+}
+
+func(s *Storage) FollowTag(ctx context.Context, tag, userID string) error {
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureUser(tx, userID); err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.TagFollower{UserID: userID, Tag: tag}).Error
+	})
+}
+
+func(s *Storage) UnfollowTag(ctx context.Context, tag, userID string) error {
+	_, err := gorm.G[models.TagFollower](s.conn).
+		Where("user_id = ? AND tag = ?", userID, tag).
+		Delete(ctx)
+	return err
+}
+
+func(s *Storage) FollowedTags(ctx context.Context, userID string) ([]string, error) {
+	tags := make([]string, 0)
+	err := s.conn.WithContext(ctx).
+		Model(&models.TagFollower{}).
+		Where("user_id = ?", userID).
+		Order("tag").
+		Pluck("tag", &tags).Error
+	return tags, err
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// writeOutbox must be called with the transaction of the change it records.
+func writeOutbox(ctx context.Context, tx *gorm.DB, event events.Event) error {
+	row := event.Outbox()
+	return gorm.G[models.OutboxEvent](tx).Create(ctx, &row)
+}
+
+// ClaimOutbox locks a batch of pending events until now+lease and counts the
+// attempt. Replicas skip each other's rows, and an event is claimed again
+// once its lease ran out without being processed.
+func(s *Storage) ClaimOutbox(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.OutboxEvent, error) {
+	var res []models.OutboxEvent
+	err := s.conn.WithContext(ctx).Raw(`
+		UPDATE outbox SET locked_until = ?, attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE processed_at IS NULL AND failed_at IS NULL
+				AND (locked_until IS NULL OR locked_until <= ?)
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), now, limit,
+	).Scan(&res).Error
+	slices.SortFunc(res, func(a, b models.OutboxEvent) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return res, err
+}
+
+func(s *Storage) MarkOutboxProcessed(ctx context.Context, id int64) error {
+	_, err := gorm.G[models.OutboxEvent](s.conn).
+		Where("id = ?", id).
+		Update(ctx, "processed_at", time.Now())
+	return err
+}
+
+// MarkOutboxFailed dead-letters the event: it is not claimed again and stays
+// in the table, unlike processed events, for an operator to look at.
+func(s *Storage) MarkOutboxFailed(ctx context.Context, id int64) error {
+	_, err := gorm.G[models.OutboxEvent](s.conn).
+		Where("id = ?", id).
+		Update(ctx, "failed_at", time.Now())
+	return err
+}
+
+// OutboxAfter returns the events after the ID whatever their state; every
+// replica reads them to feed its live subscribers.
+func(s *Storage) OutboxAfter(ctx context.Context, id int64, limit int) ([]models.OutboxEvent, error) {
+	return gorm.G[models.OutboxEvent](s.conn).
+		Where("id > ?", id).
+		Order("id").
+		Limit(limit).
+		Find(ctx)
+}
+
+// OutboxCursor is where a starting replica reads from: before the oldest
+// pending event, or after the last one when all are processed.
+func(s *Storage) OutboxCursor(ctx context.Context) (int64, error) {
+	var res int64
+	err := s.conn.WithContext(ctx).Raw(`
+		SELECT COALESCE(
+			(SELECT MIN(id) - 1 FROM outbox WHERE processed_at IS NULL AND failed_at IS NULL),
+			(SELECT MAX(id) FROM outbox),
+			0)`,
+	).Scan(&res).Error
+
+	return res, err
+}
+
+// PurgeOutbox deletes the events processed before the time and returns how
+// many it deleted.
+func(s *Storage) PurgeOutbox(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := gorm.G[models.OutboxEvent](s.conn).
+		Where("processed_at < ?", before).
+		Delete(ctx)
+	return deleted, err
+}
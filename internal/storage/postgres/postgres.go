@@ -13,6 +13,7 @@ import (
 type Storage struct {
 	log *slog.Logger
 	conn *gorm.DB
+	poolSize int
 }
 
 func NewStorage(ctx context.Context, log *slog.Logger, cfg config.StorageConfig) *Storage {
@@ -24,10 +25,27 @@ func NewStorage(ctx context.Context, log *slog.Logger, cfg config.StorageConfig)
 	if err != nil {
 		panic(err)
 	}
-	return &Storage{
+	storage := &Storage{
 		log: log,
 		conn: conn,
+		poolSize: cfg.PoolSize,
 	}
+	storage.Reserve(0)
+
+	return storage
+}
+
+// Reserve sizes the pool for n connections kept out of it on top of
+// PoolSize, like the ones holding the advisory locks of the jobs.
+func(storage *Storage) Reserve(n int) {
+	if storage.poolSize <= 0 {
+		return
+	}
+	db, err := storage.conn.DB()
+	if err != nil {
+		return
+	}
+	db.SetMaxOpenConns(storage.poolSize + n)
 }
 
 func(storage *Storage) Shutdown(ctx context.Context) {
@@ -3,11 +3,23 @@ package postgres
 import (
 	"context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"github.com/behummble/Questions-answers/internal/events"
 	"github.com/behummble/Questions-answers/internal/models"
 )
 
 func(s *Storage) CreateQuestion(ctx context.Context, data *models.Question) error {
-	return gorm.G[models.Question](s.conn).Create(ctx, data)
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if data.UserID != nil {
+			if err := ensureUser(tx, *data.UserID); err != nil {
+				return err
+			}
+		}
+		if err := gorm.G[models.Question](tx).Create(ctx, data); err != nil {
+			return err
+		}
+		return writeOutbox(ctx, tx, events.NewQuestionCreated(*data))
+	})
 }
 
 func(s *Storage) Question(ctx context.Context, id int) (models.QuestionWithAnswers, error) {
@@ -15,7 +27,11 @@ func(s *Storage) Question(ctx context.Context, id int) (models.QuestionWithAnswe
 	if err != nil {
 		return models.QuestionWithAnswers{}, err
 	}
-	answers, err := gorm.G[models.Answer](s.conn).Where("question_id = ?", id).Find(ctx)
+	answers, err := gorm.G[models.Answer](s.conn).
+		Preload("Author", nil).
+		Where("question_id = ?", id).
+		Order("id").
+		Find(ctx)
 
 	res := models.QuestionWithAnswers{
 		Question: question,
@@ -25,14 +41,61 @@ func(s *Storage) Question(ctx context.Context, id int) (models.QuestionWithAnswe
 	return res, err
 }
 
-func(s *Storage) AllQuestions(ctx context.Context) ([]models.Question, error) {
-	return gorm.G[models.Question](s.conn).Find(ctx)
+func(s *Storage) AllQuestions(ctx context.Context, order models.QuestionSort) ([]models.Question, error) {
+	if order == models.SortViews {
+		return gorm.G[models.Question](s.conn).Order("view_count DESC, id").Find(ctx)
+	}
+	return gorm.G[models.Question](s.conn).Order("id").Find(ctx)
 }
 
-func(s *Storage) DeleteQuestion(ctx context.Context, id int) (int, error) {
-	return gorm.G[models.Question](s.conn).Where("id = ?", id).Delete(ctx)
+func(s *Storage) DeleteQuestion(ctx context.Context, id, version int) (models.QuestionDelete, error) {
+	res := models.QuestionDelete{ID: id, Version: version}
+	err := s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteQuestion(ctx, tx, &res)
+	})
+
+	return res, err
+}
+
+// deleteQuestion deletes the question of the item with its answers, whose
+// IDs it records so their cached copies can be dropped. The question is
+// locked first, so no answer is added between reading the IDs and the
+// delete.
+func deleteQuestion(ctx context.Context, tx *gorm.DB, item *models.QuestionDelete) error {
+	query := tx.Model(&models.Question{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", item.ID)
+	if item.Version != 0 {
+		query = query.Where("version = ?", item.Version)
+	}
+	var locked []int
+	if err := query.Pluck("id", &locked).Error; err != nil || len(locked) == 0 {
+		return err
+	}
+	err := tx.Model(&models.Answer{}).
+		Where("question_id = ?", item.ID).
+		Order("id").
+		Pluck("id", &item.AnswerIDs).Error
+	if err != nil {
+		return err
+	}
+	var deleted []models.Question
+	if err := tx.Clauses(clause.Returning{}).Where("id = ?", item.ID).Delete(&deleted).Error; err != nil {
+		return err
+	}
+	item.Deleted = true
+	return writeOutbox(ctx, tx, events.NewQuestionDeleted(deleted[0]))
+}
+
+// bumpQuestions raises the version of the questions whose answers are added,
+// deleted or moved, so a change made at the version a client read before
+// cannot miss the new answer set its ETag covers.
+func bumpQuestions(tx *gorm.DB, ids ...int) error {
+	return tx.Model(&models.Question{}).
+		Where("id IN ?", ids).
+		Update("version", gorm.Expr("version + 1")).Error
 }
 
 func(s *Storage) Exist(ctx context.Context, id int) (models.Question, error) {
 	return gorm.G[models.Question](s.conn).Where("id = ?", id).First(ctx)
-}
\ No newline at end of file
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// insertBatchSize is the number of rows of an INSERT of a batch, well below
+// the 65535 parameters of a Postgres statement.
+const insertBatchSize = 100
+
+// CreateQuestions creates the questions in one transaction, with an INSERT
+// for every insertBatchSize of them.
+func(s *Storage) CreateQuestions(ctx context.Context, data []*models.Question) error {
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createQuestions(ctx, tx, data)
+	})
+}
+
+// WriteQuestions creates and then deletes the questions of the batch in one
+// transaction. A delete that removes nothing rolls the batch back with
+// gorm.ErrRecordNotFound; it is the first delete not marked Deleted.
+func(s *Storage) WriteQuestions(ctx context.Context, batch *models.QuestionBatch) error {
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createQuestions(ctx, tx, batch.Create); err != nil {
+			return err
+		}
+		for i := range batch.Delete {
+			item := &batch.Delete[i]
+			if err := deleteQuestion(ctx, tx, item); err != nil {
+				return err
+			}
+			if !item.Deleted {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+}
+
+func createQuestions(ctx context.Context, tx *gorm.DB, data []*models.Question) error {
+	if len(data) == 0 {
+		return nil
+	}
+	users := make([]models.User, 0)
+	seen := make(map[string]struct{})
+	for _, question := range data {
+		if question.UserID == nil {
+			continue
+		}
+		if _, ok := seen[*question.UserID]; ok {
+			continue
+		}
+		seen[*question.UserID] = struct{}{}
+		users = append(users, models.User{ID: *question.UserID})
+	}
+	if len(users) != 0 {
+		err := tx.WithContext(ctx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			CreateInBatches(&users, insertBatchSize).Error
+		if err != nil {
+			return err
+		}
+	}
+	if err := tx.WithContext(ctx).CreateInBatches(data, insertBatchSize).Error; err != nil {
+		return err
+	}
+
+	outbox := make([]models.OutboxEvent, 0, len(data))
+	for _, question := range data {
+		outbox = append(outbox, events.NewQuestionCreated(*question).Outbox())
+	}
+	return tx.WithContext(ctx).CreateInBatches(&outbox, insertBatchSize).Error
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordReputation appends the entries to the ledger and adds their points
+// to the users. Entries already in the ledger are skipped, so an event
+// delivered twice is counted once.
+func(s *Storage) RecordReputation(ctx context.Context, entries []models.ReputationEvent) error {
+	return s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range entries {
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entries[i])
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				continue
+			}
+			err := tx.Exec(
+				"UPDATE users SET reputation = reputation + ? WHERE id = ?",
+				entries[i].Points, entries[i].UserID,
+			).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RecomputeReputation rebuilds every total from the ledger.
+func(s *Storage) RecomputeReputation(ctx context.Context) error {
+	return s.conn.WithContext(ctx).Exec(`
+		UPDATE users SET reputation = COALESCE(
+			(SELECT SUM(points) FROM reputation_events WHERE reputation_events.user_id = users.id), 0
+		)`,
+	).Error
+}
+
+func(s *Storage) Leaderboard(ctx context.Context, page models.Page) ([]models.User, error) {
+	return gorm.G[models.User](s.conn).
+		Order("reputation DESC, id").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(ctx)
+}
+
+func(s *Storage) ReputationEvents(ctx context.Context, id string, page models.Page) ([]models.ReputationEvent, error) {
+	return gorm.G[models.ReputationEvent](s.conn).
+		Where("user_id = ?", id).
+		Order("id DESC").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(ctx)
+}
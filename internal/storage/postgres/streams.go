@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// StreamQuestions passes the questions to yield one at a time as they are
+// read from the result, instead of loading them all as AllQuestions does.
+// An error of yield stops the stream and is returned.
+func(s *Storage) StreamQuestions(ctx context.Context, order models.QuestionSort, yield func(models.Question) error) error {
+	orderBy := "id"
+	if order == models.SortViews {
+		orderBy = "view_count DESC, id"
+	}
+	rows, err := s.conn.WithContext(ctx).Model(&models.Question{}).Order(orderBy).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var question models.Question
+		if err := s.conn.ScanRows(rows, &question); err != nil {
+			return err
+		}
+		if err := yield(question); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// StreamAnswers passes the answers of the question to yield as
+// StreamQuestions does. Their authors are joined in the same query: the
+// cursor holds its connection until the end, so a second query would need
+// another one from the pool.
+func(s *Storage) StreamAnswers(ctx context.Context, questionID int, yield func(models.Answer) error) error {
+	rows, err := s.conn.WithContext(ctx).
+		Model(&models.Answer{}).
+		Select(
+			"answers.id, answers.question_id, answers.user_id, answers.text, answers.text_html, " +
+			"answers.score, answers.accepted, answers.version, answers.created_at, " +
+			"users.display_name, users.avatar_url, users.updated_at",
+		).
+		Joins("JOIN users ON users.id = answers.user_id").
+		Where("answers.question_id = ?", questionID).
+		Order("answers.id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var answer models.Answer
+		var author models.Author
+		var createdAt, updatedAt sql.NullTime
+		err := rows.Scan(
+			&answer.ID,
+			&answer.QuestionID,
+			&answer.UserID,
+			&answer.Text,
+			&answer.TextHTML,
+			&answer.Score,
+			&answer.Accepted,
+			&answer.Version,
+			&createdAt,
+			&author.DisplayName,
+			&author.AvatarURL,
+			&updatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		answer.CreatedAt = createdAt.Time
+		author.ID = answer.UserID
+		author.UpdatedAt = updatedAt.Time
+		answer.Author = &author
+		if err := yield(answer); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func(s *Storage) User(ctx context.Context, id string) (models.User, error) {
+	return gorm.G[models.User](s.conn).Where("id = ?", id).First(ctx)
+}
+
+// SaveUser creates the user or replaces its profile.
+func(s *Storage) SaveUser(ctx context.Context, data *models.User) error {
+	return s.conn.WithContext(ctx).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"display_name", "bio", "avatar_url", "updated_at"}),
+			},
+			clause.Returning{},
+		).
+		Create(data).Error
+}
+
+// AuthoredAnswers returns the answers of the user with only their ID and
+// QuestionID set.
+func(s *Storage) AuthoredAnswers(ctx context.Context, id string) ([]models.Answer, error) {
+	return gorm.G[models.Answer](s.conn).
+		Select("id", "question_id").
+		Where("user_id = ?", id).
+		Find(ctx)
+}
+
+func(s *Storage) UserAnswers(ctx context.Context, id string, page models.Page) ([]models.Answer, error) {
+	return gorm.G[models.Answer](s.conn).
+		Preload("Author", nil).
+		Where("user_id = ?", id).
+		Order("id DESC").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(ctx)
+}
+
+// UserQuestions lists the questions the user asked.
+func(s *Storage) UserQuestions(ctx context.Context, id string, page models.Page) ([]models.Question, error) {
+	return gorm.G[models.Question](s.conn).
+		Where("user_id = ?", id).
+		Order("id DESC").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(ctx)
+}
+
+// ensureUsers creates an empty profile for authors seen for the first time.
+func ensureUsers(ctx context.Context, tx *gorm.DB, answers []*models.Answer) error {
+	users := make([]models.User, 0, 1)
+	seen := make(map[string]struct{})
+	for _, answer := range answers {
+		if _, ok := seen[answer.UserID]; ok {
+			continue
+		}
+		seen[answer.UserID] = struct{}{}
+		users = append(users, models.User{ID: answer.UserID})
+	}
+	return tx.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&users).Error
+}
+
+func ensureUser(tx *gorm.DB, id string) error {
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.User{ID: id}).Error
+}
+
+func loadAuthors(ctx context.Context, tx *gorm.DB, answers []*models.Answer) error {
+	ids := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		ids = append(ids, answer.UserID)
+	}
+	authors, err := gorm.G[models.Author](tx).Where("id IN ?", ids).Find(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]models.Author, len(authors))
+	for _, author := range authors {
+		byID[author.ID] = author
+	}
+	for _, answer := range answers {
+		if author, ok := byID[answer.UserID]; ok {
+			answer.Author = &author
+		}
+	}
+	return nil
+}
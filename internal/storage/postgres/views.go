@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+// AddViews adds the views of every question in one statement. The rank is
+// ln(decayed views) + weight; the exponent is clamped because EXP underflow
+// is an error in Postgres.
+func(s *Storage) AddViews(ctx context.Context, views map[int]int, weight float64) error {
+	ids := make([]int, 0, len(views))
+	for id := range views {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	values := make([]string, 0, len(ids))
+	args := []any{weight, weight}
+	for _, id := range ids {
+		values = append(values, "(?::int, ?::bigint)")
+		args = append(args, id, views[id])
+	}
+
+	return s.conn.WithContext(ctx).Exec(`
+		UPDATE questions AS q SET
+			view_count = q.view_count + v.n,
+			trending_rank = LN(EXP(GREATEST(q.trending_rank - ?, -700)) + v.n) + ?
+		FROM (VALUES `+strings.Join(values, ", ")+`) AS v(id, n)
+		WHERE q.id = v.id`,
+		args...,
+	).Error
+}
+
+func(s *Storage) TrendingQuestions(ctx context.Context, page models.Page) ([]models.Question, error) {
+	return gorm.G[models.Question](s.conn).
+		Where("view_count > 0").
+		Order("trending_rank DESC, id").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(ctx)
+}
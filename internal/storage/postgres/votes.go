@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VoteAnswer sets the vote of the user and updates the answer score. A vote
+// equal to the current one changes nothing and writes no event.
+func(s *Storage) VoteAnswer(ctx context.Context, vote *models.Vote) (models.AnswerVoted, error) {
+	var res models.AnswerVoted
+	err := s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		answer, err := lockAnswer(tx, vote.AnswerID)
+		if err != nil {
+			return err
+		}
+		current, err := gorm.G[models.Vote](tx).
+			Where("answer_id = ? AND user_id = ?", vote.AnswerID, vote.UserID).
+			First(ctx)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		res = models.AnswerVoted{Answer: answer, VoterID: vote.UserID, Value: vote.Value, Previous: current.Value}
+		if err := loadAuthors(ctx, tx, []*models.Answer{&res.Answer}); err != nil {
+			return err
+		}
+		if vote.Value == current.Value {
+			return nil
+		}
+
+		if vote.Value == 0 {
+			err = tx.Where("answer_id = ? AND user_id = ?", vote.AnswerID, vote.UserID).
+				Delete(&models.Vote{}).Error
+		} else {
+			if err := ensureUser(tx, vote.UserID); err != nil {
+				return err
+			}
+			err = tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "answer_id"}, {Name: "user_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+			}).Create(vote).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		// The score is part of the representation, so the version changes
+		// with it like with any other edit.
+		res.Answer.Score += vote.Value - current.Value
+		res.Answer.Version++
+		err = tx.Model(&models.Answer{}).
+			Where("id = ?", answer.ID).
+			Updates(map[string]any{"score": res.Answer.Score, "version": res.Answer.Version}).Error
+		if err != nil {
+			return err
+		}
+		return writeOutbox(ctx, tx, events.NewAnswerVoted(res))
+	})
+
+	return res, err
+}
+
+// AcceptAnswer marks the answer accepted and clears the previously accepted
+// answer of the question. Accepting the accepted answer again is a no-op.
+func(s *Storage) AcceptAnswer(ctx context.Context, id int) (models.AnswerAccepted, error) {
+	var res models.AnswerAccepted
+	err := s.conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		answer, err := lockAnswer(tx, id)
+		if err != nil {
+			return err
+		}
+		res.Answer = answer
+		if err := loadAuthors(ctx, tx, []*models.Answer{&res.Answer}); err != nil {
+			return err
+		}
+		if answer.Accepted {
+			return nil
+		}
+		res.QuestionUserID, err = questionAuthor(tx, answer.QuestionID)
+		if err != nil {
+			return err
+		}
+
+		var previous []models.Answer
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("question_id = ? AND accepted", answer.QuestionID).
+			Find(&previous).Error
+		if err != nil {
+			return err
+		}
+		if len(previous) != 0 {
+			res.Previous = &previous[0]
+			res.Previous.Accepted = false
+			res.Previous.Version++
+			if err := saveAccepted(tx, *res.Previous); err != nil {
+				return err
+			}
+		}
+
+		res.Answer.Accepted = true
+		res.Answer.Version++
+		if err := saveAccepted(tx, res.Answer); err != nil {
+			return err
+		}
+		return writeOutbox(ctx, tx, events.NewAnswerAccepted(res))
+	})
+
+	return res, err
+}
+
+func lockAnswer(tx *gorm.DB, id int) (models.Answer, error) {
+	var answer models.Answer
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&answer).Error
+	return answer, err
+}
+
+// questionAuthor is the user who asked the question, or "" for questions
+// asked before authors were recorded.
+func questionAuthor(tx *gorm.DB, id int) (string, error) {
+	var question models.Question
+	err := tx.Select("user_id").Where("id = ?", id).First(&question).Error
+	if err != nil || question.UserID == nil {
+		return "", err
+	}
+	return *question.UserID, nil
+}
+
+func saveAccepted(tx *gorm.DB, answer models.Answer) error {
+	return tx.Model(&models.Answer{}).
+		Where("id = ?", answer.ID).
+		Updates(map[string]any{"accepted": answer.Accepted, "version": answer.Version}).Error
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func(s *Storage) CreateWebhook(ctx context.Context, data *models.Webhook) error {
+	return gorm.G[models.Webhook](s.conn).Create(ctx, data)
+}
+
+func(s *Storage) Webhook(ctx context.Context, id int) (models.Webhook, error) {
+	return gorm.G[models.Webhook](s.conn).Where("id = ?", id).First(ctx)
+}
+
+func(s *Storage) AllWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	return gorm.G[models.Webhook](s.conn).Order("id").Find(ctx)
+}
+
+func(s *Storage) UpdateWebhook(ctx context.Context, data *models.Webhook) (int, error) {
+	res := s.conn.WithContext(ctx).
+		Model(&models.Webhook{}).
+		Where("id = ?", data.ID).
+		Select("url", "events", "active", "failure_count", "updated_at").
+		Updates(data)
+	return int(res.RowsAffected), res.Error
+}
+
+func(s *Storage) DeleteWebhook(ctx context.Context, id int) (int, error) {
+	return gorm.G[models.Webhook](s.conn).Where("id = ?", id).Delete(ctx)
+}
+
+// EnqueueDeliveries stores pending deliveries. A delivery that already
+// exists for the webhook and event is skipped, so a redelivered event does
+// not notify a webhook twice.
+func(s *Storage) EnqueueDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return s.conn.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(deliveries).Error
+}
+
+// ClaimDueDeliveries leases pending deliveries that are due by pushing their
+// next attempt forward, so other replicas skip them while they are sent.
+func(s *Storage) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var res []models.WebhookDelivery
+	err := s.conn.WithContext(ctx).Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), now, models.DeliveryPending, now, limit,
+	).Scan(&res).Error
+
+	return res, err
+}
+
+func(s *Storage) SaveDelivery(ctx context.Context, data *models.WebhookDelivery) error {
+	return s.conn.WithContext(ctx).Save(data).Error
+}
+
+func(s *Storage) Deliveries(ctx context.Context, webhookID, limit int) ([]models.WebhookDelivery, error) {
+	return gorm.G[models.WebhookDelivery](s.conn).
+		Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Limit(limit).
+		Find(ctx)
+}
+
+// RecordWebhookResult resets the consecutive failure counter on success and
+// disables the webhook once it reaches disableAfter failures.
+func(s *Storage) RecordWebhookResult(ctx context.Context, id int, success bool, disableAfter int) error {
+	if success {
+		return s.conn.WithContext(ctx).Exec(
+			"UPDATE webhooks SET failure_count = 0 WHERE id = ?", id,
+		).Error
+	}
+	return s.conn.WithContext(ctx).Exec(
+		`UPDATE webhooks SET failure_count = failure_count + 1,
+			active = active AND failure_count + 1 < ?, updated_at = NOW()
+		WHERE id = ?`,
+		disableAfter, id,
+	).Error
+}
@@ -0,0 +1,170 @@
+package views
+
+import (
+	"container/list"
+	"context"
+	"log/slog"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit = 100
+)
+
+type Storage interface {
+	// AddViews adds the counts to the questions and moves their trending
+	// rank to ln(decayed views) + weight.
+	AddViews(ctx context.Context, views map[int]int, weight float64) error
+	TrendingQuestions(ctx context.Context, page models.Page) ([]models.Question, error)
+}
+
+// Handler is called with the questions whose views a flush wrote.
+type Handler func(ctx context.Context, questionIDs []int) error
+
+type viewKey struct {
+	questionID int
+	viewer string
+}
+
+type viewEntry struct {
+	key viewKey
+	at time.Time
+}
+
+// Counter counts question views. Views are deduplicated per viewer inside
+// the window and buffered in memory; Run writes them to the storage in one
+// batch per flush interval, so serving a question never writes to the DB.
+// Views buffered when the process dies are lost. The viewers are remembered
+// oldest first, so the oldest is forgotten when MaxViewers are.
+type Counter struct {
+	log *slog.Logger
+	cfg config.ViewsConfig
+	storage Storage
+	now func() time.Time
+	mu sync.Mutex
+	seen map[viewKey]*list.Element
+	order *list.List
+	pending map[int]int
+	handlers []Handler
+}
+
+func NewCounter(log *slog.Logger, cfg config.ViewsConfig, storage Storage) *Counter {
+	return &Counter{
+		log: log,
+		cfg: cfg,
+		storage: storage,
+		now: time.Now,
+		seen: make(map[viewKey]*list.Element),
+		order: list.New(),
+		pending: make(map[int]int),
+	}
+}
+
+// Subscribe registers a handler called after every flush that wrote views.
+// It must be called before the first flush.
+func(c *Counter) Subscribe(handle Handler) {
+	c.handlers = append(c.handlers, handle)
+}
+
+// Record counts a view of the question unless the viewer already viewed it
+// within the window. When MaxViewers are remembered the oldest viewer is
+// forgotten to remember this one.
+func(c *Counter) Record(questionID int, viewer string) {
+	now := c.now()
+	key := viewKey{questionID: questionID, viewer: viewer}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.seen[key]; ok {
+		entry := elem.Value.(*viewEntry)
+		if now.Sub(entry.at) < c.cfg.Window {
+			return
+		}
+		entry.at = now
+		c.order.MoveToBack(elem)
+	} else if c.cfg.MaxViewers > 0 {
+		for c.order.Len() >= c.cfg.MaxViewers {
+			c.forget(c.order.Front())
+		}
+		c.seen[key] = c.order.PushBack(&viewEntry{key: key, at: now})
+	}
+	c.pending[questionID]++
+}
+
+func(c *Counter) forget(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.seen, elem.Value.(*viewEntry).key)
+}
+
+// Flush writes the buffered views and forgets the viewers whose window has
+// passed. Views that fail to be written are kept for the next flush.
+func(c *Counter) Flush(ctx context.Context) error {
+	now := c.now()
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[int]int)
+	for elem := c.order.Front(); elem != nil && now.Sub(elem.Value.(*viewEntry).at) >= c.cfg.Window; elem = c.order.Front() {
+		c.forget(elem)
+	}
+	c.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := c.storage.AddViews(ctx, pending, c.weight(now))
+	if err != nil {
+		c.mu.Lock()
+		for id, n := range pending {
+			c.pending[id] += n
+		}
+		c.mu.Unlock()
+		return err
+	}
+	ids := slices.Sorted(maps.Keys(pending))
+	for _, handle := range c.handlers {
+		if err := handle(ctx, ids); err != nil {
+			c.log.Error(
+				"ViewsHandlingError",
+				slog.String("component", "views"),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// Trending returns the questions with the highest view rate, where a view
+// loses half its weight every HalfLife.
+func(c *Counter) Trending(ctx context.Context, page models.Page) (models.GetQuestionsResponse, error) {
+	if page.Limit <= 0 {
+		page.Limit = defaultLimit
+	}
+	if page.Limit > maxLimit {
+		page.Limit = maxLimit
+	}
+	questions, err := c.storage.TrendingQuestions(ctx, page)
+	if err != nil {
+		c.log.Error(
+			"DB_ReadingError",
+			slog.String("component", "views"),
+			slog.Any("error", err),
+		)
+		return models.GetQuestionsResponse{}, err
+	}
+
+	return models.GetQuestionsResponse{Questions: questions}, nil
+}
+
+// weight is the decay rate times the time. Adding it to ln(views) keeps
+// ranks written at different times comparable without rewriting them.
+func(c *Counter) weight(t time.Time) float64 {
+	return math.Ln2 / c.cfg.HalfLife.Seconds() * float64(t.UnixMilli()) / 1000
+}
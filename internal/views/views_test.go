@@ -0,0 +1,173 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+type failingStorage struct {
+	mock.MockStorageViews
+	fail bool
+}
+
+func(s *failingStorage) AddViews(ctx context.Context, views map[int]int, weight float64) error {
+	if s.fail {
+		return errors.New("unavailable")
+	}
+	return s.MockStorageViews.AddViews(ctx, views, weight)
+}
+
+func TestRecordDeduplicatesInsideWindow(t *testing.T) {
+	ctx := context.Background()
+	questions, storage := newTestStorage(t, 2)
+	counter := NewCounter(slog.Default(), testConfig(), storage)
+	now := time.Date(2025, time.December, 25, 10, 0, 0, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+
+	counter.Record(1, "ip:10.0.0.1")
+	counter.Record(1, "ip:10.0.0.1")
+	counter.Record(1, "user:alice")
+	counter.Record(2, "ip:10.0.0.1")
+	counter.Flush(ctx)
+
+	now = now.Add(31 * time.Minute)
+	counter.Record(1, "ip:10.0.0.1")
+	counter.Flush(ctx)
+
+	excpected := map[int]int{1: 3, 2: 1}
+	for id, views := range excpected {
+		res, err := questions.Question(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Question.ViewCount != views {
+			t.Errorf("Excpected %d views of question %d, got %d", views, id, res.Question.ViewCount)
+		}
+	}
+	if storage.Flushes() != 2 {
+		t.Errorf("Excpected 2 batches, got %d", storage.Flushes())
+	}
+}
+
+func TestRecordForgetsOldestViewer(t *testing.T) {
+	ctx := context.Background()
+	questions, storage := newTestStorage(t, 1)
+	cfg := testConfig()
+	cfg.MaxViewers = 2
+	counter := NewCounter(slog.Default(), cfg, storage)
+	now := time.Date(2025, time.December, 25, 10, 0, 0, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+
+	for _, viewer := range []string{"ip:10.0.0.1", "ip:10.0.0.2", "ip:10.0.0.3"} {
+		counter.Record(1, viewer)
+		now = now.Add(time.Minute)
+	}
+	// 10.0.0.1 was forgotten, the others are still remembered.
+	for _, viewer := range []string{"ip:10.0.0.3", "ip:10.0.0.2", "ip:10.0.0.1"} {
+		counter.Record(1, viewer)
+	}
+	counter.Flush(ctx)
+
+	res, err := questions.Question(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Question.ViewCount != 4 {
+		t.Errorf("Excpected 4 views, got %d", res.Question.ViewCount)
+	}
+}
+
+func TestFlushKeepsViewsOnError(t *testing.T) {
+	ctx := context.Background()
+	questions, views := newTestStorage(t, 1)
+	storage := &failingStorage{MockStorageViews: *views, fail: true}
+	counter := NewCounter(slog.Default(), testConfig(), storage)
+
+	counter.Record(1, "ip:10.0.0.1")
+	counter.Flush(ctx)
+	storage.fail = false
+	counter.Flush(ctx)
+
+	res, err := questions.Question(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Question.ViewCount != 1 {
+		t.Errorf("Excpected 1 view, got %d", res.Question.ViewCount)
+	}
+}
+
+func TestFlushCallsHandlers(t *testing.T) {
+	ctx := context.Background()
+	_, storage := newTestStorage(t, 2)
+	counter := NewCounter(slog.Default(), testConfig(), storage)
+	var flushed []int
+	counter.Subscribe(func(ctx context.Context, questionIDs []int) error {
+		flushed = append(flushed, questionIDs...)
+		return nil
+	})
+
+	counter.Flush(ctx)
+	counter.Record(2, "ip:10.0.0.1")
+	counter.Record(1, "ip:10.0.0.1")
+	counter.Flush(ctx)
+
+	if len(flushed) != 2 || flushed[0] != 1 || flushed[1] != 2 {
+		t.Errorf("Excpected questions 1 and 2 once, got %v", flushed)
+	}
+}
+
+func TestTrendingPrefersRecentViews(t *testing.T) {
+	ctx := context.Background()
+	_, storage := newTestStorage(t, 2)
+	counter := NewCounter(slog.Default(), testConfig(), storage)
+	now := time.Date(2025, time.December, 25, 10, 0, 0, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+
+	// Question 1 had many views a day ago, question 2 has a few now.
+	for i := 0; i < 10; i++ {
+		counter.Record(1, "viewer" + string(rune('a' + i)))
+	}
+	counter.Flush(ctx)
+	now = now.Add(24 * time.Hour)
+	for i := 0; i < 3; i++ {
+		counter.Record(2, "viewer" + string(rune('a' + i)))
+	}
+	counter.Flush(ctx)
+
+	res, err := counter.Trending(ctx, models.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Questions) != 2 || res.Questions[0].ID != 2 {
+		t.Errorf("Excpected question 2 to trend first, got %+v", res.Questions)
+	}
+}
+
+func newTestStorage(t *testing.T, count int) (*mock.MockStorageQuestions, *mock.MockStorageViews) {
+	answers := mock.NewMockStorageAnswers(1)
+	questions := mock.NewMockStorageQuestions(count, answers)
+	for i := 0; i < count; i++ {
+		if err := questions.CreateQuestion(context.Background(), &models.Question{Text: "test"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return questions, mock.NewMockStorageViews(questions)
+}
+
+func testConfig() config.ViewsConfig {
+	return config.ViewsConfig{
+		Window: 30 * time.Minute,
+		FlushInterval: time.Second,
+		HalfLife: 6 * time.Hour,
+		MaxViewers: 100,
+	}
+}
@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var ErrForbiddenAddress = errors.New("ForbiddenAddress")
+
+// reserved are the non-public ranges netip.Addr does not classify: "this
+// network", which Linux connects to the host itself, and carrier-grade NAT.
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// NewClient returns the client deliveries are sent with. Webhook URLs are
+// chosen by users, so the client does not follow redirects and its dialer
+// refuses loopback, private and link-local addresses, including the cloud
+// metadata endpoint, whatever the host name resolves to at delivery time.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the target and defeat the check.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// control runs after the host name is resolved, right before connecting.
+func control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !public(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+
+	return nil
+}
+
+func public(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// publicHost rejects at registration the hosts that can never be delivered
+// to. Other names are checked by the dialer, as they may resolve to anything.
+func publicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return public(addr)
+	}
+
+	return true
+}
@@ -0,0 +1,229 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+// Dispatcher turns domain events into webhook deliveries and sends them.
+// Handle is subscribed to the events dispatcher and stores one pending
+// delivery per subscribed webhook; every poll sends the deliveries that are
+// due. Failed attempts are retried with exponential backoff until
+// MaxAttempts.
+type Dispatcher struct {
+	log *slog.Logger
+	cfg config.WebhooksConfig
+	storage Storage
+	client *http.Client
+	now func() time.Time
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewDispatcher(log *slog.Logger, cfg config.WebhooksConfig, storage Storage, client *http.Client) *Dispatcher {
+	if client == nil {
+		client = NewClient(cfg.Timeout)
+	}
+	return &Dispatcher{
+		log: log,
+		cfg: cfg,
+		storage: storage,
+		client: client,
+		now: time.Now,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+func(d *Dispatcher) Run(ctx context.Context) {
+	defer close(d.done)
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		d.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown stops polling and waits for the current batch to finish.
+func(d *Dispatcher) Shutdown(ctx context.Context) {
+	close(d.stop)
+	select {
+	case <-d.done:
+	case <-ctx.Done():
+	}
+}
+
+// Poll sends the deliveries that are due.
+func(d *Dispatcher) Poll(ctx context.Context) {
+	if err := d.deliver(ctx); err != nil {
+		d.logError("WebhookDeliveryError", err)
+	}
+}
+
+// Handle queues a delivery of the event for every active webhook subscribed
+// to its type. Queuing is idempotent per webhook and event.
+func(d *Dispatcher) Handle(ctx context.Context, event events.Event) error {
+	webhooks, err := d.storage.AllWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	deliveries := make([]*models.WebhookDelivery, 0)
+	for _, webhook := range webhooks {
+		if !webhook.Active || !slices.Contains(webhook.Events, event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			WebhookID: webhook.ID,
+			EventID: int64(event.ID),
+			EventType: event.Type,
+			Payload: payload,
+			Status: models.DeliveryPending,
+			NextAttemptAt: d.now(),
+		})
+	}
+
+	return d.storage.EnqueueDeliveries(ctx, deliveries)
+}
+
+func(d *Dispatcher) deliver(ctx context.Context) error {
+	// The lease outlives one attempt, so a delivery is not picked up twice
+	// unless the replica sending it dies.
+	lease := 2 * d.cfg.Timeout
+	due, err := d.storage.ClaimDueDeliveries(ctx, d.now(), lease, d.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+	webhooks := make(map[int]models.Webhook)
+	for i := range due {
+		delivery := &due[i]
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = d.storage.Webhook(ctx, delivery.WebhookID)
+			if err != nil {
+				d.logError("DB_ReadingError", err)
+				continue
+			}
+		}
+		success := d.attempt(ctx, webhook, delivery)
+		if err := d.storage.SaveDelivery(ctx, delivery); err != nil {
+			d.logError("DB_WritingError", err)
+		}
+		if !webhook.Active {
+			continue
+		}
+		err := d.storage.RecordWebhookResult(ctx, webhook.ID, success, d.cfg.DisableAfter)
+		if err != nil {
+			d.logError("DB_WritingError", err)
+		}
+		if !success {
+			webhook.FailureCount++
+			webhook.Active = webhook.FailureCount < d.cfg.DisableAfter
+			if !webhook.Active {
+				d.log.Warn(fmt.Sprintf("Disable webhook with id: %d after %d failures", webhook.ID, webhook.FailureCount))
+			}
+		} else {
+			webhook.FailureCount = 0
+		}
+		webhooks[webhook.ID] = webhook
+	}
+
+	return nil
+}
+
+// attempt sends the delivery once and updates its status and schedule.
+func(d *Dispatcher) attempt(ctx context.Context, webhook models.Webhook, delivery *models.WebhookDelivery) bool {
+	now := d.now()
+	delivery.UpdatedAt = now
+	if !webhook.Active {
+		delivery.Status = models.DeliveryFailed
+		delivery.LastError = "WebhookDisabled"
+		return false
+	}
+
+	delivery.Attempts++
+	statusCode, err := d.send(ctx, webhook, delivery)
+	delivery.LastStatusCode = statusCode
+	if err == nil {
+		delivery.Status = models.DeliverySucceeded
+		delivery.LastError = ""
+		return true
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.cfg.MaxAttempts {
+		delivery.Status = models.DeliveryFailed
+	} else {
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	}
+
+	return false
+}
+
+func(d *Dispatcher) send(ctx context.Context, webhook models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.Timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := d.now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "questions-answers-webhooks")
+	request.Header.Set(HeaderEvent, delivery.EventType)
+	request.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// backoff doubles the initial delay per failed attempt, capped at MaxBackoff.
+func(d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.InitialBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, d.cfg.MaxBackoff)
+}
+
+func(d *Dispatcher) logError(msg string, err error) {
+	d.log.Error(
+		msg,
+		slog.String("component", "webhooks"),
+		slog.Any("error", err),
+	)
+}
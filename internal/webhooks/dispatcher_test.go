@@ -0,0 +1,220 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+)
+
+type receiver struct {
+	mu sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies [][]byte
+}
+
+func(r *receiver) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body, _ := io.ReadAll(request.Body)
+	r.requests = append(r.requests, request)
+	r.bodies = append(r.bodies, body)
+	status := http.StatusOK
+	if len(r.statuses) != 0 {
+		status = r.statuses[0]
+		r.statuses = r.statuses[1:]
+	}
+	writer.WriteHeader(status)
+}
+
+func TestDispatcherDeliversSignedEvents(t *testing.T) {
+	ctx := context.Background()
+	rec := &receiver{}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	storage := mock.NewMockStorageWebhooks()
+	created := models.Webhook{URL: ts.URL, Secret: "secret", Events: []string{events.AnswerCreated}, Active: true}
+	if err := storage.CreateWebhook(ctx, &created); err != nil {
+		t.Fatal(err)
+	}
+	d := NewDispatcher(slog.Default(), testConfig(), storage, ts.Client())
+	handle(t, d, events.Event{ID: 1, Type: events.QuestionCreated, QuestionID: 1, Payload: []byte(`{}`)})
+	handle(t, d, events.Event{ID: 2, Type: events.AnswerCreated, QuestionID: 1, AnswerID: 2, Payload: []byte(`{"ID":2}`)})
+	// A redelivered event is queued once.
+	handle(t, d, events.Event{ID: 2, Type: events.AnswerCreated, QuestionID: 1, AnswerID: 2, Payload: []byte(`{"ID":2}`)})
+	d.Poll(ctx)
+
+	if len(rec.requests) != 1 {
+		t.Fatalf("Excpected 1 delivery, got %d", len(rec.requests))
+	}
+	request, body := rec.requests[0], rec.bodies[0]
+	timestamp, _ := strconv.ParseInt(request.Header.Get(HeaderTimestamp), 10, 64)
+	if !Verify(created.Secret, request.Header.Get(HeaderSignature), timestamp, body) {
+		t.Error("Excpected valid signature")
+	}
+	if request.Header.Get(HeaderEvent) != events.AnswerCreated {
+		t.Errorf("Excpected event header %s, got %s", events.AnswerCreated, request.Header.Get(HeaderEvent))
+	}
+	var event events.Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != events.AnswerCreated || event.AnswerID != 2 || event.ID != 2 {
+		t.Errorf("Excpected answer.created event 2, got %+v", event)
+	}
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	rec := &receiver{statuses: []int{http.StatusInternalServerError}}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	storage := mock.NewMockStorageWebhooks()
+	created := createWebhook(t, storage, ts.URL)
+
+	now := time.Date(2000, time.January, 1, 8, 8, 8, 8, time.UTC)
+	d := NewDispatcher(slog.Default(), testConfig(), storage, ts.Client())
+	d.now = func() time.Time { return now }
+	handle(t, d, events.Event{ID: 1, Type: events.AnswerCreated, Payload: []byte(`{}`)})
+	d.Poll(ctx)
+
+	deliveries, _ := storage.Deliveries(ctx, created.ID, 10)
+	if deliveries[0].Status != models.DeliveryPending || deliveries[0].Attempts != 1 {
+		t.Fatalf("Excpected pending delivery after 1 attempt, got %+v", deliveries[0])
+	}
+	if !deliveries[0].NextAttemptAt.Equal(now.Add(time.Second)) {
+		t.Errorf("Excpected retry after initial backoff, got %v", deliveries[0].NextAttemptAt)
+	}
+
+	d.Poll(ctx)
+	if len(rec.requests) != 1 {
+		t.Fatalf("Excpected no retry before backoff, got %d requests", len(rec.requests))
+	}
+
+	now = now.Add(time.Second)
+	d.Poll(ctx)
+	deliveries, _ = storage.Deliveries(ctx, created.ID, 10)
+	if deliveries[0].Status != models.DeliverySucceeded || deliveries[0].Attempts != 2 {
+		t.Errorf("Excpected delivery to succeed on 2nd attempt, got %+v", deliveries[0])
+	}
+}
+
+func TestDispatcherDisablesFailingWebhook(t *testing.T) {
+	ctx := context.Background()
+	rec := &receiver{statuses: []int{500, 500, 500}}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	storage := mock.NewMockStorageWebhooks()
+	created := createWebhook(t, storage, ts.URL)
+
+	cfg := testConfig()
+	cfg.DisableAfter = 2
+	d := NewDispatcher(slog.Default(), cfg, storage, ts.Client())
+	for i := 1; i <= 3; i++ {
+		handle(t, d, events.Event{ID: uint64(i), Type: events.AnswerCreated, Payload: []byte(`{}`)})
+	}
+	d.Poll(ctx)
+
+	webhook, _ := storage.Webhook(ctx, created.ID)
+	if webhook.Active {
+		t.Error("Excpected webhook to be disabled")
+	}
+	if len(rec.requests) != 2 {
+		t.Errorf("Excpected no requests after disabling, got %d", len(rec.requests))
+	}
+	deliveries, _ := storage.Deliveries(ctx, created.ID, 10)
+	if deliveries[0].Status != models.DeliveryFailed || deliveries[0].LastError != "WebhookDisabled" {
+		t.Errorf("Excpected last delivery to fail as disabled, got %+v", deliveries[0])
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	d := NewDispatcher(slog.Default(), testConfig(), nil, nil)
+
+	excpected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, delay := range excpected {
+		if got := d.backoff(i + 1); got != delay {
+			t.Errorf("Excpected backoff %v for attempt %d, got %v", delay, i+1, got)
+		}
+	}
+}
+
+func TestClientRefusesPrivateAddresses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Excpected no request to reach a loopback server")
+	}))
+	defer ts.Close()
+
+	_, err := NewClient(time.Second).Post(ts.URL, "application/json", nil)
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("Excpected ErrForbiddenAddress, got %v", err)
+	}
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	client := NewClient(time.Second)
+	// The transport is replaced only to reach the loopback test server.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hook" {
+			http.Redirect(w, r, "/internal", http.StatusFound)
+			return
+		}
+		t.Errorf("Excpected redirect to %s not to be followed", r.URL.Path)
+	}))
+	defer ts.Close()
+	client.Transport = ts.Client().Transport
+
+	res, err := client.Post(ts.URL+"/hook", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusFound {
+		t.Errorf("Excpected the redirect as response, got %v", res.StatusCode)
+	}
+}
+
+func handle(t *testing.T, d *Dispatcher, event events.Event) {
+	if err := d.Handle(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// createWebhook stores the webhook directly: the test servers listen on
+// loopback, which the manager rejects.
+func createWebhook(t *testing.T, storage Storage, url string) models.Webhook {
+	webhook := models.Webhook{URL: url, Secret: "secret", Events: slices.Clone(Types), Active: true}
+	if err := storage.CreateWebhook(context.Background(), &webhook); err != nil {
+		t.Fatal(err)
+	}
+	return webhook
+}
+
+func testConfig() config.WebhooksConfig {
+	return config.WebhooksConfig{
+		PollInterval: time.Second,
+		BatchSize: 10,
+		Timeout: time.Second,
+		MaxAttempts: 3,
+		InitialBackoff: time.Second,
+		MaxBackoff: 5 * time.Second,
+		DisableAfter: 10,
+	}
+}
@@ -0,0 +1,218 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/events"
+	"github.com/behummble/Questions-answers/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidWebhook = errors.New("InvalidWebhook")
+
+// Types lists the event types a webhook can subscribe to.
+var Types = []string{
+	events.QuestionCreated,
+	events.QuestionDeleted,
+	events.QuestionMarkedDuplicate,
+	events.QuestionMerged,
+	events.AnswerCreated,
+	events.AnswerDeleted,
+	events.AnswerVoted,
+	events.AnswerAccepted,
+	events.AnswerUnaccepted,
+	events.AnswerMoved,
+	events.CommentCreated,
+}
+
+const deliveriesLimit = 100
+
+type Storage interface {
+	CreateWebhook(ctx context.Context, data *models.Webhook) error
+	Webhook(ctx context.Context, id int) (models.Webhook, error)
+	AllWebhooks(ctx context.Context) ([]models.Webhook, error)
+	UpdateWebhook(ctx context.Context, data *models.Webhook) (int, error)
+	DeleteWebhook(ctx context.Context, id int) (int, error)
+	Deliveries(ctx context.Context, webhookID, limit int) ([]models.WebhookDelivery, error)
+
+	EnqueueDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+	SaveDelivery(ctx context.Context, data *models.WebhookDelivery) error
+	RecordWebhookResult(ctx context.Context, id int, success bool, disableAfter int) error
+}
+
+// Manager handles webhook registrations.
+type Manager struct {
+	log *slog.Logger
+	storage Storage
+}
+
+func NewManager(log *slog.Logger, storage Storage) *Manager {
+	return &Manager{
+		log: log,
+		storage: storage,
+	}
+}
+
+func(m *Manager) CreateWebhook(ctx context.Context, data []byte) (models.CreateWebhookResponse, error) {
+	var request models.CreateWebhookRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return models.CreateWebhookResponse{}, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	eventTypes, err := validate(request.URL, request.Events)
+	if err != nil {
+		return models.CreateWebhookResponse{}, err
+	}
+	secret := request.Secret
+	if secret == "" {
+		secret, err = newSecret()
+		if err != nil {
+			return models.CreateWebhookResponse{}, err
+		}
+	}
+
+	webhook := models.Webhook{
+		URL: request.URL,
+		Secret: secret,
+		Events: eventTypes,
+		Active: true,
+	}
+	if err := m.storage.CreateWebhook(ctx, &webhook); err != nil {
+		m.logDBError("DB_WritingError", err)
+		return models.CreateWebhookResponse{}, errors.New("DB_WritingError")
+	}
+	m.log.Info(fmt.Sprintf("Create webhook with id: %d", webhook.ID))
+
+	return models.CreateWebhookResponse{Webhook: webhook, Secret: secret}, nil
+}
+
+func(m *Manager) Webhook(ctx context.Context, id int) (models.Webhook, error) {
+	webhook, err := m.storage.Webhook(ctx, id)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		m.logDBError("DB_ReadingError", err)
+	}
+
+	return webhook, err
+}
+
+func(m *Manager) AllWebhooks(ctx context.Context) (models.GetWebhooksResponse, error) {
+	webhooks, err := m.storage.AllWebhooks(ctx)
+	if err != nil {
+		m.logDBError("DB_ReadingError", err)
+		return models.GetWebhooksResponse{}, err
+	}
+
+	return models.GetWebhooksResponse{Webhooks: webhooks}, nil
+}
+
+// UpdateWebhook replaces URL, events and the active flag. Re-activating a
+// webhook clears its failure counter.
+func(m *Manager) UpdateWebhook(ctx context.Context, id int, data []byte) (models.Webhook, error) {
+	var request models.UpdateWebhookRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return models.Webhook{}, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	eventTypes, err := validate(request.URL, request.Events)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	webhook, err := m.Webhook(ctx, id)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	if request.Active && !webhook.Active {
+		webhook.FailureCount = 0
+	}
+	webhook.URL = request.URL
+	webhook.Events = eventTypes
+	webhook.Active = request.Active
+	webhook.UpdatedAt = time.Now()
+
+	rows, err := m.storage.UpdateWebhook(ctx, &webhook)
+	if err != nil {
+		m.logDBError("DB_WritingError", err)
+		return models.Webhook{}, errors.New("DB_WritingError")
+	}
+	if rows == 0 {
+		return models.Webhook{}, gorm.ErrRecordNotFound
+	}
+	m.log.Info(fmt.Sprintf("Update webhook with id: %d", id))
+
+	return webhook, nil
+}
+
+func(m *Manager) DeleteWebhook(ctx context.Context, id int) error {
+	rows, err := m.storage.DeleteWebhook(ctx, id)
+	if err != nil {
+		m.logDBError("DB_DeletingError", err)
+		return errors.New("DB_DeletingError")
+	}
+	if rows == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	m.log.Info(fmt.Sprintf("Delete webhook with id: %d", id))
+
+	return nil
+}
+
+// Deliveries returns the most recent deliveries of the webhook, newest first.
+func(m *Manager) Deliveries(ctx context.Context, id int) (models.GetWebhookDeliveriesResponse, error) {
+	if _, err := m.Webhook(ctx, id); err != nil {
+		return models.GetWebhookDeliveriesResponse{}, err
+	}
+	deliveries, err := m.storage.Deliveries(ctx, id, deliveriesLimit)
+	if err != nil {
+		m.logDBError("DB_ReadingError", err)
+		return models.GetWebhookDeliveriesResponse{}, err
+	}
+
+	return models.GetWebhookDeliveriesResponse{Deliveries: deliveries}, nil
+}
+
+func(m *Manager) logDBError(msg string, err error) {
+	m.log.Error(
+		msg,
+		slog.String("component", "db"),
+		slog.Any("error", err),
+	)
+}
+
+// validate checks the target URL and returns the event types to subscribe
+// to; an empty list subscribes to all of them.
+func validate(rawURL string, eventTypes []string) ([]string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidWebhook)
+	}
+	if !publicHost(parsed.Hostname()) {
+		return nil, fmt.Errorf("%w: url must not point to a loopback, private or link-local host", ErrInvalidWebhook)
+	}
+	if len(eventTypes) == 0 {
+		return slices.Clone(Types), nil
+	}
+	for _, eventType := range eventTypes {
+		if !slices.Contains(Types, eventType) {
+			return nil, fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, eventType)
+		}
+	}
+
+	return eventTypes, nil
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
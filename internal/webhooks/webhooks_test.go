@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateWebhookGeneratesSecret(t *testing.T) {
+	manager := NewManager(slog.Default(), mock.NewMockStorageWebhooks())
+
+	res, err := manager.CreateWebhook(context.Background(), []byte(`{"URL":"https://example.com/hook"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Secret) != 64 || res.Webhook.Secret != res.Secret {
+		t.Errorf("Excpected generated 32 byte secret, got %q", res.Secret)
+	}
+	if len(res.Webhook.Events) != len(Types) || !res.Webhook.Active {
+		t.Errorf("Excpected active webhook for all events, got %+v", res.Webhook)
+	}
+}
+
+func TestCreateWebhookWithInvalidData(t *testing.T) {
+	manager := NewManager(slog.Default(), mock.NewMockStorageWebhooks())
+
+	for _, raw := range []string{
+		`{"URL":"ftp://example.com"}`,
+		`{"URL":"/relative"}`,
+		`{"URL":"https://example.com","Events":["question.accepted"]}`,
+		`{"URL":"http://localhost:8080/hook"}`,
+		`{"URL":"http://127.0.0.1/hook"}`,
+		`{"URL":"http://10.0.0.5/hook"}`,
+		`{"URL":"http://169.254.169.254/latest/meta-data"}`,
+		`{"URL":"http://[::1]/hook"}`,
+		`not json`,
+	} {
+		_, err := manager.CreateWebhook(context.Background(), []byte(raw))
+		if !errors.Is(err, ErrInvalidWebhook) {
+			t.Errorf("Excpected ErrInvalidWebhook for %s, got %v", raw, err)
+		}
+	}
+}
+
+func TestUpdateWebhookReactivates(t *testing.T) {
+	ctx := context.Background()
+	storage := mock.NewMockStorageWebhooks()
+	manager := NewManager(slog.Default(), storage)
+	created := createWebhook(t, storage, "https://example.com/hook")
+	storage.RecordWebhookResult(ctx, created.ID, false, 1)
+
+	res, err := manager.UpdateWebhook(ctx, created.ID, []byte(`{"URL":"https://example.com/new","Active":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Active || res.FailureCount != 0 || res.URL != "https://example.com/new" {
+		t.Errorf("Excpected reactivated webhook, got %+v", res)
+	}
+
+	_, err = manager.UpdateWebhook(ctx, 42, []byte(`{"URL":"https://example.com"}`))
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Excpected ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestSignatureRoundTrip(t *testing.T) {
+	body := []byte(`{"type":"answer.created"}`)
+	signature := Sign("secret", 946714088, body)
+
+	if !Verify("secret", signature, 946714088, body) {
+		t.Error("Excpected signature to verify")
+	}
+	if Verify("secret", signature, 946714089, body) {
+		t.Error("Excpected signature with other timestamp to fail")
+	}
+	if Verify("other", signature, 946714088, body) {
+		t.Error("Excpected signature with other secret to fail")
+	}
+}
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE answers DROP COLUMN IF EXISTS version;
+ALTER TABLE questions DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
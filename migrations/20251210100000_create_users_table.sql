@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    display_name TEXT NOT NULL DEFAULT '',
+    bio TEXT NOT NULL DEFAULT '',
+    avatar_url TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+INSERT INTO users (id, created_at, updated_at)
+SELECT user_id, MIN(created_at), MIN(created_at) FROM answers GROUP BY user_id
+ON CONFLICT DO NOTHING;
+ALTER TABLE answers ADD CONSTRAINT answers_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE answers DROP CONSTRAINT IF EXISTS answers_user_id_fkey;
+DROP TABLE IF EXISTS users;
+-- +goose StatementEnd
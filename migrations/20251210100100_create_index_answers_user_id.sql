@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+-- +goose StatementBegin
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_answers_user_id ON answers (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose NO TRANSACTION
+-- +goose StatementBegin
+DROP INDEX CONCURRENTLY IF EXISTS idx_answers_user_id;
+-- +goose StatementEnd
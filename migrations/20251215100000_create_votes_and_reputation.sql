@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS accepted BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_answers_accepted ON answers (question_id) WHERE accepted;
+CREATE TABLE IF NOT EXISTS votes (
+    answer_id INTEGER NOT NULL REFERENCES answers(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    value SMALLINT NOT NULL CHECK (value IN (-1, 1)),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (answer_id, user_id)
+);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS reputation INTEGER NOT NULL DEFAULT 0;
+CREATE TABLE IF NOT EXISTS reputation_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    event_id BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    points INTEGER NOT NULL,
+    answer_id INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (event_id, user_id, reason)
+);
+CREATE INDEX IF NOT EXISTS idx_reputation_events_user_id ON reputation_events (user_id, id);
+CREATE INDEX IF NOT EXISTS idx_users_reputation ON users (reputation DESC, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reputation_events;
+ALTER TABLE users DROP COLUMN IF EXISTS reputation;
+DROP TABLE IF EXISTS votes;
+DROP INDEX IF EXISTS idx_answers_accepted;
+ALTER TABLE answers DROP COLUMN IF EXISTS accepted;
+ALTER TABLE answers DROP COLUMN IF EXISTS score;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS view_count BIGINT NOT NULL DEFAULT 0;
+-- trending_rank is ln(decayed views) plus the decay rate times the time of
+-- the last update, so ranks written at different times compare directly.
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS trending_rank DOUBLE PRECISION NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_questions_view_count ON questions (view_count DESC, id);
+CREATE INDEX IF NOT EXISTS idx_questions_trending_rank ON questions (trending_rank DESC, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_questions_trending_rank;
+DROP INDEX IF EXISTS idx_questions_view_count;
+ALTER TABLE questions DROP COLUMN IF EXISTS trending_rank;
+ALTER TABLE questions DROP COLUMN IF EXISTS view_count;
+-- +goose StatementEnd
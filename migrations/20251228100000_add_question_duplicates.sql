@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_questions_text_trgm ON questions USING GIN (text gin_trgm_ops);
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS duplicate_of INTEGER REFERENCES questions(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE questions DROP COLUMN IF EXISTS duplicate_of;
+DROP INDEX IF EXISTS idx_questions_text_trgm;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS text_html TEXT NOT NULL DEFAULT '';
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS text_html TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE answers DROP COLUMN IF EXISTS text_html;
+ALTER TABLE questions DROP COLUMN IF EXISTS text_html;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleting the parent leaves the row with neither parent set; the blob is
+-- deleted before the row.
+CREATE TABLE IF NOT EXISTS attachments (
+    id SERIAL PRIMARY KEY,
+    question_id INTEGER REFERENCES questions(id) ON DELETE SET NULL,
+    answer_id INTEGER REFERENCES answers(id) ON DELETE SET NULL,
+    user_id UUID NOT NULL REFERENCES users(id),
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    blob_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (question_id IS NULL OR answer_id IS NULL)
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_question_id ON attachments (question_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_answer_id ON attachments (answer_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_orphaned ON attachments (id) WHERE question_id IS NULL AND answer_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attachments;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id);
+
+CREATE TABLE IF NOT EXISTS question_followers (
+    question_id INTEGER NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (question_id, user_id)
+);
+
+-- event_id makes a redelivered event write each notification once.
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    type TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    question_id INTEGER NOT NULL DEFAULT 0,
+    answer_id INTEGER NOT NULL DEFAULT 0,
+    actor_id UUID,
+    event_id BIGINT NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (user_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+    answers BOOLEAN NOT NULL DEFAULT TRUE,
+    accepted BOOLEAN NOT NULL DEFAULT TRUE,
+    followed BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS question_followers;
+ALTER TABLE questions DROP COLUMN IF EXISTS user_id;
+-- +goose StatementEnd
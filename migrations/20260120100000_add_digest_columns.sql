@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notification_preferences
+    ADD COLUMN IF NOT EXISTS email TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS digest TEXT NOT NULL DEFAULT 'off',
+    ADD COLUMN IF NOT EXISTS digest_sent_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_digest
+    ON notification_preferences (digest, digest_sent_at) WHERE digest <> 'off';
+CREATE INDEX IF NOT EXISTS idx_question_followers_user_id ON question_followers (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_question_followers_user_id;
+DROP INDEX IF EXISTS idx_notification_preferences_digest;
+ALTER TABLE notification_preferences
+    DROP COLUMN IF EXISTS digest_sent_at,
+    DROP COLUMN IF EXISTS digest,
+    DROP COLUMN IF EXISTS email;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP WITH TIME ZONE;
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (id) WHERE processed_at IS NULL AND failed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (id) WHERE processed_at IS NULL;
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS failed_at;
+-- +goose StatementEnd
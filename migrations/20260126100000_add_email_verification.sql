@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notification_preferences
+    ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS email_token TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_email_token
+    ON notification_preferences (email_token) WHERE email_token <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_notification_preferences_email_token;
+ALTER TABLE notification_preferences
+    DROP COLUMN IF EXISTS email_token,
+    DROP COLUMN IF EXISTS email_verified_at;
+-- +goose StatementEnd
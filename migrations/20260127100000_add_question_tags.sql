@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]';
+CREATE INDEX IF NOT EXISTS idx_questions_tags ON questions USING GIN (tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_questions_tags;
+ALTER TABLE questions DROP COLUMN IF EXISTS tags;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tag_followers (
+    user_id UUID NOT NULL REFERENCES users(id),
+    tag TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, tag)
+);
+CREATE INDEX IF NOT EXISTS idx_questions_created_at ON questions (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_questions_created_at;
+DROP TABLE IF EXISTS tag_followers;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A comment belongs to exactly one question or answer and goes with it.
+CREATE TABLE IF NOT EXISTS comments (
+    id SERIAL PRIMARY KEY,
+    question_id INTEGER REFERENCES questions(id) ON DELETE CASCADE,
+    answer_id INTEGER REFERENCES answers(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    text TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK ((question_id IS NULL) <> (answer_id IS NULL))
+);
+CREATE INDEX IF NOT EXISTS idx_comments_question_id ON comments (question_id, id);
+CREATE INDEX IF NOT EXISTS idx_comments_answer_id ON comments (answer_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS comments;
+-- +goose StatementEnd
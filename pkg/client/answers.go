@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+
+	"github.com/behummble/Questions-answers/pkg/qaclient"
+)
+
+// CreateAnswers answers the question with each of texts, as the user of
+// WithUser.
+func(c *Client) CreateAnswers(ctx context.Context, questionID int, texts ...string) ([]Answer, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Answers []Answer `json:"answers"`
+	}
+	body := qaclient.CreateAnswerRequest{UserId: user.String(), Texts: texts}
+	if err := call(c.api.CreateAnswer(ctx, questionID, body)).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Answers, nil
+}
+
+func(c *Client) GetAnswer(ctx context.Context, id int) (*Answer, error) {
+	var res struct {
+		Answer Answer `json:"answer"`
+	}
+	r := call(c.api.GetAnswer(ctx, id, nil))
+	if err := r.into(&res); err != nil {
+		return nil, err
+	}
+	res.Answer.ETag = r.res.Header.Get("ETag")
+	return &res.Answer, nil
+}
+
+// DeleteAnswer deletes the answer if it is unchanged since the ETag of
+// GetAnswer, and fails with ErrPreconditionFailed otherwise.
+func(c *Client) DeleteAnswer(ctx context.Context, id int, etag string) error {
+	return call(c.api.DeleteAnswer(ctx, id, &qaclient.DeleteAnswerParams{IfMatch: etag})).check()
+}
+
+// MoveAnswer moves the answer to the question questionID, as the
+// moderator of WithUser, if the answer is unchanged since the ETag of
+// GetAnswer. It needs the admin token of WithToken.
+func(c *Client) MoveAnswer(ctx context.Context, id, questionID int, etag string) (*Answer, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Answer Answer `json:"answer"`
+	}
+	if err := call(c.api.PatchAnswer(ctx, id, &qaclient.PatchAnswerParams{IfMatch: etag}, qaclient.PatchAnswerRequest{UserId: user, QuestionId: questionID})).into(&res); err != nil {
+		return nil, err
+	}
+	return &res.Answer, nil
+}
+
+// Vote up votes the answer with 1, down votes it with -1 and withdraws the
+// vote with 0, as the user of WithUser.
+func(c *Client) Vote(ctx context.Context, answerID, value int) (*Vote, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res Vote
+	body := qaclient.VoteRequest{UserId: user, Value: qaclient.VoteRequestValue(value)}
+	if err := call(c.api.VoteAnswer(ctx, answerID, body)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AcceptAnswer marks the answer as the accepted one of its question, which
+// the user of WithUser must have asked.
+func(c *Client) AcceptAnswer(ctx context.Context, id int) (*Answer, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Answer Answer `json:"answer"`
+	}
+	if err := call(c.api.AcceptAnswer(ctx, id, &qaclient.AcceptAnswerParams{XUserID: user})).into(&res); err != nil {
+		return nil, err
+	}
+	return &res.Answer, nil
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/behummble/Questions-answers/pkg/qaclient"
+)
+
+// UploadQuestionAttachment attaches the file to the question, as the user
+// of WithUser. The server checks its type from its content.
+func(c *Client) UploadQuestionAttachment(ctx context.Context, questionID int, filename string, file io.Reader) (*Attachment, error) {
+	contentType, body, err := c.attachmentForm(filename, file)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Attachment Attachment `json:"attachment"`
+	}
+	if err := call(c.api.CreateQuestionAttachmentWithBody(ctx, questionID, contentType, body)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res.Attachment, nil
+}
+
+// UploadAnswerAttachment attaches the file to the answer, as the user of
+// WithUser.
+func(c *Client) UploadAnswerAttachment(ctx context.Context, answerID int, filename string, file io.Reader) (*Attachment, error) {
+	contentType, body, err := c.attachmentForm(filename, file)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Attachment Attachment `json:"attachment"`
+	}
+	if err := call(c.api.CreateAnswerAttachmentWithBody(ctx, answerID, contentType, body)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res.Attachment, nil
+}
+
+func(c *Client) QuestionAttachments(ctx context.Context, questionID int) ([]Attachment, error) {
+	var res struct {
+		Attachments []Attachment `json:"attachments"`
+	}
+	if err := call(c.api.GetQuestionAttachments(ctx, questionID)).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Attachments, nil
+}
+
+func(c *Client) AnswerAttachments(ctx context.Context, answerID int) ([]Attachment, error) {
+	var res struct {
+		Attachments []Attachment `json:"attachments"`
+	}
+	if err := call(c.api.GetAnswerAttachments(ctx, answerID)).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Attachments, nil
+}
+
+// DownloadAttachment opens the file of an attachment through its signed
+// URL. The caller closes it.
+func(c *Client) DownloadAttachment(ctx context.Context, attachment Attachment) (io.ReadCloser, error) {
+	link, err := url.Parse(attachment.URL)
+	if err != nil {
+		return nil, fmt.Errorf("client: attachment URL %q: %w", attachment.URL, err)
+	}
+	expires, err := strconv.Atoi(link.Query().Get("expires"))
+	if err != nil {
+		return nil, fmt.Errorf("client: attachment URL %q has no expiry", attachment.URL)
+	}
+	params := &qaclient.DownloadAttachmentParams{Expires: expires, Signature: link.Query().Get("signature")}
+
+	res, err := c.api.DownloadAttachment(ctx, attachment.ID, params)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		defer res.Body.Close()
+		return nil, newError(res)
+	}
+	return res.Body, nil
+}
+
+// attachmentForm is the multipart form of an upload. The file is read into
+// memory, so the request can be retried.
+func(c *Client) attachmentForm(filename string, file io.Reader) (string, io.Reader, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return "", nil, err
+	}
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("user_id", user.String()); err != nil {
+		return "", nil, err
+	}
+	part, err := form.CreateFormFile("file", strings.ReplaceAll(filename, `"`, ""))
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return "", nil, err
+	}
+	if err := form.Close(); err != nil {
+		return "", nil, err
+	}
+	return form.FormDataContentType(), &body, nil
+}
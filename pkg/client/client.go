@@ -0,0 +1,208 @@
+// Package client is the Go SDK of the Questions and Answers API. It calls
+// /v1 through the generated pkg/qaclient and adds what the generated client
+// leaves to its callers: plain types, the identity of the caller, retries,
+// iterators over the paginated listings and errors to match with errors.Is.
+//
+//	c, err := client.New("http://localhost:8080", client.WithUser(userID))
+//	question, err := c.CreateQuestion(ctx, client.CreateQuestionInput{Text: "What is Go?"})
+//	for answer, err := range c.UserAnswers(ctx, userID) {
+//		...
+//	}
+//
+// clienttest serves the API in memory for the tests of its consumers.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/behummble/Questions-answers/pkg/qaclient"
+)
+
+// RetryPolicy says how requests failing with a network error, 429 or a 5xx
+// are retried. Only the idempotent ones are: GET, PUT and DELETE, never a
+// POST, which the server may have applied before failing.
+type RetryPolicy = qaclient.RetryPolicy
+
+// DefaultRetryPolicy is used unless WithRetryPolicy is given.
+var DefaultRetryPolicy = qaclient.DefaultRetryPolicy
+
+// DefaultPageSize is the page size of the iterators unless WithPageSize is
+// given.
+const DefaultPageSize = 50
+
+type Client struct {
+	api *qaclient.Client
+	user *uuid.UUID
+	pageSize int
+}
+
+type options struct {
+	httpClient *http.Client
+	token string
+	user string
+	retry RetryPolicy
+	pageSize int
+	userAgent string
+}
+
+type Option func(*options)
+
+// WithHTTPClient sends the requests with httpClient instead of a default
+// http.Client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithToken sends token as a bearer token, as the admin endpoints require.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithUser acts as the user with the UUID id: it is the author of the
+// questions, answers and votes of the client and the owner of the
+// notifications it reads.
+func WithUser(id string) Option {
+	return func(o *options) {
+		o.user = id
+	}
+}
+
+// WithRetryPolicy replaces the DefaultRetryPolicy, MaxAttempts 1 disables
+// the retries.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithPageSize sets how many items the iterators fetch per request, at
+// most 100.
+func WithPageSize(size int) Option {
+	return func(o *options) {
+		o.pageSize = size
+	}
+}
+
+// WithUserAgent names the consumer in the User-Agent of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// New returns a client of the server at baseURL, for example
+// http://localhost:8080, without the /v1 of the version.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	o := options{retry: DefaultRetryPolicy, pageSize: DefaultPageSize, userAgent: "qa-go-client"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.pageSize <= 0 || o.pageSize > 100 {
+		return nil, fmt.Errorf("client: page size %d is not within 1 and 100", o.pageSize)
+	}
+
+	c := &Client{pageSize: o.pageSize}
+	if o.user != "" {
+		user, err := parseUser(o.user)
+		if err != nil {
+			return nil, err
+		}
+		c.user = &user
+	}
+
+	apiOpts := []qaclient.ClientOption{
+		qaclient.WithRequestEditorFn(func(ctx context.Context, request *http.Request) error {
+			request.Header.Set("User-Agent", o.userAgent)
+			if o.token != "" {
+				request.Header.Set("Authorization", "Bearer " + o.token)
+			}
+			return nil
+		}),
+	}
+	if o.httpClient != nil {
+		apiOpts = append(apiOpts, qaclient.WithHTTPClient(o.httpClient))
+	}
+	apiOpts = append(apiOpts, qaclient.WithRetries(o.retry))
+
+	api, err := qaclient.NewClient(strings.TrimSuffix(baseURL, "/") + "/v1", apiOpts...)
+	if err != nil {
+		return nil, err
+	}
+	c.api = api
+
+	return c, nil
+}
+
+// currentUser is the user of WithUser, which the endpoints acting for a
+// user require.
+func(c *Client) currentUser() (uuid.UUID, error) {
+	if c.user == nil {
+		return uuid.UUID{}, fmt.Errorf("client: no WithUser: %w", ErrUserRequired)
+	}
+	return *c.user, nil
+}
+
+// result is a response of the API, or the error of its request.
+type result struct {
+	res *http.Response
+	err error
+}
+
+func call(res *http.Response, err error) result {
+	return result{res: res, err: err}
+}
+
+// into reads the JSON body of a successful response into out, and turns
+// the others into an *Error.
+func(r result) into(out any) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.res.Body.Close()
+	if r.res.StatusCode >= http.StatusBadRequest {
+		return newError(r.res)
+	}
+	if out == nil {
+		io.Copy(io.Discard, r.res.Body)
+		return nil
+	}
+	if err := json.NewDecoder(r.res.Body).Decode(out); err != nil {
+		return fmt.Errorf("client: decoding the response of %s %s: %w", r.res.Request.Method, r.res.Request.URL.Path, err)
+	}
+	return nil
+}
+
+// check is into for the responses without a body.
+func(r result) check() error {
+	return r.into(nil)
+}
+
+// jsonBody encodes the body of a request. The generated request bodies are
+// not used for the updates, whose unset fields must be left out.
+func jsonBody(value any) (io.Reader, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+func parseUser(id string) (uuid.UUID, error) {
+	user, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("client: user %q: %w", id, ErrInvalidUser)
+	}
+	return user, nil
+}
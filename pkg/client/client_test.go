@@ -0,0 +1,360 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
+	serv "github.com/behummble/Questions-answers/internal/handlers/http"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/models"
+	"github.com/behummble/Questions-answers/internal/service"
+	"github.com/behummble/Questions-answers/pkg/client"
+	"github.com/behummble/Questions-answers/pkg/client/clienttest"
+)
+
+const (
+	author = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	voter = "9b2e4c1a-7d3f-4e8b-a6c5-1f0d2e3b4a59"
+)
+
+type staticJobs []models.JobStatus
+
+func(j staticJobs) Jobs() []models.JobStatus {
+	return j
+}
+
+func newServer(t *testing.T, cfg *config.ServerConfig, jobs serv.Jobs) *httptest.Server {
+	answers := mock.NewMockStorageAnswers(10)
+	questions := mock.NewMockStorageQuestions(10, answers)
+	svc := service.NewService(slog.Default(), questions, answers, mock.NewMockStorageUsers(questions), mock.NewMockStorageComments(questions), config.ReputationConfig{}, config.DuplicatesConfig{}, nil)
+	s := serv.NewServer(context.Background(), slog.Default(), cfg, svc, serv.Deps{Events: events.NewHub(16, 16), Jobs: jobs})
+	ts := httptest.NewServer(s.GetHandler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newClient(t *testing.T, url string, opts ...client.Option) *client.Client {
+	c, err := client.New(url, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClientAgainstServer(t *testing.T) {
+	ts := newServer(t, &config.ServerConfig{}, nil)
+	c := newClient(t, ts.URL, client.WithUser(author), client.WithPageSize(2))
+	ctx := context.Background()
+
+	created, err := c.CreateQuestion(ctx, client.CreateQuestionInput{Text: "What is Go?", Tags: []string{"Go"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Question.UserID != author || created.Question.CreatedAt.IsZero() {
+		t.Fatalf("Excpected the question of the author, got %+v", created.Question)
+	}
+	if len(created.Question.Tags) != 1 || created.Question.Tags[0] != "go" {
+		t.Errorf("Excpected the tag go, got %v", created.Question.Tags)
+	}
+	id := created.Question.ID
+
+	answers, err := c.CreateAnswers(ctx, id, "A language", "A game", "A verb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) != 3 || answers[0].QuestionID != id {
+		t.Fatalf("Excpected 3 answers to the question, got %+v", answers)
+	}
+
+	res, err := c.GetQuestion(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Question.Text != "What is Go?" || len(res.Answers) != 3 {
+		t.Errorf("Excpected the question with its answers, got %+v", res)
+	}
+
+	vote, err := newClient(t, ts.URL, client.WithUser(voter)).Vote(ctx, answers[0].ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vote.Answer.Score != 1 {
+		t.Errorf("Excpected a score of 1, got %d", vote.Answer.Score)
+	}
+	profile, err := c.GetUser(ctx, author)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.User.ID != author || profile.Privileges == nil {
+		t.Errorf("Excpected the profile of the author, got %+v", profile)
+	}
+
+	// Three answers take two pages of 2.
+	var listed []int
+	for answer, err := range c.UserAnswers(ctx, author) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed = append(listed, answer.ID)
+	}
+	if len(listed) != 3 {
+		t.Errorf("Excpected the 3 answers of the author, got %v", listed)
+	}
+
+	for _, err := range c.UserAnswers(ctx, "not-a-uuid") {
+		if !errors.Is(err, client.ErrInvalidUser) {
+			t.Errorf("Excpected ErrInvalidUser, got %v", err)
+		}
+	}
+
+	// The vote changed the answers since res was read.
+	if err := c.DeleteQuestion(ctx, id, res.ETag); !errors.Is(err, client.ErrPreconditionFailed) {
+		t.Errorf("Excpected ErrPreconditionFailed, got %v", err)
+	}
+	answer, err := c.GetAnswer(ctx, answers[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteAnswer(ctx, answer.ID, answer.ETag); err != nil {
+		t.Errorf("Excpected the answer to be deleted, got %v", err)
+	}
+}
+
+func TestClientComments(t *testing.T) {
+	ts := newServer(t, &config.ServerConfig{}, nil)
+	c := newClient(t, ts.URL, client.WithUser(author), client.WithPageSize(2))
+	ctx := context.Background()
+
+	created, err := c.CreateQuestion(ctx, client.CreateQuestionInput{Text: "What is Go?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	answers, err := c.CreateAnswers(ctx, created.Question.ID, "A language")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int
+	for _, text := range []string{"Which one?", "The one from Google?", "Thanks"} {
+		comment, err := c.CommentAnswer(ctx, answers[0].ID, text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if comment.AnswerID == nil || *comment.AnswerID != answers[0].ID || comment.QuestionID != nil {
+			t.Fatalf("Excpected a comment on the answer, got %+v", comment)
+		}
+		ids = append(ids, comment.ID)
+	}
+	if _, err := c.CommentQuestion(ctx, created.Question.ID, ""); !errors.Is(err, client.ErrInvalidComment) {
+		t.Errorf("Excpected ErrInvalidComment, got %v", err)
+	}
+
+	if err := c.DeleteComment(ctx, ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	// The two comments left take one page of 2.
+	var listed []int
+	for comment, err := range c.AnswerComments(ctx, answers[0].ID) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed = append(listed, comment.ID)
+	}
+	if len(listed) != 2 || listed[0] != ids[0] || listed[1] != ids[2] {
+		t.Errorf("Excpected the comments %d and %d, got %v", ids[0], ids[2], listed)
+	}
+	for _, err := range c.QuestionComments(ctx, 404) {
+		if !errors.Is(err, client.ErrNotFound) {
+			t.Errorf("Excpected ErrNotFound, got %v", err)
+		}
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	ts := newServer(t, &config.ServerConfig{}, nil)
+	c := newClient(t, ts.URL, client.WithUser(author))
+	ctx := context.Background()
+
+	_, err := c.GetQuestion(ctx, 404)
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Excpected ErrNotFound, got %v", err)
+	}
+
+	created, err := c.CreateQuestion(ctx, client.CreateQuestionInput{Text: "What is Go?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	answers, err := c.CreateAnswers(ctx, created.Question.ID, "A language")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Vote(ctx, answers[0].ID, 2)
+	if !errors.Is(err, client.ErrInvalidVote) || !errors.Is(err, client.ErrBadRequest) {
+		t.Errorf("Excpected a 400 InvalidVote, got %v", err)
+	}
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Temporary() {
+		t.Errorf("Excpected an *Error with the status, got %#v", err)
+	}
+
+	_, err = newClient(t, ts.URL).MarkDuplicate(ctx, 1, 2, `"etag"`)
+	if !errors.Is(err, client.ErrUserRequired) {
+		t.Errorf("Excpected ErrUserRequired without WithUser, got %v", err)
+	}
+	if _, err := client.New(ts.URL, client.WithUser("not-a-uuid")); !errors.Is(err, client.ErrInvalidUser) {
+		t.Errorf("Excpected ErrInvalidUser, got %v", err)
+	}
+}
+
+func TestClientSendsToken(t *testing.T) {
+	cfg := &config.ServerConfig{AdminToken: "secret"}
+	ts := newServer(t, cfg, staticJobs{{Name: "views_flush", Runs: 3}})
+	ctx := context.Background()
+
+	jobs, err := newClient(t, ts.URL, client.WithToken("secret")).Jobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Name != "views_flush" || jobs[0].Runs != 3 {
+		t.Errorf("Excpected the views_flush job, got %+v", jobs)
+	}
+
+	_, err = newClient(t, ts.URL, client.WithToken("wrong")).Jobs(ctx)
+	if !errors.Is(err, client.ErrUnauthorized) || !errors.Is(err, client.ErrAdminTokenRequired) {
+		t.Errorf("Excpected a 401 AdminTokenRequired, got %v", err)
+	}
+}
+
+func TestClientRetriesWithFakeServer(t *testing.T) {
+	srv := clienttest.NewServer()
+	defer srv.Close()
+	c := srv.Client(t, client.WithUser(author))
+	ctx := context.Background()
+
+	// A POST is not retried, the server may have applied it.
+	srv.FailNext(http.StatusServiceUnavailable)
+	if _, err := c.CreateQuestion(ctx, client.CreateQuestionInput{Text: "What is Go?"}); err == nil {
+		t.Error("Excpected the 503 of the single attempt")
+	}
+	if srv.Requests() != 1 {
+		t.Errorf("Excpected no retries, got %d requests", srv.Requests())
+	}
+	created, err := c.CreateQuestion(ctx, client.CreateQuestionInput{Text: "What is Go?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := srv.Requests()
+	srv.FailNext(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	if _, err := c.GetQuestion(ctx, created.Question.ID); err != nil {
+		t.Fatal(err)
+	}
+	if srv.Requests() - before != 3 {
+		t.Errorf("Excpected 2 retries, got %d requests", srv.Requests() - before)
+	}
+
+	srv.FailNext(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	_, err = c.GetQuestion(ctx, created.Question.ID)
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError || !apiErr.Temporary() {
+		t.Errorf("Excpected the 500 of the last attempt, got %v", err)
+	}
+
+	// Client errors are not retried.
+	before = srv.Requests()
+	if _, err := c.GetAnswer(ctx, 404); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Excpected ErrNotFound, got %v", err)
+	}
+	if srv.Requests() != before + 1 {
+		t.Errorf("Excpected a single request, got %d", srv.Requests() - before)
+	}
+}
+
+func TestClientBatchQuestions(t *testing.T) {
+	cfg := &config.ServerConfig{AdminToken: "secret", Batch: config.BatchConfig{MaxOperations: 10, MaxBytes: 1 << 20}}
+	ts := newServer(t, cfg, nil)
+	c := newClient(t, ts.URL, client.WithToken("secret"))
+	ctx := context.Background()
+
+	results, err := c.BatchQuestions(ctx, true,
+		client.BatchOperation{Op: client.CreateOperation, Text: "What is Go?", UserID: author},
+		client.BatchOperation{Op: client.CreateOperation, Text: "What is a goroutine?"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Status != http.StatusCreated || results[0].Question.UserID != author {
+		t.Fatalf("Excpected 2 created questions, got %+v", results)
+	}
+
+	results, err = c.BatchQuestions(ctx, true,
+		client.BatchOperation{Op: client.DeleteOperation, ID: results[0].Question.ID},
+		client.BatchOperation{Op: client.DeleteOperation, ID: 404},
+	)
+	if !errors.Is(err, client.ErrBatchNotApplied) || !errors.Is(err, client.ErrConflict) {
+		t.Fatalf("Excpected ErrBatchNotApplied, got %v", err)
+	}
+	if len(results) != 2 || results[0].Status != http.StatusFailedDependency || results[1].Status != http.StatusNotFound {
+		t.Errorf("Excpected the missing question to fail the batch, got %+v", results)
+	}
+
+	_, err = newClient(t, ts.URL).BatchQuestions(ctx, false, client.BatchOperation{Op: client.DeleteOperation, ID: 1})
+	if !errors.Is(err, client.ErrAdminTokenRequired) {
+		t.Errorf("Excpected ErrAdminTokenRequired, got %v", err)
+	}
+}
+
+func TestClientStreams(t *testing.T) {
+	srv := clienttest.NewServer()
+	defer srv.Close()
+	c := srv.Client(t, client.WithUser(author))
+	ctx := context.Background()
+
+	var id int
+	for _, text := range []string{"What is Go?", "What is a goroutine?", "What is a channel?"} {
+		created, err := c.CreateQuestion(ctx, client.CreateQuestionInput{Text: text})
+		if err != nil {
+			t.Fatal(err)
+		}
+		id = created.Question.ID
+	}
+	if _, err := c.CreateAnswers(ctx, id, "A pipe", "A queue"); err != nil {
+		t.Fatal(err)
+	}
+
+	var texts []string
+	for question, err := range c.StreamQuestions(ctx, "") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		texts = append(texts, question.Text)
+		if len(texts) == 2 {
+			break
+		}
+	}
+	if len(texts) != 2 || texts[0] != "What is Go?" {
+		t.Errorf("Excpected the first 2 questions, got %v", texts)
+	}
+
+	var answers []client.Answer
+	for answer, err := range c.QuestionAnswers(ctx, id) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		answers = append(answers, answer)
+	}
+	if len(answers) != 2 || answers[0].QuestionID != id || answers[0].CreatedAt.IsZero() {
+		t.Errorf("Excpected the 2 answers of the question, got %+v", answers)
+	}
+
+	for _, err := range c.QuestionAnswers(ctx, 404) {
+		if !errors.Is(err, client.ErrNotFound) {
+			t.Errorf("Excpected ErrNotFound, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package clienttest serves the Questions and Answers API in memory, for
+// the tests of code using pkg/client:
+//
+//	srv := clienttest.NewServer()
+//	defer srv.Close()
+//	c := srv.Client(t, client.WithUser(userID))
+//
+// The server is the real one, with its handlers and service, over the
+// in-memory storage of the repository tests. It serves the questions,
+// answers, votes and users; the webhooks, attachments, notifications,
+// badges and admin endpoints answer 404. Every user has every privilege.
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
+	serv "github.com/behummble/Questions-answers/internal/handlers/http"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/service"
+	"github.com/behummble/Questions-answers/pkg/client"
+)
+
+type Server struct {
+	*httptest.Server
+
+	// mu serves the requests one at a time, as the in-memory storage is
+	// not safe for concurrent use. The event streams are not held by it.
+	mu sync.Mutex
+	failures []int
+	requests int
+}
+
+func NewServer() *Server {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	answers := mock.NewMockStorageAnswers(16)
+	questions := mock.NewMockStorageQuestions(16, answers)
+	reputation := config.ReputationConfig{AnswerUpvoted: 10, AnswerDownvoted: -2, AnswerAccepted: 15, DownvoteCast: -1}
+	svc := service.NewService(log, questions, answers, mock.NewMockStorageUsers(questions), mock.NewMockStorageComments(questions), reputation, config.DuplicatesConfig{}, nil)
+	handler := serv.NewServer(context.Background(), log, &config.ServerConfig{}, svc, serv.Deps{Events: events.NewHub(16, 16)}).GetHandler()
+
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if strings.HasSuffix(request.URL.Path, "/events") {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests++
+		if len(s.failures) != 0 {
+			status := s.failures[0]
+			s.failures = s.failures[1:]
+			writer.WriteHeader(status)
+			fmt.Fprint(writer, http.StatusText(status))
+			return
+		}
+		handler.ServeHTTP(writer, request)
+	}))
+
+	return s
+}
+
+// Client returns a client of the server, failing tb if opts are invalid.
+// Its retries wait a millisecond, opts may replace them.
+func(s *Server) Client(tb testing.TB, opts ...client.Option) *client.Client {
+	tb.Helper()
+	retry := client.RetryPolicy{MaxAttempts: client.DefaultRetryPolicy.MaxAttempts, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	c, err := client.New(s.URL, append([]client.Option{client.WithHTTPClient(s.Server.Client()), client.WithRetryPolicy(retry)}, opts...)...)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return c
+}
+
+// FailNext answers the next requests with statuses, one each, instead of
+// serving them.
+func(s *Server) FailNext(statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, statuses...)
+}
+
+// Requests counts the requests the server got, including the retries.
+func(s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/behummble/Questions-answers/pkg/qaclient"
+)
+
+// CommentQuestion comments on the question, as the user of WithUser.
+func(c *Client) CommentQuestion(ctx context.Context, questionID int, text string) (*Comment, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Comment Comment `json:"comment"`
+	}
+	body := qaclient.CreateCommentRequest{UserId: user, Text: text}
+	if err := call(c.api.CommentQuestion(ctx, questionID, body)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res.Comment, nil
+}
+
+// CommentAnswer comments on the answer, as the user of WithUser.
+func(c *Client) CommentAnswer(ctx context.Context, answerID int, text string) (*Comment, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Comment Comment `json:"comment"`
+	}
+	body := qaclient.CreateCommentRequest{UserId: user, Text: text}
+	if err := call(c.api.CommentAnswer(ctx, answerID, body)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res.Comment, nil
+}
+
+// QuestionComments iterates over the comments of a question, oldest first.
+func(c *Client) QuestionComments(ctx context.Context, questionID int) iter.Seq2[Comment, error] {
+	return paginate(ctx, c, func(ctx context.Context, limit, offset int) ([]Comment, error) {
+		var res struct {
+			Comments []Comment `json:"comments"`
+		}
+		err := call(c.api.GetQuestionComments(ctx, questionID, &qaclient.GetQuestionCommentsParams{Limit: &limit, Offset: &offset})).into(&res)
+		return res.Comments, err
+	})
+}
+
+// AnswerComments iterates over the comments of an answer, oldest first.
+func(c *Client) AnswerComments(ctx context.Context, answerID int) iter.Seq2[Comment, error] {
+	return paginate(ctx, c, func(ctx context.Context, limit, offset int) ([]Comment, error) {
+		var res struct {
+			Comments []Comment `json:"comments"`
+		}
+		err := call(c.api.GetAnswerComments(ctx, answerID, &qaclient.GetAnswerCommentsParams{Limit: &limit, Offset: &offset})).into(&res)
+		return res.Comments, err
+	})
+}
+
+// DeleteComment deletes a comment of the user of WithUser. Other comments
+// fail with ErrPrivilegeRequired unless the user has the edit_others privilege.
+func(c *Client) DeleteComment(ctx context.Context, id int) error {
+	user, err := c.currentUser()
+	if err != nil {
+		return err
+	}
+	return call(c.api.DeleteComment(ctx, id, &qaclient.DeleteCommentParams{XUserID: user})).check()
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Error is a response of the server with a 4xx or 5xx status. The server
+// answers errors with a plain text code, such as InvalidUser, which is in
+// Code.
+//
+// The Err values match errors with errors.Is: those with a status match any
+// error of that status, those with a code only that code.
+type Error struct {
+	StatusCode int
+	Code string
+}
+
+func(e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("client: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("client: %d %s", e.StatusCode, e.Code)
+}
+
+func(e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.StatusCode != 0 && t.StatusCode != e.StatusCode {
+		return false
+	}
+	return t.Code == "" || t.Code == e.Code
+}
+
+// Temporary reports whether retrying the request may succeed.
+func(e *Error) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+var (
+	ErrBadRequest = &Error{StatusCode: http.StatusBadRequest}
+	ErrUnauthorized = &Error{StatusCode: http.StatusUnauthorized}
+	ErrForbidden = &Error{StatusCode: http.StatusForbidden}
+	ErrNotFound = &Error{StatusCode: http.StatusNotFound}
+	ErrConflict = &Error{StatusCode: http.StatusConflict}
+	ErrPreconditionFailed = &Error{StatusCode: http.StatusPreconditionFailed}
+	ErrTooLarge = &Error{StatusCode: http.StatusRequestEntityTooLarge}
+	ErrTooManyRequests = &Error{StatusCode: http.StatusTooManyRequests}
+
+	ErrInvalidUser = &Error{Code: "InvalidUser"}
+	ErrInvalidVote = &Error{Code: "InvalidVote"}
+	ErrInvalidSort = &Error{Code: "InvalidSort"}
+	ErrInvalidDuplicate = &Error{Code: "InvalidDuplicate"}
+	ErrInvalidMove = &Error{Code: "InvalidMove"}
+	ErrInvalidTags = &Error{Code: "InvalidTags"}
+	ErrInvalidComment = &Error{Code: "InvalidComment"}
+	ErrInvalidWebhook = &Error{Code: "InvalidWebhook"}
+	ErrInvalidPreferences = &Error{Code: "InvalidPreferences"}
+	ErrInvalidAttachment = &Error{Code: "InvalidAttachment"}
+	ErrUnsupportedAttachmentType = &Error{Code: "UnsupportedAttachmentType"}
+	ErrPrivilegeRequired = &Error{Code: "PrivilegeRequired"}
+	ErrUserRequired = &Error{Code: "UserRequired"}
+	ErrAdminTokenRequired = &Error{Code: "AdminTokenRequired"}
+	ErrDuplicateQuestion = &Error{Code: "DuplicateQuestion"}
+	ErrVersionMismatch = &Error{Code: "VersionMismatch"}
+	ErrInvalidBatch = &Error{Code: "InvalidBatch"}
+	ErrBatchTooLarge = &Error{Code: "BatchTooLarge"}
+	ErrBatchNotApplied = &Error{Code: "BatchNotApplied"}
+)
+
+// newError reads the code of an error response. Codes may carry a detail
+// after a colon, such as PrivilegeRequired: downvote, which is dropped.
+func newError(res *http.Response) *Error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1 << 10))
+	code := strings.TrimSpace(string(body))
+	if strings.HasPrefix(code, "{") || strings.HasPrefix(code, "<") {
+		code = ""
+	}
+	if name, _, found := strings.Cut(code, ":"); found {
+		code = name
+	}
+	return &Error{StatusCode: res.StatusCode, Code: code}
+}
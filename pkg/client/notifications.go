@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/behummble/Questions-answers/pkg/qaclient"
+)
+
+// Notifications iterates over the notifications of the user of WithUser,
+// newest first, or over the unread ones only.
+func(c *Client) Notifications(ctx context.Context, unread bool) iter.Seq2[Notification, error] {
+	return paginate(ctx, c, func(ctx context.Context, limit, offset int) ([]Notification, error) {
+		user, err := c.currentUser()
+		if err != nil {
+			return nil, err
+		}
+		params := &qaclient.GetNotificationsParams{XUserID: user, Limit: &limit, Offset: &offset}
+		if unread {
+			params.Unread = &unread
+		}
+		var res struct {
+			Notifications []Notification `json:"notifications"`
+		}
+		err = call(c.api.GetNotifications(ctx, params)).into(&res)
+		return res.Notifications, err
+	})
+}
+
+// MarkAllNotificationsRead marks every notification of the user of
+// WithUser as read and returns how many it marked.
+func(c *Client) MarkAllNotificationsRead(ctx context.Context) (int, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return 0, err
+	}
+	var res struct {
+		Marked int `json:"marked"`
+	}
+	if err := call(c.api.MarkAllNotificationsRead(ctx, &qaclient.MarkAllNotificationsReadParams{XUserID: user})).into(&res); err != nil {
+		return 0, err
+	}
+	return res.Marked, nil
+}
+
+func(c *Client) MarkNotificationRead(ctx context.Context, id int) error {
+	user, err := c.currentUser()
+	if err != nil {
+		return err
+	}
+	return call(c.api.MarkNotificationRead(ctx, id, &qaclient.MarkNotificationReadParams{XUserID: user})).check()
+}
+
+func(c *Client) NotificationPreferences(ctx context.Context) (*NotificationPreferences, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res NotificationPreferences
+	if err := call(c.api.GetNotificationPreferences(ctx, &qaclient.GetNotificationPreferencesParams{XUserID: user})).into(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpdateNotificationPreferencesInput changes the preferences that are not
+// nil. Digest is off, daily or weekly, and needs an Email.
+type UpdateNotificationPreferencesInput struct {
+	Answers *bool `json:"answers,omitempty"`
+	Accepted *bool `json:"accepted,omitempty"`
+	Followed *bool `json:"followed,omitempty"`
+	Comments *bool `json:"comments,omitempty"`
+	Email *string `json:"email,omitempty"`
+	Digest *string `json:"digest,omitempty"`
+}
+
+func(c *Client) UpdateNotificationPreferences(ctx context.Context, input UpdateNotificationPreferencesInput) (*NotificationPreferences, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	body, err := jsonBody(input)
+	if err != nil {
+		return nil, err
+	}
+	params := &qaclient.UpdateNotificationPreferencesParams{XUserID: user}
+	var res NotificationPreferences
+	if err := call(c.api.UpdateNotificationPreferencesWithBody(ctx, params, "application/json", body)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowTag lists the unanswered questions with the tag in the digests of
+// the user of WithUser.
+func(c *Client) FollowTag(ctx context.Context, tag string) error {
+	user, err := c.currentUser()
+	if err != nil {
+		return err
+	}
+	return call(c.api.FollowTag(ctx, tag, &qaclient.FollowTagParams{XUserID: user})).check()
+}
+
+func(c *Client) UnfollowTag(ctx context.Context, tag string) error {
+	user, err := c.currentUser()
+	if err != nil {
+		return err
+	}
+	return call(c.api.UnfollowTag(ctx, tag, &qaclient.UnfollowTagParams{XUserID: user})).check()
+}
+
+func(c *Client) FollowedTags(ctx context.Context) ([]string, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Tags []string `json:"tags"`
+	}
+	if err := call(c.api.GetFollowedTags(ctx, &qaclient.GetFollowedTagsParams{XUserID: user})).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Tags, nil
+}
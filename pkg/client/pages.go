@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+// paginate iterates over a listing with limit and offset, fetching a page
+// of c.pageSize items at a time. It ends after a short page or the first
+// error, which it yields with a zero item. Items added or removed while it
+// runs may be seen twice or skipped, as with any offset pagination.
+func paginate[T any](ctx context.Context, c *Client, fetch func(ctx context.Context, limit, offset int) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for offset := 0; ; offset += c.pageSize {
+			items, err := fetch(ctx, c.pageSize, offset)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(items) < c.pageSize {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+	"net/http"
+
+	"github.com/behummble/Questions-answers/pkg/qaclient"
+)
+
+// SortByViews lists the questions with the most views first, instead of
+// the newest first.
+const SortByViews = "views"
+
+// ListQuestions lists every question, newest first or by sort.
+func(c *Client) ListQuestions(ctx context.Context, sort string) ([]Question, error) {
+	params := &qaclient.GetAllQuestionsParams{}
+	if sort != "" {
+		value := qaclient.GetAllQuestionsParamsSort(sort)
+		params.Sort = &value
+	}
+	var res struct {
+		Questions []Question `json:"questions"`
+	}
+	if err := call(c.api.GetAllQuestions(ctx, params)).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Questions, nil
+}
+
+// TrendingQuestions iterates over the questions with the most views of
+// the recent windows.
+func(c *Client) TrendingQuestions(ctx context.Context) iter.Seq2[Question, error] {
+	return paginate(ctx, c, func(ctx context.Context, limit, offset int) ([]Question, error) {
+		var res struct {
+			Questions []Question `json:"questions"`
+		}
+		err := call(c.api.GetTrendingQuestions(ctx, &qaclient.GetTrendingQuestionsParams{Limit: &limit, Offset: &offset})).into(&res)
+		return res.Questions, err
+	})
+}
+
+// GetQuestion returns a question with its answers. The view it counts is
+// the one of the user of WithUser, if any. A duplicate is followed to the
+// question it duplicates.
+func(c *Client) GetQuestion(ctx context.Context, id int) (*QuestionWithAnswers, error) {
+	var res QuestionWithAnswers
+	r := call(c.api.GetQuestion(ctx, id, nil))
+	if err := r.into(&res); err != nil {
+		return nil, err
+	}
+	res.ETag = r.res.Header.Get("ETag")
+	return &res, nil
+}
+
+type CreateQuestionInput struct {
+	Text string
+	// Tags are stored lowercase; at most 5.
+	Tags []string
+	// Anonymous asks without the user of WithUser as the author.
+	Anonymous bool
+	// Strict refuses the question when it has likely duplicates: the
+	// error is then ErrDuplicateQuestion and the CreatedQuestion has the
+	// Duplicates but no Question.
+	Strict bool
+}
+
+func(c *Client) CreateQuestion(ctx context.Context, input CreateQuestionInput) (*CreatedQuestion, error) {
+	body := qaclient.CreateQuestionRequest{Text: input.Text}
+	if len(input.Tags) != 0 {
+		body.Tags = &input.Tags
+	}
+	if !input.Anonymous && c.user != nil {
+		body.UserId = c.user
+	}
+	params := &qaclient.CreateQuestionParams{}
+	if input.Strict {
+		params.Strict = &input.Strict
+	}
+
+	res, err := c.api.CreateQuestion(ctx, params, body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusConflict {
+		defer res.Body.Close()
+		var created CreatedQuestion
+		if err := json.NewDecoder(io.LimitReader(res.Body, 1 << 20)).Decode(&created); err != nil {
+			return nil, &Error{StatusCode: res.StatusCode, Code: ErrDuplicateQuestion.Code}
+		}
+		return &created, &Error{StatusCode: res.StatusCode, Code: ErrDuplicateQuestion.Code}
+	}
+	var created CreatedQuestion
+	if err := call(res, nil).into(&created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// DeleteQuestion deletes the question if it is unchanged since the ETag of
+// GetQuestion, and fails with ErrPreconditionFailed otherwise.
+func(c *Client) DeleteQuestion(ctx context.Context, id int, etag string) error {
+	return call(c.api.DeleteQuestion(ctx, id, &qaclient.DeleteQuestionParams{IfMatch: etag})).check()
+}
+
+// BatchOperation creates a question with Text, and UserID as its author if
+// set, or deletes the question ID, at Version if set.
+type BatchOperation struct {
+	Op string `json:"op"`
+	Text string `json:"text,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+	ID int `json:"id,omitempty"`
+	Version int `json:"version,omitempty"`
+}
+
+// CreateOperation and DeleteOperation are the values of BatchOperation.Op.
+const (
+	CreateOperation = "create"
+	DeleteOperation = "delete"
+)
+
+// BatchQuestions applies the operations with the admin token of WithToken.
+// An atomic batch applies all of them or none: when one fails the error is
+// ErrBatchNotApplied, returned with the results, which tell which one.
+func(c *Client) BatchQuestions(ctx context.Context, atomic bool, operations ...BatchOperation) ([]BatchResult, error) {
+	body, err := jsonBody(struct {
+		Operations []BatchOperation `json:"operations"`
+	}{Operations: operations})
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.api.BatchQuestionsWithBody(ctx, &qaclient.BatchQuestionsParams{Atomic: &atomic}, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	var batch struct {
+		Results []BatchResult `json:"results"`
+	}
+	if res.StatusCode == http.StatusConflict {
+		defer res.Body.Close()
+		notApplied := &Error{StatusCode: res.StatusCode, Code: ErrBatchNotApplied.Code}
+		if err := json.NewDecoder(io.LimitReader(res.Body, 1 << 20)).Decode(&batch); err != nil {
+			return nil, notApplied
+		}
+		return batch.Results, notApplied
+	}
+	if err := call(res, nil).into(&batch); err != nil {
+		return nil, err
+	}
+	return batch.Results, nil
+}
+
+// MarkDuplicate marks the question id as a duplicate of target, as the
+// moderator of WithUser, if the question is unchanged since the ETag of
+// GetQuestion. It needs the admin token of WithToken.
+func(c *Client) MarkDuplicate(ctx context.Context, id, target int, etag string) (*Question, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Question Question `json:"question"`
+	}
+	if err := call(c.api.MarkDuplicate(ctx, id, target, &qaclient.MarkDuplicateParams{IfMatch: etag}, qaclient.MarkDuplicateRequest{UserId: user})).into(&res); err != nil {
+		return nil, err
+	}
+	return &res.Question, nil
+}
+
+// MergeQuestions moves the answers of the question id to target and marks
+// it as a duplicate, as the moderator of WithUser, if the question is
+// unchanged since the ETag of GetQuestion. It needs the admin token of
+// WithToken.
+func(c *Client) MergeQuestions(ctx context.Context, id, target int, etag string) (*MergedQuestion, error) {
+	user, err := c.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	var res MergedQuestion
+	if err := call(c.api.MergeQuestions(ctx, id, target, &qaclient.MergeQuestionsParams{IfMatch: etag}, qaclient.MergeQuestionRequest{UserId: user})).into(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowQuestion notifies the user of WithUser of the new answers to the
+// question.
+func(c *Client) FollowQuestion(ctx context.Context, id int) error {
+	user, err := c.currentUser()
+	if err != nil {
+		return err
+	}
+	return call(c.api.FollowQuestion(ctx, id, &qaclient.FollowQuestionParams{XUserID: user})).check()
+}
+
+func(c *Client) UnfollowQuestion(ctx context.Context, id int) error {
+	user, err := c.currentUser()
+	if err != nil {
+		return err
+	}
+	return call(c.api.UnfollowQuestion(ctx, id, &qaclient.UnfollowQuestionParams{XUserID: user})).check()
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+	"net/http"
+
+	"github.com/behummble/Questions-answers/pkg/qaclient"
+)
+
+// acceptNDJSON asks the server to stream a listing as newline-delimited
+// JSON.
+func acceptNDJSON(ctx context.Context, req *http.Request) error {
+	req.Header.Set("Accept", "application/x-ndjson")
+	return nil
+}
+
+// stream iterates over the rows of an NDJSON response as they arrive. It
+// ends after the last row or the first error, which it yields with a zero
+// item; a stream the server cut short ends with io.ErrUnexpectedEOF.
+// Stopping the iteration closes the response.
+func stream[T any](fetch func() (*http.Response, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		res, err := fetch()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer res.Body.Close()
+		if res.StatusCode >= http.StatusBadRequest {
+			yield(zero, newError(res))
+			return
+		}
+		decoder := json.NewDecoder(res.Body)
+		for {
+			var row T
+			err := decoder.Decode(&row)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
+
+// StreamQuestions iterates over every question, newest first or by sort,
+// as the server reads them. Unlike ListQuestions it does not hold them all
+// in memory.
+func(c *Client) StreamQuestions(ctx context.Context, sort string) iter.Seq2[Question, error] {
+	return stream[Question](func() (*http.Response, error) {
+		params := &qaclient.GetAllQuestionsParams{}
+		if sort != "" {
+			value := qaclient.GetAllQuestionsParamsSort(sort)
+			params.Sort = &value
+		}
+		return c.api.GetAllQuestions(ctx, params, acceptNDJSON)
+	})
+}
+
+// QuestionAnswers iterates over the answers of the question as the server
+// reads them.
+func(c *Client) QuestionAnswers(ctx context.Context, questionID int) iter.Seq2[Answer, error] {
+	return stream[Answer](func() (*http.Response, error) {
+		return c.api.GetQuestionAnswers(ctx, questionID, nil, acceptNDJSON)
+	})
+}
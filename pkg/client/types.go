@@ -0,0 +1,212 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The types of the API, as /v1 writes them.
+
+type Question struct {
+	ID int `json:"id"`
+	Text string `json:"text"`
+	TextHTML string `json:"text_html"`
+	// UserID is empty for questions asked anonymously.
+	UserID string `json:"user_id"`
+	Version int `json:"version"`
+	ViewCount int `json:"view_count"`
+	DuplicateOf *int `json:"duplicate_of"`
+	Tags []string `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BatchResult is the outcome of a BatchOperation, with the status it would
+// have had on its own route: 201 for a create, 204 for a delete, 424 for
+// the operations of an atomic batch that failed on another one.
+type BatchResult struct {
+	Op string `json:"op"`
+	Status int `json:"status"`
+	Question *Question `json:"question"`
+	Error string `json:"error"`
+}
+
+type SimilarQuestion struct {
+	ID int `json:"id"`
+	Text string `json:"text"`
+	Similarity float64 `json:"similarity"`
+}
+
+type Author struct {
+	ID string `json:"id"`
+	DisplayName string `json:"display_name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type Answer struct {
+	ID int `json:"id"`
+	QuestionID int `json:"question_id"`
+	UserID string `json:"user_id"`
+	Author *Author `json:"author"`
+	Text string `json:"text"`
+	TextHTML string `json:"text_html"`
+	Score int `json:"score"`
+	Accepted bool `json:"accepted"`
+	Version int `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// ETag is set by GetAnswer, for DeleteAnswer.
+	ETag string `json:"-"`
+}
+
+type QuestionWithAnswers struct {
+	Question Question `json:"question"`
+	Answers []Answer `json:"answers"`
+	// ETag covers the question and its answers, for DeleteQuestion.
+	ETag string `json:"-"`
+}
+
+type CreatedQuestion struct {
+	Question Question `json:"question"`
+	// Duplicates are the questions with a similar text.
+	Duplicates []SimilarQuestion `json:"duplicates"`
+}
+
+type MergedQuestion struct {
+	// Question is the target, which now has the answers.
+	Question Question `json:"question"`
+	Merged Question `json:"merged"`
+	Answers []Answer `json:"answers"`
+}
+
+type Vote struct {
+	Answer Answer `json:"answer"`
+	Value int `json:"value"`
+}
+
+type User struct {
+	ID string `json:"id"`
+	DisplayName string `json:"display_name"`
+	Bio string `json:"bio"`
+	AvatarURL string `json:"avatar_url"`
+	Reputation int `json:"reputation"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Profile is a user with the privileges its reputation grants.
+type Profile struct {
+	User User `json:"user"`
+	Privileges []string `json:"privileges"`
+}
+
+type ReputationEvent struct {
+	ID int64 `json:"id"`
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+	Points int `json:"points"`
+	AnswerID int `json:"answer_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Badge struct {
+	ID int `json:"id"`
+	Name string `json:"name"`
+	Title string `json:"title"`
+	Description string `json:"description"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserBadge struct {
+	UserID string `json:"user_id"`
+	BadgeID int `json:"badge_id"`
+	Badge Badge `json:"badge"`
+	AwardedAt time.Time `json:"awarded_at"`
+}
+
+type Notification struct {
+	ID int64 `json:"id"`
+	UserID string `json:"user_id"`
+	Type string `json:"type"`
+	Reason string `json:"reason"`
+	QuestionID int `json:"question_id"`
+	AnswerID int `json:"answer_id"`
+	ActorID *string `json:"actor_id"`
+	ReadAt *time.Time `json:"read_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type NotificationPreferences struct {
+	Answers bool `json:"answers"`
+	Accepted bool `json:"accepted"`
+	Followed bool `json:"followed"`
+	Comments bool `json:"comments"`
+	Email string `json:"email"`
+	// EmailVerified is set once the link mailed to Email was opened;
+	// digests are mailed only then.
+	EmailVerified bool `json:"email_verified"`
+	Digest string `json:"digest"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Attachment struct {
+	ID int `json:"id"`
+	QuestionID *int `json:"question_id"`
+	AnswerID *int `json:"answer_id"`
+	UserID string `json:"user_id"`
+	Filename string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size int64 `json:"size"`
+	// URL is signed and expires, see attachments.url_ttl.
+	URL string `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Comment is on a question or an answer; exactly one of QuestionID and
+// AnswerID is set.
+type Comment struct {
+	ID int `json:"id"`
+	QuestionID *int `json:"question_id"`
+	AnswerID *int `json:"answer_id"`
+	UserID string `json:"user_id"`
+	Text string `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Webhook struct {
+	ID int `json:"id"`
+	URL string `json:"url"`
+	Events []string `json:"events"`
+	Active bool `json:"active"`
+	FailureCount int `json:"failure_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID int `json:"id"`
+	WebhookID int `json:"webhook_id"`
+	EventID int64 `json:"event_id"`
+	EventType string `json:"event_type"`
+	Payload json.RawMessage `json:"payload"`
+	Status string `json:"status"`
+	Attempts int `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastStatusCode int `json:"last_status_code"`
+	LastError string `json:"last_error"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Job struct {
+	Name string `json:"name"`
+	Schedule string `json:"schedule"`
+	Local bool `json:"local"`
+	Leader bool `json:"leader"`
+	Running bool `json:"running"`
+	Runs int64 `json:"runs"`
+	Failures int64 `json:"failures"`
+	Skipped int64 `json:"skipped"`
+	LastRunAt *time.Time `json:"last_run_at"`
+	LastDurationMs int64 `json:"last_duration_ms"`
+	LastError string `json:"last_error"`
+	NextRunAt *time.Time `json:"next_run_at"`
+}
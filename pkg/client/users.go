@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/behummble/Questions-answers/pkg/qaclient"
+)
+
+func(c *Client) GetUser(ctx context.Context, id string) (*Profile, error) {
+	user, err := parseUser(id)
+	if err != nil {
+		return nil, err
+	}
+	var res Profile
+	if err := call(c.api.GetUser(ctx, user)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpdateUserInput changes the fields that are not nil.
+type UpdateUserInput struct {
+	DisplayName *string `json:"display_name,omitempty"`
+	Bio *string `json:"bio,omitempty"`
+	AvatarURL *string `json:"avatar_url,omitempty"`
+}
+
+func(c *Client) UpdateUser(ctx context.Context, id string, input UpdateUserInput) (*Profile, error) {
+	user, err := parseUser(id)
+	if err != nil {
+		return nil, err
+	}
+	body, err := jsonBody(input)
+	if err != nil {
+		return nil, err
+	}
+	var res Profile
+	if err := call(c.api.UpdateUserWithBody(ctx, user, "application/json", body)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UserAnswers iterates over the answers of a user, newest first.
+func(c *Client) UserAnswers(ctx context.Context, id string) iter.Seq2[Answer, error] {
+	return paginate(ctx, c, func(ctx context.Context, limit, offset int) ([]Answer, error) {
+		user, err := parseUser(id)
+		if err != nil {
+			return nil, err
+		}
+		var res struct {
+			Answers []Answer `json:"answers"`
+		}
+		err = call(c.api.GetUserAnswers(ctx, user, &qaclient.GetUserAnswersParams{Limit: &limit, Offset: &offset})).into(&res)
+		return res.Answers, err
+	})
+}
+
+// UserQuestions iterates over the questions of a user, newest first.
+func(c *Client) UserQuestions(ctx context.Context, id string) iter.Seq2[Question, error] {
+	return paginate(ctx, c, func(ctx context.Context, limit, offset int) ([]Question, error) {
+		user, err := parseUser(id)
+		if err != nil {
+			return nil, err
+		}
+		var res struct {
+			Questions []Question `json:"questions"`
+		}
+		err = call(c.api.GetUserQuestions(ctx, user, &qaclient.GetUserQuestionsParams{Limit: &limit, Offset: &offset})).into(&res)
+		return res.Questions, err
+	})
+}
+
+// Reputation iterates over the reputation ledger of a user, newest first.
+// The total is the Reputation of GetUser.
+func(c *Client) Reputation(ctx context.Context, id string) iter.Seq2[ReputationEvent, error] {
+	return paginate(ctx, c, func(ctx context.Context, limit, offset int) ([]ReputationEvent, error) {
+		user, err := parseUser(id)
+		if err != nil {
+			return nil, err
+		}
+		var res struct {
+			Events []ReputationEvent `json:"events"`
+		}
+		err = call(c.api.GetReputation(ctx, user, &qaclient.GetReputationParams{Limit: &limit, Offset: &offset})).into(&res)
+		return res.Events, err
+	})
+}
+
+// Leaderboard iterates over the users by reputation, highest first.
+func(c *Client) Leaderboard(ctx context.Context) iter.Seq2[User, error] {
+	return paginate(ctx, c, func(ctx context.Context, limit, offset int) ([]User, error) {
+		var res struct {
+			Users []User `json:"users"`
+		}
+		err := call(c.api.GetLeaderboard(ctx, &qaclient.GetLeaderboardParams{Limit: &limit, Offset: &offset})).into(&res)
+		return res.Users, err
+	})
+}
+
+// Badges lists the badges there are to earn.
+func(c *Client) Badges(ctx context.Context) ([]Badge, error) {
+	var res struct {
+		Badges []Badge `json:"badges"`
+	}
+	if err := call(c.api.GetBadges(ctx)).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Badges, nil
+}
+
+// UserBadges lists the badges a user earned.
+func(c *Client) UserBadges(ctx context.Context, id string) ([]UserBadge, error) {
+	user, err := parseUser(id)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Badges []UserBadge `json:"badges"`
+	}
+	if err := call(c.api.GetUserBadges(ctx, user)).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Badges, nil
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+)
+
+// CreateWebhookInput subscribes URL to Events, every event when empty. The
+// Secret signs the deliveries and is generated when empty.
+type CreateWebhookInput struct {
+	URL string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+	Events []string `json:"events,omitempty"`
+}
+
+// CreateWebhook returns the webhook with its secret, which is not returned
+// again. The webhook calls need the admin token of WithToken.
+func(c *Client) CreateWebhook(ctx context.Context, input CreateWebhookInput) (*Webhook, string, error) {
+	body, err := jsonBody(input)
+	if err != nil {
+		return nil, "", err
+	}
+	var res struct {
+		Webhook Webhook `json:"webhook"`
+		Secret string `json:"secret"`
+	}
+	if err := call(c.api.CreateWebhookWithBody(ctx, "application/json", body)).into(&res); err != nil {
+		return nil, "", err
+	}
+	return &res.Webhook, res.Secret, nil
+}
+
+func(c *Client) Webhooks(ctx context.Context) ([]Webhook, error) {
+	var res struct {
+		Webhooks []Webhook `json:"webhooks"`
+	}
+	if err := call(c.api.GetAllWebhooks(ctx)).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Webhooks, nil
+}
+
+func(c *Client) GetWebhook(ctx context.Context, id int) (*Webhook, error) {
+	var res Webhook
+	if err := call(c.api.GetWebhook(ctx, id)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+type UpdateWebhookInput struct {
+	URL string `json:"url"`
+	Events []string `json:"events,omitempty"`
+	Active bool `json:"active"`
+}
+
+func(c *Client) UpdateWebhook(ctx context.Context, id int, input UpdateWebhookInput) (*Webhook, error) {
+	body, err := jsonBody(input)
+	if err != nil {
+		return nil, err
+	}
+	var res Webhook
+	if err := call(c.api.UpdateWebhookWithBody(ctx, id, "application/json", body)).into(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func(c *Client) DeleteWebhook(ctx context.Context, id int) error {
+	return call(c.api.DeleteWebhook(ctx, id)).check()
+}
+
+// WebhookDeliveries lists the recent deliveries of a webhook.
+func(c *Client) WebhookDeliveries(ctx context.Context, id int) ([]WebhookDelivery, error) {
+	var res struct {
+		Deliveries []WebhookDelivery `json:"deliveries"`
+	}
+	if err := call(c.api.GetWebhookDeliveries(ctx, id)).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Deliveries, nil
+}
+
+// Jobs lists the status of the background jobs. It needs the admin token
+// of WithToken.
+func(c *Client) Jobs(ctx context.Context) ([]Job, error) {
+	var res struct {
+		Jobs []Job `json:"jobs"`
+	}
+	if err := call(c.api.GetJobs(ctx)).into(&res); err != nil {
+		return nil, err
+	}
+	return res.Jobs, nil
+}
@@ -0,0 +1,140 @@
+// Package qaclient is a typed client of the Questions and Answers API,
+// generated from docs/swagger/swagger.yaml. The server passed to
+// NewClientWithResponses is the address of a version, for example
+// http://localhost:8080/v1.
+package qaclient
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config oapi-codegen.yaml ../../docs/swagger/swagger.yaml
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy says how a request that failed is retried.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt, 1 disables the retries.
+	MaxAttempts int
+	// BaseDelay is doubled after each attempt, up to MaxDelay, and jittered.
+	BaseDelay time.Duration
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy retries a request up to three times over about a
+// second.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// WithRetries retries the requests of the client that fail with a network
+// error, 429 or a 5xx, following policy and the Retry-After of the
+// responses. Only idempotent requests are retried: GET, HEAD, PUT, DELETE
+// and OPTIONS, and the POSTs that carry an Idempotency-Key, since the server
+// may have applied a POST whose response was lost. The waits end with the
+// context passed to the methods, so a request is never retried past its
+// deadline. Pass it after WithHTTPClient.
+func WithRetries(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		doer := c.Client
+		if doer == nil {
+			doer = &http.Client{}
+		}
+		c.Client = &retryingDoer{doer: doer, policy: policy}
+		return nil
+	}
+}
+
+type retryingDoer struct {
+	doer HttpRequestDoer
+	policy RetryPolicy
+}
+
+func(d *retryingDoer) Do(request *http.Request) (*http.Response, error) {
+	if d.policy.MaxAttempts <= 1 || !idempotent(request) {
+		return d.doer.Do(request)
+	}
+	var body []byte
+	if request.Body != nil && request.GetBody == nil {
+		var err error
+		if body, err = io.ReadAll(request.Body); err != nil {
+			return nil, err
+		}
+		request.Body.Close()
+	}
+
+	for attempt := 1; ; attempt++ {
+		if body != nil {
+			request.Body = io.NopCloser(bytes.NewReader(body))
+		} else if request.GetBody != nil && attempt > 1 {
+			rewound, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = rewound
+		}
+
+		res, err := d.doer.Do(request)
+		if attempt >= d.policy.MaxAttempts || !retryable(res, err) || request.Context().Err() != nil {
+			return res, err
+		}
+		wait := d.policy.delay(attempt, res)
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func idempotent(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return request.Header.Get("Idempotency-Key") != ""
+}
+
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+// delay is the wait before the attempt after attempt: the Retry-After of
+// the response when it has one, else an exponential backoff. Both are
+// capped at MaxDelay.
+func(p RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay := time.Duration(seconds) * time.Second
+			if p.MaxDelay > 0 && delay > p.MaxDelay {
+				delay = p.MaxDelay
+			}
+			return delay
+		}
+	}
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay / 2 + rand.N(delay / 2 + 1)
+}
+
+// NewRetryingClient is NewClientWithResponses with the DefaultRetryPolicy,
+// applied after opts.
+func NewRetryingClient(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	return NewClientWithResponses(server, append(opts, WithRetries(DefaultRetryPolicy))...)
+}
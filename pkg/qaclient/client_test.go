@@ -0,0 +1,184 @@
+package qaclient_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/behummble/Questions-answers/internal/config"
+	"github.com/behummble/Questions-answers/internal/events"
+	serv "github.com/behummble/Questions-answers/internal/handlers/http"
+	"github.com/behummble/Questions-answers/internal/mock"
+	"github.com/behummble/Questions-answers/internal/service"
+	"github.com/behummble/Questions-answers/pkg/qaclient"
+)
+
+func TestClientAgainstServer(t *testing.T) {
+	answers := mock.NewMockStorageAnswers(10)
+	questions := mock.NewMockStorageQuestions(10, answers)
+	svc := service.NewService(slog.Default(), questions, answers, mock.NewMockStorageUsers(questions), mock.NewMockStorageComments(questions), config.ReputationConfig{}, config.DuplicatesConfig{}, nil)
+	s := serv.NewServer(context.Background(), slog.Default(), &config.ServerConfig{}, svc, serv.Deps{Events: events.NewHub(16, 16)})
+	ts := httptest.NewServer(s.GetHandler())
+	defer ts.Close()
+
+	client, err := qaclient.NewRetryingClient(ts.URL + "/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	created, err := client.CreateQuestionWithResponse(ctx, nil, qaclient.CreateQuestionRequest{Text: "What is Go?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.JSON201 == nil || created.JSON201.Question == nil || created.JSON201.Question.Id == nil {
+		t.Fatalf("Excpected the created question, got %v %s", created.StatusCode(), created.Body)
+	}
+	id := *created.JSON201.Question.Id
+
+	answer, err := client.CreateAnswerWithResponse(ctx, id, qaclient.CreateAnswerRequest{
+		UserId: "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+		Texts: []string{"A language"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer.StatusCode() != http.StatusCreated {
+		t.Fatalf("Excpected 201 for the answer, got %v %s", answer.StatusCode(), answer.Body)
+	}
+
+	res, err := client.GetQuestionWithResponse(ctx, id, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.JSON200 == nil || res.JSON200.Answers == nil || len(*res.JSON200.Answers) != 1 {
+		t.Fatalf("Excpected the question with its answer, got %v %s", res.StatusCode(), res.Body)
+	}
+	if text := (*res.JSON200.Answers)[0].Text; text == nil || *text != "A language" {
+		t.Errorf("Excpected the text of the answer, got %v", text)
+	}
+	if res.JSON200.Question.CreatedAt == nil || res.JSON200.Question.CreatedAt.IsZero() {
+		t.Errorf("Excpected the created_at of the question, got %v", res.JSON200.Question.CreatedAt)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			writer.Header().Set("Retry-After", "0")
+			writer.WriteHeader(http.StatusTooManyRequests)
+		default:
+			writer.Header().Set("Content-Type", "application/json")
+			writer.Write([]byte(`{"badges": []}`))
+		}
+	}))
+	defer ts.Close()
+
+	policy := qaclient.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	client, err := qaclient.NewClientWithResponses(ts.URL, qaclient.WithRetries(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetBadgesWithResponse(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode() != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("Excpected 200 on the third attempt, got %v after %d", res.StatusCode(), calls.Load())
+	}
+}
+
+func TestClientRetriesOnlyIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls.Add(1)
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	policy := qaclient.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	client, err := qaclient.NewClientWithResponses(ts.URL, qaclient.WithRetries(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := client.CreateQuestionWithResponse(ctx, nil, qaclient.CreateQuestionRequest{Text: "What is Go?"}); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Excpected a POST to be sent once, got %d", calls.Load())
+	}
+
+	calls.Store(0)
+	withKey := func(ctx context.Context, request *http.Request) error {
+		request.Header.Set("Idempotency-Key", "create-1")
+		return nil
+	}
+	if _, err := client.CreateQuestionWithResponse(ctx, nil, qaclient.CreateQuestionRequest{Text: "What is Go?"}, withKey); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Excpected a POST with an Idempotency-Key to be retried, got %d", calls.Load())
+	}
+}
+
+func TestClientCapsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if calls.Add(1) == 1 {
+			writer.Header().Set("Retry-After", "3600")
+			writer.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{"badges": []}`))
+	}))
+	defer ts.Close()
+
+	policy := qaclient.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	client, err := qaclient.NewClientWithResponses(ts.URL, qaclient.WithRetries(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	res, err := client.GetBadgesWithResponse(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Errorf("Excpected 200 on the second attempt, got %v", res.StatusCode())
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Excpected Retry-After to be capped at MaxDelay, took %v", elapsed)
+	}
+}
+
+func TestClientRetriesStopWithContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	policy := qaclient.RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: time.Second}
+	client, err := qaclient.NewClientWithResponses(ts.URL, qaclient.WithRetries(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50 * time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.GetBadgesWithResponse(ctx); err == nil {
+		t.Error("Excpected the deadline to end the retries")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Excpected the retries to stop at the deadline, took %v", elapsed)
+	}
+}